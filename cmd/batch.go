@@ -0,0 +1,349 @@
+// batch.go
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/pool"
+)
+
+// batchSummary is the file in the output directory that holds the batchReport
+const batchSummary = "summary.json"
+
+// batchFormats maps the --format values of batch to file extensions
+var batchFormats = map[string]string{"png": ".png", "jpeg": ".jpg", "webp": ".webp"}
+
+func batchCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "batch",
+		Summary: "Screenshot every URL of a file with one browser",
+		Help: "Reads one URL per line, skipping blank lines and lines starting with #.\n" +
+			"Pages load concurrently in one browser; each URL gets a screenshot\n" +
+			"named after its line number and host, and summary.json records the status,\n" +
+			"title, timings and error of every URL. Plugins run onStart and onExit\n" +
+			"once for the whole batch. Exits 1 when any URL fails. Ctrl-C starts\n" +
+			"no more URLs and saves the results of those already started.",
+		Args: []cli.Arg{{Name: "file", Complete: cli.CompleteFile, Usage: "file of URLs, or - for stdin"}},
+		Flags: append(navigationFlags(),
+			cli.Flag{Name: "concurrency", Value: "n", Default: "4", Usage: "pages loading at the same time"},
+			cli.Flag{Name: "browsers", Value: "n", Default: "1", Usage: "browsers to spread the pages over"},
+			cli.Flag{Name: "max-uses", Value: "n", Default: "0", Usage: "replace a browser after it has loaded n pages; 0 keeps it"},
+			cli.Flag{Name: "out-dir", Value: "dir", Default: "batch", Complete: cli.CompleteFile, Usage: "where to write screenshots and summary.json"},
+			cli.Flag{Name: "format", Value: "format", Default: "png", Values: []string{"png", "jpeg", "webp"}, Usage: "screenshot format"},
+			cli.Flag{Name: "full-page", Bool: true, Usage: "capture whole scrollable pages instead of the viewport"},
+		),
+		Examples: []string{
+			"phantom-vite batch urls.txt --concurrency 8",
+			"phantom-vite batch urls.txt --out-dir shots --format jpeg --full-page --output json",
+			"grep -v staging sites.txt | phantom-vite batch - --engine cdp",
+		},
+		Run: runBatch,
+	}
+}
+
+func runBatch(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	var concurrency, browsers, maxUses int
+	for _, f := range []struct {
+		name string
+		n    *int
+		min  int
+	}{{"concurrency", &concurrency, 1}, {"browsers", &browsers, 1}, {"max-uses", &maxUses, 0}} {
+		if *f.n, err = ctx.Int(f.name); err != nil {
+			return err
+		}
+		if *f.n < f.min {
+			return ctx.Usagef("--%s must be at least %d, got %d", f.name, f.min, *f.n)
+		}
+	}
+	urls, err := readURLs(ctx.Arg("file"))
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("no URLs in %s", ctx.Arg("file"))
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+	dir := ctx.String("out-dir")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	log := logOutput(ctx)
+	r := &batchReport{Engine: cfg.Engine, Dir: dir, Results: make([]batchResult, len(urls))}
+	fmt.Fprintf(log, "🚀 Opening %d URLs with %s engine, %d at a time...\n", len(urls), cfg.Engine, concurrency)
+
+	start := time.Now()
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, cfg.Engine, "batch")
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	b := &batch{
+		cfg:  cfg,
+		nav:  navigationOptions(ctx, cfg),
+		shot: engine.ScreenshotOptions{Format: ctx.String("format"), FullPage: ctx.Bool("full-page")},
+		dir:  dir,
+		log:  log,
+	}
+	launch := pool.Open(cfg.Engine, cfg.EngineConfig())
+	b.pages, err = pool.New(pool.Config{
+		Browsers:        browsers,
+		PagesPerBrowser: (concurrency + browsers - 1) / browsers,
+		MaxPages:        concurrency,
+		MaxUses:         maxUses,
+		Launch: func(ctx context.Context) (engine.Engine, error) {
+			step := time.Now()
+			defer func() { b.launched(time.Since(step)) }()
+			return launch(ctx)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer b.pages.Close()
+
+	// The first page launches a browser, so a broken engine fails the
+	// command instead of every URL.
+	first, err := b.pages.Acquire(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	first.Release()
+
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	started := b.run(stop, urls, r.Results, min(concurrency, len(urls)))
+	if started < len(urls) {
+		fmt.Fprintf(log, "🛑 Stopped; saving the results of %d of %d URLs\n", started, len(urls))
+		r.Results = r.Results[:started]
+	}
+	r.Timings.Launch = b.launch
+	r.Timings.Total = milliseconds(time.Since(start))
+	r.Pool = b.pages.Stats()
+
+	for _, res := range r.Results {
+		if res.Status == batchOK {
+			r.OK++
+		} else {
+			r.Failed++
+		}
+	}
+	if err := writeBatchSummary(r); err != nil {
+		return err
+	}
+	if err := ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+	if err := writeReport(ctx, r); err != nil {
+		return err
+	}
+	if r.Failed > 0 {
+		return fmt.Errorf("%d of %d URLs failed; see %s", r.Failed, len(urls), filepath.Join(dir, batchSummary))
+	}
+	if started < len(urls) {
+		return fmt.Errorf("stopped after %d of %d URLs; see %s", started, len(urls), filepath.Join(dir, batchSummary))
+	}
+	return nil
+}
+
+// batchURL is a URL of a batch file and the line it is on
+type batchURL struct {
+	line int
+	url  string
+}
+
+// readURLs reads the URLs of file, or of stdin for "-"
+func readURLs(file string) ([]batchURL, error) {
+	var in io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	var urls []batchURL
+	scanner := bufio.NewScanner(in)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			urls = append(urls, batchURL{line: n, url: line})
+		}
+	}
+	return urls, scanner.Err()
+}
+
+func writeBatchSummary(r *batchReport) error {
+	f, err := os.Create(filepath.Join(r.Dir, batchSummary))
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Statuses of a batchResult
+const (
+	batchOK     = "ok"
+	batchFailed = "failed"
+)
+
+// batch captures URLs with pages of a pool
+type batch struct {
+	pages *pool.Pool
+	cfg   config.Config
+	nav   *engine.NavigationOptions
+	shot  engine.ScreenshotOptions
+	dir   string
+
+	mu     sync.Mutex // guards the fields below
+	log    io.Writer
+	done   int
+	launch milliseconds // spent launching browsers
+}
+
+func (b *batch) launched(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.launch += milliseconds(d)
+}
+
+// run captures urls[i] into results[i] with the given number of workers.
+// Once ctx is done it starts no more URLs and waits for those loading. It
+// returns the number of URLs started, whose results are the first ones.
+func (b *batch) run(ctx context.Context, urls []batchURL, results []batchResult, workers int) int {
+	jobs := make(chan int)
+	last := urls[len(urls)-1].line
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b.capture(urls[i], &results[i], len(urls), last)
+			}
+		}()
+	}
+	started := 0
+	for i := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- i:
+			started++
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return started
+}
+
+// capture loads u in a page of the pool and saves its screenshot; last is
+// the line of the file's last URL
+func (b *batch) capture(u batchURL, res *batchResult, total, last int) {
+	start := time.Now()
+	url := u.url
+	res.URL, res.Line = url, u.line
+	err := func() error {
+		page, err := b.pages.Acquire(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to open page: %w", err)
+		}
+		defer page.Release()
+		if err := emulateDevice(page, b.cfg); err != nil {
+			return err
+		}
+		step := time.Now()
+		if err := page.Navigate(url, b.nav); err != nil {
+			return fmt.Errorf("navigation failed: %w", err)
+		}
+		res.Timings.Navigate = milliseconds(time.Since(step))
+		if title, err := page.Title(); err == nil {
+			res.Title = title
+		}
+
+		shot := b.shot
+		shot.Path = filepath.Join(b.dir, artifactName(u.line, last, url)+batchFormats[shot.Format])
+		step = time.Now()
+		if err := page.Screenshot(shot); err != nil {
+			return fmt.Errorf("screenshot failed: %w", err)
+		}
+		res.Screenshot = shot.Path
+		res.Timings.Screenshot = milliseconds(time.Since(step))
+		return nil
+	}()
+	res.Timings.Total = milliseconds(time.Since(start))
+
+	res.Status = batchOK
+	if err != nil {
+		res.Status, res.Error, res.Kind = batchFailed, err.Error(), engine.KindOf(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done++
+	if err != nil {
+		fmt.Fprintf(b.log, "❌ [%d/%d] %s: %v\n", b.done, total, url, err)
+	} else {
+		fmt.Fprintf(b.log, "📸 [%d/%d] %s (%v)\n", b.done, total, url, res.Timings.Total)
+	}
+}
+
+// artifactName names the files of the URL on the given line after the line
+// number, padded to the width of last, and the URL's host and path, e.g.
+// "007-example.com-docs"
+func artifactName(line, last int, url string) string {
+	name := url
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		name = rest
+	}
+	name = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.Trim(name, "-")
+	if len(name) > 80 {
+		name = strings.TrimRight(name[:80], "-")
+	}
+	width := len(strconv.Itoa(last))
+	return fmt.Sprintf("%0*d-%s", max(width, 3), line, name)
+}
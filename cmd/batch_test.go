@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+var (
+	testEngineMu sync.Mutex
+	testEngine   *enginetest.Engine
+)
+
+func init() {
+	engine.Register(engine.Driver{
+		Name:        "fake",
+		Description: "In-memory engine for tests",
+		New: func() engine.Engine {
+			testEngineMu.Lock()
+			defer testEngineMu.Unlock()
+			if testEngine != nil {
+				return testEngine
+			}
+			return &enginetest.Engine{}
+		},
+		Probe: func() engine.EngineStatus { return engine.EngineStatus{Name: "fake", Available: true} },
+	})
+	engine.Register(engine.Driver{
+		Name:  "missing",
+		New:   func() engine.Engine { return &enginetest.Engine{} },
+		Probe: func() engine.EngineStatus { return engine.EngineStatus{Name: "missing", Error: "not installed"} },
+	})
+}
+
+// useEngine makes the fake driver open eng until the test ends. In tests
+// that do not call it, the driver opens a new enginetest.Engine each time.
+func useEngine(t *testing.T, eng *enginetest.Engine) {
+	t.Helper()
+	testEngineMu.Lock()
+	defer testEngineMu.Unlock()
+	testEngine = eng
+	t.Cleanup(func() {
+		testEngineMu.Lock()
+		defer testEngineMu.Unlock()
+		testEngine = nil
+	})
+}
+
+// chdir moves the test into dir, away from the repository's config file
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestBatch(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	urls := "# deploy 42\nhttps://example.com/\n\nhttps://slow.example.com\nhttps://example.com/docs?page=2\n"
+	os.WriteFile("urls.txt", []byte(urls), 0644)
+	eng := &enginetest.Engine{}
+	useEngine(t, eng)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"batch", "urls.txt", "--engine", "fake", "--concurrency", "2", "--out-dir", "shots", "--format", "jpeg", "--wait-until", "networkidle0", "--output", "json"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitError {
+		t.Fatalf("expected exit code %d for a failed URL, got %d\n%s", cli.ExitError, code, stderr.String())
+	}
+	if eng.Opened() > 2 {
+		t.Errorf("expected at most 2 pages, opened %d", eng.Opened())
+	}
+
+	summary, err := os.ReadFile(filepath.Join("shots", batchSummary))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(summary, stdout.Bytes()) {
+		t.Errorf("expected stdout to match summary.json:\n%s\n%s", stdout.String(), summary)
+	}
+	var r batchReport
+	if err := json.Unmarshal(summary, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.OK != 2 || r.Failed != 1 || len(r.Results) != 3 {
+		t.Fatalf("expected 2 ok and 1 failed, got %+v", r)
+	}
+	if r.Pool.Launched != 1 || r.Pool.Acquired != 4 || r.Pool.InUse != 0 {
+		t.Errorf("expected one browser and a page for the warm-up and each URL, got %+v", r.Pool)
+	}
+	want := []batchResult{
+		{URL: "https://example.com/", Line: 2, Status: batchOK, Title: "Title of https://example.com/", Screenshot: filepath.Join("shots", "002-example.com.jpg")},
+		{URL: "https://slow.example.com", Line: 4, Status: batchFailed, Kind: engine.KindNavigationTimeout},
+		{URL: "https://example.com/docs?page=2", Line: 5, Status: batchOK, Title: "Title of https://example.com/docs?page=2", Screenshot: filepath.Join("shots", "005-example.com-docs-page-2.jpg")},
+	}
+	for i, got := range r.Results {
+		got.Error, got.Timings = "", batchResultTimings{}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("result %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+	if !strings.Contains(r.Results[1].Error, "networkidle0") {
+		t.Errorf("expected the navigation error, got %q", r.Results[1].Error)
+	}
+	if data, _ := os.ReadFile(r.Results[0].Screenshot); string(data) != "jpeg" {
+		t.Errorf("expected a jpeg screenshot, got %q", data)
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	os.WriteFile("empty.txt", []byte("# nothing yet\n"), 0644)
+
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"batch", "empty.txt", "--engine", "fake"}, cli.ExitError},
+		{[]string{"batch", "missing.txt", "--engine", "fake"}, cli.ExitError},
+		{[]string{"batch", "empty.txt", "--concurrency", "0"}, cli.ExitUsage},
+		{[]string{"batch", "empty.txt", "--browsers", "0"}, cli.ExitUsage},
+		{[]string{"batch", "empty.txt", "--max-uses", "-1"}, cli.ExitUsage},
+		{[]string{"batch", "empty.txt", "--format", "gif"}, cli.ExitUsage},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := cli.Run(newRootCommand(), tc.args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d\n%s", tc.args, tc.code, code, stderr.String())
+		}
+	}
+}
+
+func TestBatchStops(t *testing.T) {
+	stop, cancel := context.WithCancel(context.Background())
+	cancel()
+	urls := []batchURL{{line: 1, url: "https://example.com/"}}
+	if started := (&batch{}).run(stop, urls, make([]batchResult, 1), 1); started != 0 {
+		t.Errorf("expected a stopped batch to start no URLs, started %d", started)
+	}
+}
+
+func TestArtifactName(t *testing.T) {
+	for _, tc := range []struct {
+		line, last int
+		url        string
+		want       string
+	}{
+		{1, 5, "https://example.com", "001-example.com"},
+		{42, 1200, "http://example.com:8080/a/b?q=1#top", "0042-example.com-8080-a-b-q-1-top"},
+		{3, 3, "example.com/" + strings.Repeat("x", 100), "003-example.com-" + strings.Repeat("x", 68)},
+	} {
+		if got := artifactName(tc.line, tc.last, tc.url); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.url, tc.want, got)
+		}
+	}
+}
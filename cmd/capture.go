@@ -0,0 +1,255 @@
+// capture.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+)
+
+// navigationFlags are the flags of commands that load a page before they
+// capture it
+func navigationFlags() []cli.Flag {
+	return []cli.Flag{
+		{Name: "wait-until", Value: "event", Default: "load", Values: engine.WaitUntilEvents,
+			Usage: "page event that ends navigation; networkidle0 and networkidle2 wait until at most 0 or 2 requests are in flight for 500ms"},
+		{Name: "wait-for-selector", Value: "selector", Usage: "also wait for an element matching this CSS selector"},
+	}
+}
+
+// navigationOptions builds the options of the navigation flags. --timeout
+// bounds the whole navigation, including the wait for --wait-for-selector.
+func navigationOptions(ctx *cli.Context, cfg config.Config) *engine.NavigationOptions {
+	return &engine.NavigationOptions{
+		Timeout:         time.Duration(cfg.Timeout),
+		WaitUntil:       ctx.String("wait-until"),
+		WaitForSelector: ctx.String("wait-for-selector"),
+	}
+}
+
+// screenshotFlags are the flags of commands that save a screenshot
+func screenshotFlags() []cli.Flag {
+	return []cli.Flag{
+		{Name: "screenshot", Value: "file", Default: "screenshot.png", Complete: cli.CompleteFile,
+			Usage: "where to save the screenshot; the extension picks the format: .png, .jpg or .webp"},
+		{Name: "full-page", Bool: true, Usage: "capture the whole scrollable page instead of the viewport"},
+		{Name: "clip", Value: "x,y,w,h", Usage: "capture only this rectangle, in CSS pixels"},
+		{Name: "quality", Value: "0-100", Usage: "JPEG or WebP quality"},
+		{Name: "omit-background", Bool: true, Usage: "capture without the default white background (PNG and WebP)"},
+	}
+}
+
+// screenshotOptions builds the options of the screenshot flags. Invalid
+// combinations are usage errors, so they are reported before any engine
+// is launched.
+func screenshotOptions(ctx *cli.Context) (engine.ScreenshotOptions, error) {
+	opts := engine.ScreenshotOptions{
+		Path:           ctx.String("screenshot"),
+		FullPage:       ctx.Bool("full-page"),
+		OmitBackground: ctx.Bool("omit-background"),
+	}
+	format, ok := engine.ScreenshotFormat(opts.Path)
+	if !ok {
+		return opts, ctx.Usagef("--screenshot must end in .png, .jpg, .jpeg or .webp, got %q", opts.Path)
+	}
+	opts.Format = format
+
+	quality, err := ctx.Int("quality")
+	switch {
+	case err != nil:
+		return opts, err
+	case quality < 0 || quality > 100:
+		return opts, ctx.Usagef("--quality must be between 0 and 100, got %d", quality)
+	case ctx.IsSet("quality") && format == "png":
+		return opts, ctx.Usagef("--quality applies to .jpg and .webp screenshots, not PNG")
+	}
+	opts.Quality = quality
+
+	if opts.OmitBackground && format == "jpeg" {
+		return opts, ctx.Usagef("--omit-background needs a .png or .webp screenshot; JPEG has no transparency")
+	}
+
+	if clip := ctx.String("clip"); clip != "" {
+		if opts.Clip, err = parseClip(clip); err != nil {
+			return opts, ctx.Usagef("--clip %v", err)
+		}
+	}
+	return opts, nil
+}
+
+// parseClip parses "x,y,w,h" into a clip rectangle
+func parseClip(s string) (*engine.ClipOptions, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("expects x,y,w,h, got %q", s)
+	}
+	var v [4]float64
+	for i, part := range parts {
+		n, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expects numbers, got %q", part)
+		}
+		v[i] = n
+	}
+	if v[0] < 0 || v[1] < 0 {
+		return nil, fmt.Errorf("x and y must not be negative, got %s", s)
+	}
+	if v[2] <= 0 || v[3] <= 0 {
+		return nil, fmt.Errorf("width and height must be greater than 0, got %s", s)
+	}
+	return &engine.ClipOptions{X: v[0], Y: v[1], Width: v[2], Height: v[3]}, nil
+}
+
+// pdfFlags are the flags of the pdf command
+func pdfFlags() []cli.Flag {
+	return []cli.Flag{
+		{Name: "format", Value: "name", Default: "letter", Values: paperNames(), Usage: "paper size"},
+		{Name: "size", Value: "WxH", Usage: "custom paper size such as 8.5inx11in or 210mmx297mm; overrides --format"},
+		{Name: "margin", Value: "lengths", Usage: "page margins like CSS: 1 to 4 lengths such as 1cm or 0.5in,1in"},
+		{Name: "landscape", Bool: true, Usage: "print in landscape orientation"},
+		{Name: "print-background", Bool: true, Usage: "print background colors and images"},
+		{Name: "scale", Value: "0.1-2", Usage: "zoom of the page content"},
+		{Name: "header-template", Value: "html", Usage: "HTML header of each page; elements of class pageNumber, totalPages, title, url or date are filled in"},
+		{Name: "footer-template", Value: "html", Usage: "HTML footer of each page, like --header-template"},
+		{Name: "page-ranges", Value: "ranges", Usage: "pages to print, such as 1-5,8; every page by default"},
+	}
+}
+
+// paperNames lists the named paper sizes in order
+func paperNames() []string {
+	names := make([]string, 0, len(engine.PaperSizes))
+	for name := range engine.PaperSizes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// inchesPer holds the units lengths may be given in; a bare number is in inches
+var inchesPer = map[string]float64{"in": 1, "cm": 1 / 2.54, "mm": 1 / 25.4}
+
+// pdfOptions builds the options of the pdf flags, saving to path. Like
+// screenshotOptions, mistakes are usage errors.
+func pdfOptions(ctx *cli.Context, path string) (engine.PDFOptions, error) {
+	opts := engine.PDFOptions{
+		Path:            path,
+		Format:          ctx.String("format"),
+		Landscape:       ctx.Bool("landscape"),
+		PrintBackground: ctx.Bool("print-background"),
+		HeaderTemplate:  ctx.String("header-template"),
+		FooterTemplate:  ctx.String("footer-template"),
+	}
+
+	if size := ctx.String("size"); size != "" {
+		w, h, ok := strings.Cut(size, "x")
+		var err error
+		if !ok {
+			return opts, ctx.Usagef("--size expects WxH, got %q", size)
+		}
+		if opts.Width, err = parseLength(w); err == nil {
+			opts.Height, err = parseLength(h)
+		}
+		switch {
+		case err != nil:
+			return opts, ctx.Usagef("--size %v", err)
+		case opts.Width <= 0 || opts.Height <= 0:
+			return opts, ctx.Usagef("--size width and height must be greater than 0, got %s", size)
+		}
+		opts.Format = ""
+	}
+
+	if margin := ctx.String("margin"); margin != "" {
+		m, err := parseMargin(margin)
+		if err != nil {
+			return opts, ctx.Usagef("--margin %v", err)
+		}
+		opts.Margin = m
+	}
+
+	if scale := ctx.String("scale"); scale != "" {
+		n, err := strconv.ParseFloat(scale, 64)
+		switch {
+		case err != nil:
+			return opts, ctx.Usagef("--scale expects a number, got %q", scale)
+		case n < 0.1 || n > 2:
+			return opts, ctx.Usagef("--scale must be between 0.1 and 2, got %s", scale)
+		}
+		opts.Scale = n
+	}
+
+	if ranges := ctx.String("page-ranges"); ranges != "" {
+		if err := checkPageRanges(ranges); err != nil {
+			return opts, ctx.Usagef("--page-ranges %v", err)
+		}
+		opts.PageRanges = ranges
+	}
+	return opts, nil
+}
+
+// parseLength parses a length such as "1in", "2.5cm" or "10mm" into inches
+func parseLength(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	number, scale := s, 1.0
+	for unit, inches := range inchesPer {
+		if strings.HasSuffix(s, unit) {
+			number, scale = strings.TrimSuffix(s, unit), inches
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expects lengths in in, cm or mm, got %q", s)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("must not be negative, got %q", s)
+	}
+	return n * scale, nil
+}
+
+// parseMargin parses one to four comma-separated lengths in the order of
+// the CSS margin property: top, right, bottom, left
+func parseMargin(s string) (*engine.PDFMargin, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) > 4 {
+		return nil, fmt.Errorf("expects 1 to 4 lengths, got %q", s)
+	}
+	v := make([]float64, len(parts))
+	for i, part := range parts {
+		n, err := parseLength(part)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = n
+	}
+	switch len(v) {
+	case 1:
+		return &engine.PDFMargin{Top: v[0], Right: v[0], Bottom: v[0], Left: v[0]}, nil
+	case 2:
+		return &engine.PDFMargin{Top: v[0], Right: v[1], Bottom: v[0], Left: v[1]}, nil
+	case 3:
+		return &engine.PDFMargin{Top: v[0], Right: v[1], Bottom: v[2], Left: v[1]}, nil
+	}
+	return &engine.PDFMargin{Top: v[0], Right: v[1], Bottom: v[2], Left: v[3]}, nil
+}
+
+// checkPageRanges accepts comma-separated pages and ranges such as "1-5, 8"
+func checkPageRanges(s string) error {
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(first)
+		to := from
+		if err == nil && isRange {
+			to, err = strconv.Atoi(last)
+		}
+		if err != nil || from < 1 || to < from {
+			return fmt.Errorf("expects pages or ranges such as 1-5,8, got %q", part)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+func TestNavigationOptions(t *testing.T) {
+	ctx, err := newRootCommand().Parse([]string{"pdf", "https://example.com", "--timeout", "5s", "--wait-until", "networkidle2", "--wait-for-selector", "#app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &engine.NavigationOptions{Timeout: 5 * time.Second, WaitUntil: "networkidle2", WaitForSelector: "#app"}
+	if got := navigationOptions(ctx, resolved.Config); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if _, err := newRootCommand().Parse([]string{"open", "https://example.com", "--wait-until", "idle"}); err == nil {
+		t.Errorf("expected an unknown --wait-until to be a usage error")
+	}
+}
+
+func TestScreenshotOptions(t *testing.T) {
+	for _, tc := range []struct {
+		flags []string
+		want  engine.ScreenshotOptions
+	}{
+		{nil, engine.ScreenshotOptions{Path: "screenshot.png", Format: "png"}},
+		{[]string{"--screenshot", "out.webp", "--full-page", "--quality", "80", "--omit-background"},
+			engine.ScreenshotOptions{Path: "out.webp", Format: "webp", Quality: 80, FullPage: true, OmitBackground: true}},
+		{[]string{"--screenshot=shots/a.JPG", "--clip", "10, 20.5,300,200"},
+			engine.ScreenshotOptions{Path: "shots/a.JPG", Format: "jpeg", Clip: &engine.ClipOptions{X: 10, Y: 20.5, Width: 300, Height: 200}}},
+	} {
+		ctx, err := newRootCommand().Parse(append([]string{"open", "https://example.com"}, tc.flags...))
+		if err != nil {
+			t.Fatalf("%q: %v", tc.flags, err)
+		}
+		got, err := screenshotOptions(ctx)
+		if err != nil {
+			t.Errorf("%q: %v", tc.flags, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: expected %+v, got %+v", tc.flags, tc.want, got)
+		}
+	}
+}
+
+func TestScreenshotOptionErrors(t *testing.T) {
+	for _, tc := range []struct {
+		flags   []string
+		message string
+	}{
+		{[]string{"--screenshot", "out.gif"}, `--screenshot must end in .png, .jpg, .jpeg or .webp, got "out.gif"`},
+		{[]string{"--quality", "80"}, "--quality applies to .jpg and .webp screenshots, not PNG"},
+		{[]string{"--screenshot", "a.jpg", "--quality", "101"}, "--quality must be between 0 and 100, got 101"},
+		{[]string{"--screenshot", "a.jpg", "--quality", "high"}, `flag --quality expects a number, got "high"`},
+		{[]string{"--screenshot", "a.jpg", "--omit-background"}, "--omit-background needs a .png or .webp screenshot; JPEG has no transparency"},
+		{[]string{"--clip", "1,2,3"}, `--clip expects x,y,w,h, got "1,2,3"`},
+		{[]string{"--clip", "1,2,3,x"}, `--clip expects numbers, got "x"`},
+		{[]string{"--clip", "-1,0,10,10"}, "--clip x and y must not be negative, got -1,0,10,10"},
+		{[]string{"--clip", "0,0,0,10"}, "--clip width and height must be greater than 0, got 0,0,0,10"},
+	} {
+		ctx, err := newRootCommand().Parse(append([]string{"open", "https://example.com"}, tc.flags...))
+		if err != nil {
+			t.Fatalf("%q: %v", tc.flags, err)
+		}
+		_, err = screenshotOptions(ctx)
+		var usage *cli.UsageError
+		if !errors.As(err, &usage) || usage.Message != tc.message {
+			t.Errorf("%q: expected usage error %q, got %v", tc.flags, tc.message, err)
+		}
+	}
+}
+
+func TestPDFOptions(t *testing.T) {
+	for _, tc := range []struct {
+		flags []string
+		want  engine.PDFOptions
+	}{
+		{nil, engine.PDFOptions{Path: "page.pdf", Format: "letter"}},
+		{[]string{"--format", "a4", "--landscape", "--print-background", "--scale", "0.5", "--page-ranges", "1-3, 5"},
+			engine.PDFOptions{Path: "page.pdf", Format: "a4", Landscape: true, PrintBackground: true, Scale: 0.5, PageRanges: "1-3, 5"}},
+		{[]string{"--size", "254mmx5in", "--margin", "1in,2.54cm"},
+			engine.PDFOptions{Path: "page.pdf", Width: 10, Height: 5, Margin: &engine.PDFMargin{Top: 1, Right: 1, Bottom: 1, Left: 1}}},
+		{[]string{"--margin", "1,2,3", "--footer-template", "<p></p>"},
+			engine.PDFOptions{Path: "page.pdf", Format: "letter", Margin: &engine.PDFMargin{Top: 1, Right: 2, Bottom: 3, Left: 2}, FooterTemplate: "<p></p>"}},
+	} {
+		ctx, err := newRootCommand().Parse(append([]string{"pdf", "https://example.com"}, tc.flags...))
+		if err != nil {
+			t.Fatalf("%q: %v", tc.flags, err)
+		}
+		got, err := pdfOptions(ctx, "page.pdf")
+		if err != nil {
+			t.Errorf("%q: %v", tc.flags, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: expected %+v, got %+v", tc.flags, tc.want, got)
+		}
+	}
+}
+
+func TestPDFOptionErrors(t *testing.T) {
+	for _, tc := range []struct {
+		flags   []string
+		message string
+	}{
+		{[]string{"--size", "8.5in"}, `--size expects WxH, got "8.5in"`},
+		{[]string{"--size", "8.5ftx11in"}, `--size expects lengths in in, cm or mm, got "8.5ft"`},
+		{[]string{"--size", "0x11in"}, "--size width and height must be greater than 0, got 0x11in"},
+		{[]string{"--margin", "1,2,3,4,5"}, `--margin expects 1 to 4 lengths, got "1,2,3,4,5"`},
+		{[]string{"--margin", "-1cm"}, `--margin must not be negative, got "-1cm"`},
+		{[]string{"--scale", "3"}, "--scale must be between 0.1 and 2, got 3"},
+		{[]string{"--scale", "big"}, `--scale expects a number, got "big"`},
+		{[]string{"--page-ranges", "5-2"}, `--page-ranges expects pages or ranges such as 1-5,8, got "5-2"`},
+		{[]string{"--page-ranges", "1,,2"}, `--page-ranges expects pages or ranges such as 1-5,8, got ""`},
+	} {
+		ctx, err := newRootCommand().Parse(append([]string{"pdf", "https://example.com"}, tc.flags...))
+		if err != nil {
+			t.Fatalf("%q: %v", tc.flags, err)
+		}
+		_, err = pdfOptions(ctx, "page.pdf")
+		var usage *cli.UsageError
+		if !errors.As(err, &usage) || usage.Message != tc.message {
+			t.Errorf("%q: expected usage error %q, got %v", tc.flags, tc.message, err)
+		}
+	}
+}
+
+func TestOpenPageClosesOnFailure(t *testing.T) {
+	eng := &enginetest.Engine{Load: func(url string) error { return errors.New("404 " + url) }}
+	if _, err := openPage(eng, config.Config{}, "https://example.com/missing", nil); err == nil {
+		t.Fatal("expected the navigation to fail")
+	}
+	if open := eng.Open(); open != 0 {
+		t.Errorf("expected the page to be closed, %d still open", open)
+	}
+}
@@ -0,0 +1,590 @@
+// commands.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+)
+
+// newRootCommand builds the phantom-vite command tree. The config settings
+// and --output are flags of the root, so every command accepts them.
+func newRootCommand() *cli.Command {
+	root := &cli.Command{
+		Name:    "phantom-vite",
+		Summary: "Headless browser CLI",
+		Help: "🕴️  Phantom Vite - Headless Browser CLI\n\nRun a script, or a command below.\n\n" +
+			"Exit codes: 0 success, 1 failure, 2 usage error, 3 invalid config, 4 engine unavailable,\n" +
+			"5 navigation timeout, 6 script failure, 7 plugin failure.",
+		Args: []cli.Arg{{Name: "script", Optional: true, Complete: cli.CompleteFile,
+			Usage: "JavaScript or TypeScript file to run with the configured engine"}},
+		Flags: append(settingFlags(), outputFlag()),
+		Examples: []string{
+			"phantom-vite open https://example.com",
+			"phantom-vite open https://example.com --engine playwright",
+			"phantom-vite open https://example.com --headless=false --viewport 1280x720 --timeout 10s",
+			"phantom-vite doctor --output json",
+			"phantom-vite build",
+			"phantom-vite script.ts",
+		},
+		Run: runScript,
+		Commands: []*cli.Command{
+			{
+				Name:    "open",
+				Summary: "Open a URL, print its title and save a screenshot",
+				Args:    []cli.Arg{{Name: "url", Usage: "page to open"}},
+				Flags:   append(navigationFlags(), screenshotFlags()...),
+				Examples: []string{
+					"phantom-vite open https://example.com",
+					"phantom-vite open https://example.com --device \"iPhone 12\"",
+					"phantom-vite open https://example.com --screenshot out.webp --full-page --quality 80",
+					"phantom-vite open https://example.com --screenshot hero.png --clip 0,0,1280,600 --omit-background",
+					"phantom-vite open https://example.com --wait-until networkidle0 --wait-for-selector '#app'",
+					"phantom-vite open https://example.com --output json",
+				},
+				Run: runOpen,
+			},
+			{
+				Name:    "pdf",
+				Summary: "Open a URL and save it as a PDF",
+				Args: []cli.Arg{
+					{Name: "url", Usage: "page to open"},
+					{Name: "file", Optional: true, Complete: cli.CompleteFile, Usage: "where to save the PDF (default page.pdf)"},
+				},
+				Flags: append(navigationFlags(), pdfFlags()...),
+				Help:  "Printing to PDF needs a headless browser. The selenium engine has no\nheader or footer templates.",
+				Examples: []string{
+					"phantom-vite pdf https://example.com out.pdf",
+					"phantom-vite pdf https://example.com report.pdf --format a4 --margin 1cm --print-background",
+					"phantom-vite pdf https://example.com --landscape --page-ranges 1-3 --footer-template '<span class=\"pageNumber\"></span>'",
+				},
+				Run: runPDF,
+			},
+			batchCommand(),
+			crawlCommand(),
+			scrapeCommand(),
+			serverCommand(),
+			testCommand(),
+			stepsCommand(),
+			{
+				Name:     "build",
+				Summary:  "Build the project with Vite",
+				Examples: []string{"phantom-vite build"},
+				Run:      runBuild,
+			},
+			{
+				Name:     "bundle",
+				Summary:  "Bundle one script with Vite",
+				Args:     []cli.Arg{{Name: "file", Complete: cli.CompleteFile, Usage: "script to bundle into dist/"}},
+				Examples: []string{"phantom-vite bundle scripts/login.ts"},
+				Run:      runBundle,
+			},
+			{
+				Name:     "serve",
+				Summary:  "Preview a build with Vite",
+				Args:     []cli.Arg{{Name: "file", Complete: cli.CompleteFile, Usage: "Vite config to preview"}},
+				Examples: []string{"phantom-vite serve vite.config.js"},
+				Run:      runServe,
+			},
+			{
+				Name:     "doctor",
+				Summary:  "Check runtimes, engines and configuration",
+				Examples: []string{"phantom-vite doctor"},
+				Run:      runDoctor,
+			},
+			{
+				Name:     "engines",
+				Summary:  "List engines and whether they are available",
+				Examples: []string{"phantom-vite engines", "phantom-vite engines --output json"},
+				Run:      runEngines,
+			},
+			{
+				Name:     "agent",
+				Summary:  "Run the Python AI agent with a prompt",
+				Args:     []cli.Arg{{Name: "prompt", Variadic: true}},
+				Examples: []string{`phantom-vite agent "summarize this repo"`},
+				Run:      runAgent,
+			},
+			{
+				Name:     "gemini",
+				Summary:  "Pass a prompt to the Gemini CLI",
+				Args:     []cli.Arg{{Name: "prompt", Variadic: true}},
+				Examples: []string{`phantom-vite gemini "generate a blog post on Go concurrency"`},
+				Run:      runGemini,
+			},
+			{
+				Name:     "plugins",
+				Summary:  "List configured plugins",
+				Examples: []string{"phantom-vite plugins"},
+				Run:      runPlugins,
+			},
+			configCommand(),
+			cli.HelpCommand(),
+			cli.CompletionCommand(),
+		},
+	}
+	classifyErrors(root)
+	return root
+}
+
+// settingFlags turns the config settings into root flags
+func settingFlags() []cli.Flag {
+	flags := make([]cli.Flag, len(config.Settings))
+	for i, s := range config.Settings {
+		flags[i] = cli.Flag{Name: s.Flag, Value: s.Value, Usage: s.Usage, Env: s.Env, Bool: s.Bool}
+		switch s.Flag {
+		case "engine":
+			// Drivers without New, such as gemini, only report their status
+			for _, driver := range engine.List() {
+				if driver.New != nil {
+					flags[i].Values = append(flags[i].Values, driver.Name)
+				}
+			}
+		case "executable-path":
+			flags[i].Complete = cli.CompleteFile
+		}
+	}
+	return flags
+}
+
+// configFlags returns the config settings given as flags, in order
+func configFlags(ctx *cli.Context) []config.FlagValue {
+	var flags []config.FlagValue
+	for _, f := range ctx.Set {
+		if s := config.LookupSetting(f.Flag.Name); s != nil {
+			flags = append(flags, config.FlagValue{Setting: s, Value: f.Value})
+		}
+	}
+	return flags
+}
+
+// configError lists every invalid configuration value
+type configError struct{ err error }
+
+func (e *configError) Error() string {
+	return "Invalid configuration:\n  " + strings.ReplaceAll(e.err.Error(), "\n", "\n  ")
+}
+
+func (e *configError) Unwrap() error { return e.err }
+
+func (e *configError) ErrorKind() engine.ErrorKind { return engine.KindConfig }
+
+// loadConfig resolves the layered configuration with the command's flags
+func loadConfig(ctx *cli.Context) (*config.Resolved, error) {
+	resolved, err := config.Resolver{Flags: configFlags(ctx)}.Resolve()
+	if err != nil {
+		return nil, &configError{err}
+	}
+	return resolved, nil
+}
+
+func runDoctor(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+
+	r := &doctorReport{
+		Runtimes: []runtimeStatus{
+			{Name: "go", Available: exec.Command("go", "version").Run() == nil},
+			{Name: "node", Available: exec.Command("node", "--version").Run() == nil},
+			{Name: "python", Available: exec.Command(resolveCommand("python3"), "--version").Run() == nil},
+		},
+		Engines: checkEngineStatus(),
+		Config: doctorConfig{
+			Engine:   cfg.Engine,
+			Headless: cfg.Headless,
+			Viewport: cfg.Viewport,
+			Timeout:  milliseconds(cfg.Timeout),
+		},
+	}
+	return writeReport(ctx, r)
+}
+
+func runOpen(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	shot, err := screenshotOptions(ctx)
+	if err != nil {
+		return err
+	}
+	r := &openReport{pageReport: pageReport{URL: ctx.Arg("url"), Engine: cfg.Engine}}
+
+	var t visitTimings
+	err = visitPage(ctx, cfg, "open", &r.pageReport, &t, func(page engine.Page) error {
+		if err := page.Screenshot(shot); err != nil {
+			return fmt.Errorf("screenshot failed: %w", err)
+		}
+		r.Screenshot = shot.Path
+		return nil
+	})
+	r.Timings = openTimings{Launch: t.launch, Navigate: t.navigate, Screenshot: t.save, Total: t.total}
+	return finishPageReport(ctx, r, &r.pageReport, err)
+}
+
+func runPDF(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	path := ctx.Arg("file")
+	if path == "" {
+		path = "page.pdf"
+	}
+	opts, err := pdfOptions(ctx, path)
+	if err != nil {
+		return err
+	}
+	r := &pdfReport{pageReport: pageReport{URL: ctx.Arg("url"), Engine: cfg.Engine}}
+
+	var t visitTimings
+	err = visitPage(ctx, cfg, "pdf", &r.pageReport, &t, func(page engine.Page) error {
+		if err := page.PDF(opts); err != nil {
+			return fmt.Errorf("pdf failed: %w", err)
+		}
+		r.PDF = opts.Path
+		return nil
+	})
+	r.Timings = pdfTimings{Launch: t.launch, Navigate: t.navigate, PDF: t.save, Total: t.total}
+	return finishPageReport(ctx, r, &r.pageReport, err)
+}
+
+// finishPageReport prints report unless err fails the command in text
+// mode; in JSON mode the report is printed with the error in page
+func finishPageReport(ctx *cli.Context, report report, page *pageReport, err error) error {
+	if err != nil && !jsonOutput(ctx) {
+		return err
+	}
+	if err != nil {
+		page.Error, page.Kind = err.Error(), engine.KindOf(err)
+	}
+	if werr := writeReport(ctx, report); err == nil {
+		err = werr
+	}
+	return err
+}
+
+// visitTimings are the durations of the steps of visitPage
+type visitTimings struct {
+	launch, navigate, save, total milliseconds
+}
+
+// visitPage opens r.URL, hands the loaded page to save and fills in r and t
+// as it goes. command names the command to plugins.
+func visitPage(ctx *cli.Context, cfg config.Config, command string, r *pageReport, t *visitTimings, save func(engine.Page) error) error {
+	log := logOutput(ctx)
+	if err := validateEngine(r.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	fmt.Fprintf(log, "🚀 Opening %s with %s engine...\n", r.URL, r.Engine)
+
+	start := time.Now()
+	defer func() { t.total = milliseconds(time.Since(start)) }()
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, r.Engine, command)
+	pctx.Meta.URL = r.URL
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	contextPath, err := writeContextFile(cfg, r.URL)
+	if err == nil {
+		os.Setenv("PHANTOM_CONTEXT_PATH", contextPath)
+		defer os.Remove(contextPath)
+	}
+
+	step := time.Now()
+	eng, err := engine.Open(r.Engine, cfg.EngineConfig())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	defer eng.Close()
+	t.launch = milliseconds(time.Since(step))
+
+	step = time.Now()
+	nav := navigationOptions(ctx, cfg)
+	page, err := openPage(eng, cfg, r.URL, nav)
+	switch {
+	case engine.KindOf(err) == engine.KindNavigationTimeout && !jsonOutput(ctx):
+		return fmt.Errorf("navigation timed out after %v: %w\n💡 Raise --timeout, or wait for less with --wait-until", nav.Timeout, err)
+	case engine.KindOf(err) == engine.KindNavigationTimeout:
+		return fmt.Errorf("navigation timed out after %v: %w", nav.Timeout, err)
+	case err != nil:
+		return fmt.Errorf("navigation failed: %w", err)
+	}
+	t.navigate = milliseconds(time.Since(step))
+
+	if err := ExecutePluginHooksWithContext("onPageLoad", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	if title, err := page.Title(); err == nil {
+		r.Title = title
+	}
+	step = time.Now()
+	if err := save(page); err != nil {
+		return err
+	}
+	t.save = milliseconds(time.Since(step))
+
+	return ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log)
+}
+
+func runEngines(ctx *cli.Context) error {
+	r := &enginesReport{}
+	for _, driver := range engine.List() {
+		r.Engines = append(r.Engines, engineInfo{EngineStatus: driver.Status(), Description: driver.Description})
+	}
+	return writeReport(ctx, r)
+}
+
+func runBuild(ctx *cli.Context) error {
+	log := logOutput(ctx)
+	fmt.Fprintln(log, "🔧 [Phantom Vite] Building project...")
+	start := time.Now()
+	if err := runViteBuild(log); err != nil {
+		return fmt.Errorf("build failed: %w", err)
+	}
+	return writeReport(ctx, &buildReport{Timings: buildTimings{Total: milliseconds(time.Since(start))}})
+}
+
+func runBundle(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	inputFile := ctx.Arg("file")
+	if !fileExists(inputFile) {
+		return fmt.Errorf("file not found: %s", inputFile)
+	}
+
+	log := logOutput(ctx)
+	fmt.Fprintf(log, "📦 Bundling %s for %s engine...\n", inputFile, resolved.Config.Engine)
+
+	start := time.Now()
+	if err := runViteBundle(inputFile, log); err != nil {
+		return fmt.Errorf("bundling failed: %w", err)
+	}
+	r := &buildReport{File: inputFile, Engine: resolved.Config.Engine, Timings: buildTimings{Total: milliseconds(time.Since(start))}}
+	return writeReport(ctx, r)
+}
+
+func runServe(ctx *cli.Context) error {
+	if err := textOnly(ctx); err != nil {
+		return err
+	}
+	file := ctx.Arg("file")
+	if !fileExists(file) {
+		return fmt.Errorf("file not found: %s", file)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "🌐 Serving %s...\n", file)
+	cmd := exec.Command("npx", "vite", "preview", "--config", file)
+	cmd.Stdout = ctx.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("serve error: %w", err)
+	}
+	return nil
+}
+
+func runAgent(ctx *cli.Context) error {
+	if err := textOnly(ctx); err != nil {
+		return err
+	}
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	prompt := ctx.Arg("prompt")
+	fmt.Fprintf(ctx.Stdout, "🤖 Launching AI agent with prompt: %s\n", prompt)
+
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, newPluginContext(cfg, cfg.Engine, "agent"), ctx.Stdout); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(resolveCommand("python3"), "python/agent.py", prompt)
+	cmd.Dir = projectDir()
+	cmd.Stdout = ctx.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("agent error: %w", err)
+	}
+	return nil
+}
+
+// projectDir is the directory of the project config file, found by walking
+// up from the working directory, or else the parent of the executable's
+// directory
+func projectDir() string {
+	if path := config.FindProjectFile("."); path != "" {
+		return filepath.Dir(path)
+	}
+	return filepath.Join(filepath.Dir(os.Args[0]), "..")
+}
+
+// projectRuntime is the runtime directory beside the project config file,
+// so commands find the Node runtime from subdirectories of the project; ""
+// when there is no such directory
+func projectRuntime() string {
+	path := config.FindProjectFile(".")
+	if path == "" {
+		return ""
+	}
+	dir := filepath.Join(filepath.Dir(path), "runtime")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+func runGemini(ctx *cli.Context) error {
+	if err := textOnly(ctx); err != nil {
+		return err
+	}
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	prompt := ctx.Arg("prompt")
+	if driver, err := engine.Get("gemini"); err == nil {
+		if status := driver.Status(); !status.Available {
+			return withKind(engine.KindUnavailable, fmt.Errorf("Gemini CLI is not available: %s", status.Error))
+		}
+	}
+	fmt.Fprintf(ctx.Stdout, "✨ Passing to Gemini CLI: %s\n", prompt)
+
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, newPluginContext(cfg, cfg.Engine, "gemini"), ctx.Stdout); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("gemini", prompt)
+	cmd.Stdout = ctx.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Gemini CLI error: %w", err)
+	}
+	return nil
+}
+
+func runPlugins(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	r := &pluginsReport{Plugins: []pluginStatus{}}
+	for _, plugin := range resolved.Config.Plugins {
+		r.Plugins = append(r.Plugins, pluginStatus{
+			Path:    plugin.Path,
+			Name:    plugin.Name,
+			Enabled: plugin.Enabled,
+			Found:   fileExists(plugin.Path),
+		})
+	}
+	return writeReport(ctx, r)
+}
+
+// runScript runs a script given in place of a command, bundling
+// TypeScript first.
+func runScript(ctx *cli.Context) error {
+	script := ctx.Arg("script")
+	if script == "" {
+		ctx.Command.WriteHelp(ctx.Stderr)
+		return cli.ExitStatus(cli.ExitUsage)
+	}
+	if !fileExists(script) {
+		if filepath.Ext(script) == "" {
+			return ctx.Usagef("unknown command %q", script)
+		}
+		return withKind(engine.KindScript, fmt.Errorf("script not found: %s\n💡 Make sure the file path is correct", script))
+	}
+	if err := textOnly(ctx); err != nil {
+		return err
+	}
+
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+
+	if filepath.Ext(script) == ".ts" {
+		fmt.Fprintf(ctx.Stdout, "🔧 TypeScript detected, bundling %s...\n", script)
+
+		if err := runViteBundle(script, ctx.Stdout); err != nil {
+			return withKind(engine.KindScript, fmt.Errorf("failed to bundle: %w", err))
+		}
+
+		baseName := strings.TrimSuffix(filepath.Base(script), ".ts")
+		bundledScript := filepath.Join("dist", baseName+".js")
+
+		if !fileExists(bundledScript) {
+			if files, err := os.ReadDir("dist"); err == nil {
+				fmt.Fprintln(ctx.Stdout, "📁 Files in dist directory:")
+				for _, file := range files {
+					fmt.Fprintf(ctx.Stdout, "  - %s\n", file.Name())
+				}
+			}
+			return withKind(engine.KindScript, fmt.Errorf("bundled file not found: %s", bundledScript))
+		}
+
+		script = bundledScript
+		fmt.Fprintf(ctx.Stdout, "✅ Using bundled script: %s\n", script)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "🚀 Running script: %s\n", script)
+	start := time.Now()
+
+	// ✅ Inject context before running the script
+	pctx := newPluginContext(cfg, cfg.Engine, "run")
+	pctx.Meta.Script = script
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, ctx.Stdout); err != nil {
+		return err
+	}
+
+	if err := runNodeScript(script, ctx.Stdout); err != nil {
+		return withKind(engine.KindScript, fmt.Errorf("script execution failed: %w", err))
+	}
+
+	fmt.Fprintf(ctx.Stdout, "✅ Script completed in %v\n", time.Since(start))
+	return nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+)
+
+func TestCommandLines(t *testing.T) {
+	for _, tc := range []struct {
+		args    []string
+		command string
+		flags   string // config settings as flag=value, space separated
+		usage   string // expected usage error
+	}{
+		{args: []string{"open", "https://example.com", "--engine", "cdp"}, command: "phantom-vite open", flags: "engine=cdp"},
+		{args: []string{"--headless=false", "--viewport", "800x600", "open", "u"}, command: "phantom-vite open", flags: "headless=false viewport=800x600"},
+		{args: []string{"config", "show", "--origin", "--profile", "ci"}, command: "phantom-vite config show", flags: "profile=ci"},
+		{args: []string{"script.js", "--timeout", "5s"}, command: "phantom-vite", flags: "timeout=5s"},
+		{args: []string{"agent", "summarize", "this", "page"}, command: "phantom-vite agent"},
+		{args: []string{"open"}, command: "phantom-vite open", usage: "missing <url>"},
+		{args: []string{"open", "--engine", "lynx", "u"}, command: "phantom-vite open", usage: `flag --engine must be one of ` + strings.Join(settingFlags()[0].Values, ", ") + `, got "lynx"`},
+		{args: []string{"config", "convert", "--to", "xml"}, command: "phantom-vite config convert", usage: `flag --to must be one of yaml, yml, toml, json, got "xml"`},
+		{args: []string{"config"}, command: "phantom-vite config", usage: "'phantom-vite config' needs a command"},
+		{args: []string{"doctor", "--origin"}, command: "phantom-vite doctor", usage: "unknown flag --origin"},
+	} {
+		ctx, err := newRootCommand().Parse(tc.args)
+		if got := ctx.Command.Path(); got != tc.command {
+			t.Errorf("%q: expected %q, got %q", tc.args, tc.command, got)
+		}
+		var usage *cli.UsageError
+		switch {
+		case tc.usage != "":
+			if !errors.As(err, &usage) || usage.Message != tc.usage {
+				t.Errorf("%q: expected usage error %q, got %v", tc.args, tc.usage, err)
+			}
+			continue
+		case err != nil:
+			t.Errorf("%q: %v", tc.args, err)
+			continue
+		}
+
+		var flags []string
+		for _, f := range configFlags(ctx) {
+			flags = append(flags, f.Setting.Flag+"="+f.Value)
+		}
+		if got := strings.Join(flags, " "); got != tc.flags {
+			t.Errorf("%q: expected config flags %q, got %q", tc.args, tc.flags, got)
+		}
+	}
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		code int
+		out  string
+	}{
+		{[]string{"help", "open"}, cli.ExitOK, "phantom-vite open <url> [flags]"},
+		{[]string{"config", "init", "--help"}, cli.ExitOK, "--force"},
+		{[]string{"completion", "bash"}, cli.ExitOK, "complete -F _phantom_vite phantom-vite"},
+		{[]string{"open"}, cli.ExitUsage, ""},
+		{[]string{"pdf", "https://example.com", "--scale", "5"}, cli.ExitUsage, ""},
+		{[]string{"crawl", "example.com"}, cli.ExitUsage, ""},
+		{[]string{"crawl", "https://example.com", "--depth", "-1"}, cli.ExitUsage, ""},
+		{[]string{"crawl", "https://example.com", "--delay", "often"}, cli.ExitUsage, ""},
+		{[]string{"server", "--request-timeout", "soon"}, cli.ExitUsage, ""},
+		{[]string{"server", "--concurrency", "0"}, cli.ExitUsage, ""},
+		{[]string{"test", "--test-timeout", "0s"}, cli.ExitUsage, ""},
+		{[]string{"steps"}, cli.ExitUsage, ""},
+		{[]string{"opne"}, cli.ExitUsage, ""},
+		{[]string{}, cli.ExitUsage, ""},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := cli.Run(newRootCommand(), tc.args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d\n%s", tc.args, tc.code, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tc.out) {
+			t.Errorf("%q: expected %q in:\n%s", tc.args, tc.out, stdout.String())
+		}
+	}
+}
+
+func TestCommandsAreDocumented(t *testing.T) {
+	var walk func(c *cli.Command)
+	walk = func(c *cli.Command) {
+		for _, sub := range c.Commands {
+			if sub.Summary == "" {
+				t.Errorf("'%s' has no summary", sub.Path())
+			}
+			for _, f := range sub.Flags {
+				if f.Usage == "" {
+					t.Errorf("'%s --%s' has no usage", sub.Path(), f.Name)
+				}
+			}
+			walk(sub)
+		}
+	}
+	root := newRootCommand()
+	root.Parse(nil) // links the tree so Path works
+	walk(root)
+}
+
+func TestJSONOutput(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	bad := filepath.Join(t.TempDir(), "phantomvite.config.json")
+	os.WriteFile(bad, []byte(`{"viewport": {"width": -1}}`), 0644)
+	starter := filepath.Join(t.TempDir(), "phantomvite.config.json")
+
+	for _, tc := range []struct {
+		args []string
+		code int
+		want string // a top-level key of the document
+	}{
+		{[]string{"engines"}, cli.ExitOK, "engines"},
+		{[]string{"plugins"}, cli.ExitOK, "plugins"},
+		{[]string{"config", "show"}, cli.ExitOK, "config"},
+		{[]string{"config", "show", "--origin"}, cli.ExitOK, "values"},
+		{[]string{"config", "validate", bad}, exitConfig, "problems"},
+		{[]string{"config", "init", starter}, cli.ExitOK, "file"},
+		{[]string{"config", "convert", starter, "--to", "yaml"}, cli.ExitOK, "source"},
+		{[]string{"open", "https://example.com", "--engine", "missing"}, exitUnavailable, "error"},
+		{[]string{"pdf", "https://example.com", "--engine", "missing"}, exitUnavailable, "timings"},
+	} {
+		var stdout, stderr bytes.Buffer
+		args := append(tc.args, "--output", "json")
+		if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d\n%s", args, tc.code, code, stderr.String())
+		}
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+			t.Errorf("%q: stdout is not a JSON document: %v\n%s", args, err, stdout.String())
+			continue
+		}
+		if _, ok := doc[tc.want]; !ok {
+			t.Errorf("%q: expected %q in:\n%s", args, tc.want, stdout.String())
+		}
+	}
+}
+
+func TestJSONOutputRejectedForProgramOutput(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	script := filepath.Join(t.TempDir(), "script.js")
+	os.WriteFile(script, nil, 0644)
+
+	for _, args := range [][]string{
+		{"serve", "vite.config.js"},
+		{"agent", "summarize", "the", "page"},
+		{"gemini", "hello"},
+		{script},
+	} {
+		var stdout, stderr bytes.Buffer
+		args = append(args, "--output", "json")
+		if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitUsage {
+			t.Errorf("%q: expected exit code %d, got %d\n%s", args, cli.ExitUsage, code, stderr.String())
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("%q: expected nothing on stdout, got %q", args, stdout.String())
+		}
+	}
+}
+
+func TestEnginesJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	cli.Run(newRootCommand(), []string{"engines", "--output=json"}, &stdout, io.Discard)
+	var r struct {
+		Engines []struct {
+			Name        string `json:"name"`
+			Available   *bool  `json:"available"`
+			Description string `json:"description"`
+		} `json:"engines"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Engines) != len(engine.List()) {
+		t.Fatalf("expected %d engines, got %d", len(engine.List()), len(r.Engines))
+	}
+	for i, driver := range engine.List() {
+		e := r.Engines[i]
+		if e.Name != driver.Name || e.Available == nil || e.Description != driver.Description {
+			t.Errorf("unexpected entry for %s: %+v", driver.Name, e)
+		}
+	}
+}
+
+func TestExitCodesByKind(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"--viewport", "0x0", "plugins"}, exitConfig},
+		{[]string{"open", "https://example.com", "--engine", "missing"}, exitUnavailable},
+		{[]string{"open", "https://example.com", "--engine", "gemini"}, cli.ExitUsage},
+		{[]string{"missing-script.js"}, exitScript},
+	} {
+		if code := cli.Run(newRootCommand(), tc.args, io.Discard, io.Discard); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d", tc.args, tc.code, code)
+		}
+	}
+
+	_, pluginErr := LoadPlugins(config.Config{Plugins: []engine.PluginConfig{{Path: "missing/plugin.js", Enabled: true}}})
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{engine.NewEngineError("cdp", "navigate", "timed out waiting for load", context.DeadlineExceeded), exitNavigationTimeout},
+		{fmt.Errorf("navigation failed: %w", engine.NewEngineError("selenium", "navigate", "navigation failed", context.DeadlineExceeded)), exitNavigationTimeout},
+		{engine.NewEngineError("cdp", "initialize", "failed to launch browser", errors.New("exec: not found")), exitUnavailable},
+		{engine.NewEngineError("cdp", "execute script", "script threw an exception", nil), exitScript},
+		{pluginErr, exitPlugin},
+		{errors.New("screenshot failed"), cli.ExitError},
+	} {
+		if code := cli.Run(&cli.Command{Name: "x", Run: func(*cli.Context) error { return classify(tc.err) }}, nil, io.Discard, io.Discard); code != tc.code {
+			t.Errorf("%v: expected exit code %d, got %d", tc.err, tc.code, code)
+		}
+	}
+}
+
+func TestProjectRuntimeBesideConfig(t *testing.T) {
+	root, _ := filepath.EvalSymlinks(t.TempDir())
+	os.WriteFile(filepath.Join(root, config.DefaultFile), []byte("{}"), 0644)
+	os.MkdirAll(filepath.Join(root, "tests", "e2e"), 0755)
+
+	wd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(wd) })
+	os.Chdir(filepath.Join(root, "tests", "e2e"))
+	if got := projectRuntime(); got != "" {
+		t.Errorf("expected no runtime before it exists, got %s", got)
+	}
+	os.MkdirAll(filepath.Join(root, "runtime"), 0755)
+	if got, want := projectRuntime(), filepath.Join(root, "runtime"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
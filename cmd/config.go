@@ -0,0 +1,197 @@
+// config.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+)
+
+// configCommand is 'phantom-vite config'. Its subcommands other than show
+// do not resolve the configuration, so validate and init work on a broken
+// or missing config file.
+func configCommand() *cli.Command {
+	fileArg := cli.Arg{Name: "file", Optional: true, Complete: cli.CompleteFile,
+		Usage: "config file; the nearest project config file by default"}
+	force := cli.Flag{Name: "force", Bool: true, Usage: "overwrite an existing file"}
+
+	return &cli.Command{
+		Name:    "config",
+		Summary: "Show, check and create configuration files",
+		Commands: []*cli.Command{
+			{
+				Name:    "show",
+				Summary: "Print the effective configuration",
+				Help: "Print the configuration after merging defaults, the user and project files,\n" +
+					"the selected profile, PHANTOM_* variables and flags.",
+				Flags:    []cli.Flag{{Name: "origin", Bool: true, Usage: "list every key with the layer that set it"}},
+				Examples: []string{"phantom-vite config show", "phantom-vite config show --origin --profile ci"},
+				Run:      runConfigShow,
+			},
+			{
+				Name:     "validate",
+				Summary:  "Check a config file and list every problem",
+				Args:     []cli.Arg{fileArg},
+				Examples: []string{"phantom-vite config validate", "phantom-vite config validate ci/phantomvite.config.yaml"},
+				Run:      runConfigValidate,
+			},
+			{
+				Name:    "init",
+				Summary: "Write a commented starter config file",
+				Args: []cli.Arg{{Name: "file", Optional: true, Complete: cli.CompleteFile,
+					Usage: "file to write; " + config.DefaultFile + " by default"}},
+				Flags:    []cli.Flag{force},
+				Examples: []string{"phantom-vite config init"},
+				Run:      runConfigInit,
+			},
+			{
+				Name:    "convert",
+				Summary: "Write a config file in another format",
+				Help: "Write a config file in another format next to the original, which is left\n" +
+					"in place. Comments in JSON files are not carried over.",
+				Args: []cli.Arg{fileArg},
+				Flags: []cli.Flag{
+					{Name: "to", Value: "format", Usage: "format to write", Values: []string{"yaml", "yml", "toml", "json"}},
+					force,
+				},
+				Examples: []string{"phantom-vite config convert --to yaml", "phantom-vite config convert ~/.config/phantomvite/config.json --to toml"},
+				Run:      runConfigConvert,
+			},
+			{
+				Name:     "schema",
+				Summary:  "Print the JSON Schema of config files",
+				Examples: []string{"phantom-vite config schema > phantomvite.schema.json"},
+				Run:      runConfigSchema,
+			},
+		},
+	}
+}
+
+func runConfigShow(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.Bool("origin") {
+		return writeReport(ctx, &configReport{Values: resolved.Values()})
+	}
+	return writeReport(ctx, &configReport{Config: &resolved.Config})
+}
+
+// configFile returns the file argument or the nearest project config file
+func configFile(ctx *cli.Context) (string, error) {
+	if path := ctx.Arg("file"); path != "" {
+		return path, nil
+	}
+	if path := config.FindProjectFile("."); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("no %s found; create one with 'phantom-vite config init'", config.DefaultFile)
+}
+
+// runConfigValidate checks one config file and lists every violation as
+// file:line:column.
+func runConfigValidate(ctx *cli.Context) error {
+	path, err := configFile(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	r := &validateReport{File: path, Valid: true, Problems: []*config.FieldError{}}
+	_, err = config.Load(path)
+	var errs config.Errors
+	if !errors.As(err, &errs) {
+		if err != nil {
+			return err
+		}
+		return writeReport(ctx, r)
+	}
+	r.Valid, r.Problems = false, errs
+	if err := writeReport(ctx, r); err != nil {
+		return err
+	}
+	problems := "problems"
+	if len(errs) == 1 {
+		problems = "problem"
+	}
+	return withKind(engine.KindConfig, fmt.Errorf("%s has %d %s", path, len(errs), problems))
+}
+
+// runConfigInit writes the starter config, refusing to replace an existing
+// file unless --force is given.
+func runConfigInit(ctx *cli.Context) error {
+	path := ctx.Arg("file")
+	if path == "" {
+		path = config.DefaultFile
+	}
+	if _, err := os.Stat(path); err == nil && !ctx.Bool("force") {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+	if err := os.WriteFile(path, config.Starter(), 0644); err != nil {
+		return err
+	}
+	return writeReport(ctx, &writeConfigReport{File: path, Format: config.FormatJSON})
+}
+
+// runConfigConvert writes a config file in another format next to the
+// original, which is left in place.
+func runConfigConvert(ctx *cli.Context) error {
+	to := ctx.String("to")
+	if to == "" {
+		return ctx.Usagef("missing --to")
+	}
+	if to == "yml" {
+		to = config.FormatYAML
+	}
+	path, err := configFile(ctx)
+	if err != nil {
+		return err
+	}
+	from := config.FormatOf(path)
+	if from == to {
+		return fmt.Errorf("%s is already %s", path, to)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out, err := config.Convert(from, data, to)
+	if err != nil {
+		return &config.SourceError{Source: path, Err: err}
+	}
+	target := strings.TrimSuffix(path, filepath.Ext(path)) + "." + to
+	if _, err := os.Stat(target); err == nil && !ctx.Bool("force") {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", target)
+	}
+	if err := os.WriteFile(target, out, 0644); err != nil {
+		return err
+	}
+	if err := writeReport(ctx, &writeConfigReport{File: target, Format: to, Source: path}); err != nil {
+		return err
+	}
+	log := logOutput(ctx)
+	if from == config.FormatJSON {
+		fmt.Fprintf(log, "💡 Comments in %s are not carried over\n", filepath.Base(path))
+	}
+	fmt.Fprintf(log, "💡 Remove %s to use the new file; the first of %s found is read\n", path, strings.Join(config.ProjectFiles, ", "))
+	return nil
+}
+
+func runConfigSchema(ctx *cli.Context) error {
+	data, err := config.MarshalSchema()
+	if err != nil {
+		return err
+	}
+	_, err = ctx.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,184 @@
+// crawl.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/crawl"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/pool"
+)
+
+func crawlCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "crawl",
+		Summary: "Crawl a site breadth first and save its link graph",
+		Help: "Renders each page with the engine, reads the links of the rendered DOM\n" +
+			"and follows those not seen before, up to --depth links away from the\n" +
+			"start. URLs are deduped without their fragment, default port or case of\n" +
+			"the host. robots.txt is obeyed unless --ignore-robots is given, and\n" +
+			"loads of the same host are at least --delay apart, or the Crawl-delay\n" +
+			"of robots.txt when it is longer. Plugins run onStart and onExit once and\n" +
+			"onPageLoad for every page that loads. The graph of every visited page,\n" +
+			"with its depth, parent, title and links, is saved to --out; pages that\n" +
+			"fail to load are part of it and do not fail the command. Ctrl+C stops\n" +
+			"the crawl and saves the graph so far.",
+		Args: []cli.Arg{{Name: "url", Usage: "page to start at"}},
+		Flags: append(navigationFlags(),
+			cli.Flag{Name: "depth", Value: "n", Default: "2", Usage: "links followed away from the start; 0 visits only the start"},
+			cli.Flag{Name: "same-origin", Bool: true, Usage: "follow only links with the scheme, host and port of the start"},
+			cli.Flag{Name: "max-pages", Value: "n", Default: "100", Usage: "pages visited at most; 0 for no limit"},
+			cli.Flag{Name: "concurrency", Value: "n", Default: "4", Usage: "pages loading at the same time"},
+			cli.Flag{Name: "browsers", Value: "n", Default: "1", Usage: "browsers to spread the pages over"},
+			cli.Flag{Name: "delay", Value: "duration", Default: "250ms", Usage: "least time between loads of the same host"},
+			cli.Flag{Name: "ignore-robots", Bool: true, Usage: "do not fetch or obey robots.txt"},
+			cli.Flag{Name: "out", Value: "file", Default: "crawl.json", Complete: cli.CompleteFile, Usage: "where to save the crawl graph"},
+		),
+		Examples: []string{
+			"phantom-vite crawl https://example.com --depth 3 --same-origin --max-pages 500",
+			"phantom-vite crawl https://example.com --concurrency 8 --delay 1s --out site.json",
+			"phantom-vite crawl https://example.com --output json | jq '.pages[] | select(.status == \"failed\") | .url'",
+		},
+		Run: runCrawl,
+	}
+}
+
+func runCrawl(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	var depth, maxPages, concurrency, browsers int
+	for _, f := range []struct {
+		name string
+		n    *int
+		min  int
+	}{{"depth", &depth, 0}, {"max-pages", &maxPages, 0}, {"concurrency", &concurrency, 1}, {"browsers", &browsers, 1}} {
+		if *f.n, err = ctx.Int(f.name); err != nil {
+			return err
+		}
+		if *f.n < f.min {
+			return ctx.Usagef("--%s must be at least %d, got %d", f.name, f.min, *f.n)
+		}
+	}
+	delay, err := config.ParseDuration(ctx.String("delay"))
+	if err != nil || delay < 0 {
+		return ctx.Usagef("--delay must be a duration such as 500ms, got %q", ctx.String("delay"))
+	}
+	if _, ok := crawl.Normalize(nil, ctx.Arg("url")); !ok {
+		return ctx.Usagef("%q is not an http or https URL", ctx.Arg("url"))
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	log := logOutput(ctx)
+	start := time.Now()
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, cfg.Engine, "crawl")
+	pctx.Meta.URL = ctx.Arg("url")
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	pages, err := pool.New(pool.Config{
+		Launch:          pool.Open(cfg.Engine, cfg.EngineConfig()),
+		Browsers:        browsers,
+		PagesPerBrowser: (concurrency + browsers - 1) / browsers,
+		MaxPages:        concurrency,
+	})
+	if err != nil {
+		return err
+	}
+	defer pages.Close()
+
+	// The first page launches a browser, so a broken engine fails the
+	// command instead of every page.
+	first, err := pages.Acquire(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	first.Release()
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = crawl.DefaultUserAgent
+	}
+	crawler, err := crawl.New(ctx.Arg("url"), crawl.Config{
+		Pages:        pages,
+		MaxDepth:     depth,
+		MaxPages:     maxPages,
+		SameOrigin:   ctx.Bool("same-origin"),
+		Concurrency:  concurrency,
+		Delay:        time.Duration(delay),
+		IgnoreRobots: ctx.Bool("ignore-robots"),
+		UserAgent:    userAgent,
+		Client:       &http.Client{Timeout: time.Duration(cfg.Timeout)},
+		Navigation:   navigationOptions(ctx, cfg),
+		Prepare:      func(page engine.Page) error { return emulateDevice(page, cfg) },
+		OnPage: func(page *crawl.Page) error {
+			if page.Status != crawl.StatusOK {
+				return nil
+			}
+			pctx := pctx
+			pctx.Meta.URL = page.URL
+			return ExecutePluginHooksWithContext("onPageLoad", pluginPaths, pctx, log)
+		},
+		Log: log,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(log, "🕷️  Crawling %s with %s engine, %d links deep...\n", ctx.Arg("url"), cfg.Engine, depth)
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	result, crawlErr := crawler.Run(stop)
+	if errors.Is(crawlErr, context.Canceled) {
+		fmt.Fprintln(log, "🛑 Stopped; saving the pages crawled so far")
+		crawlErr = nil
+	}
+
+	r := &crawlReport{Engine: cfg.Engine, File: ctx.String("out"), Result: result, OK: result.OK()}
+	r.Failed = len(result.Pages) - r.OK
+	r.Timings.Total = milliseconds(time.Since(start))
+	r.Pool = pages.Stats()
+	if err := writeCrawlGraph(r); err != nil {
+		return err
+	}
+	if crawlErr != nil {
+		return crawlErr
+	}
+	if err := ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+	return writeReport(ctx, r)
+}
+
+func writeCrawlGraph(r *crawlReport) error {
+	f, err := os.Create(r.File)
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/crawl"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+func TestCrawl(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	links := map[string][]interface{}{
+		"https://example.com/":     {"/docs", "https://slow.example.com/", "#top"},
+		"https://example.com/docs": {"/", "/docs/api"},
+	}
+	useEngine(t, &enginetest.Engine{Script: func(p *enginetest.Page, _ string) (interface{}, error) {
+		url, _ := p.URL()
+		return links[url], nil
+	}})
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"crawl", "https://EXAMPLE.com", "--engine", "fake", "--depth", "1", "--ignore-robots", "--delay", "0", "--out", "graph.json", "--output", "json"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitOK {
+		t.Fatalf("expected exit code %d, got %d\n%s", cli.ExitOK, code, stderr.String())
+	}
+	graph, err := os.ReadFile("graph.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(graph, stdout.Bytes()) {
+		t.Errorf("expected stdout to match graph.json:\n%s\n%s", stdout.String(), graph)
+	}
+	var r struct {
+		Start  string       `json:"start"`
+		Pages  []crawl.Page `json:"pages"`
+		OK     int          `json:"ok"`
+		Failed int          `json:"failed"`
+	}
+	if err := json.Unmarshal(graph, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Start != "https://example.com/" || r.OK != 2 || r.Failed != 1 {
+		t.Fatalf("expected 2 of 3 pages from the normalized start, got %+v", r)
+	}
+	var urls []string
+	for _, p := range r.Pages {
+		urls = append(urls, p.URL)
+	}
+	if want := []string{"https://example.com/", "https://example.com/docs", "https://slow.example.com/"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("expected pages %q, got %q", want, urls)
+	}
+	if want := []string{"https://example.com/", "https://example.com/docs/api"}; !reflect.DeepEqual(r.Pages[1].Links, want) {
+		t.Errorf("expected links %q, got %q", want, r.Pages[1].Links)
+	}
+}
@@ -0,0 +1,10 @@
+// drivers.go
+package main
+
+// Engine drivers register themselves with pkg/engine when imported. Add a
+// blank import here to make a new driver available to the CLI.
+import (
+	_ "phantomvite/pkg/engine/cdp"
+	_ "phantomvite/pkg/engine/nodebridge"
+	_ "phantomvite/pkg/engine/webdriver"
+)
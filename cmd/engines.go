@@ -0,0 +1,30 @@
+// engines.go
+package main
+
+import (
+	"os/exec"
+
+	"phantomvite/pkg/engine"
+)
+
+func init() {
+	engine.Register(engine.Driver{
+		Name:        "gemini",
+		Description: "CLI, Google AI integration for intelligent automation",
+		Probe: func() engine.EngineStatus {
+			if _, err := exec.LookPath("gemini"); err != nil {
+				return engine.EngineStatus{Error: "Not installed. Run: npm install -g @google/gemini-cli"}
+			}
+			return engine.EngineStatus{Available: true, Path: "system"}
+		},
+	})
+}
+
+func checkEngineStatus() []engine.EngineStatus {
+	drivers := engine.List()
+	statuses := make([]engine.EngineStatus, 0, len(drivers))
+	for _, driver := range drivers {
+		statuses = append(statuses, driver.Status())
+	}
+	return statuses
+}
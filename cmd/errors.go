@@ -0,0 +1,77 @@
+// errors.go
+package main
+
+import (
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/engine"
+)
+
+// Exit codes by kind of failure. Other failures exit with cli.ExitError (1)
+// and command-line mistakes with cli.ExitUsage (2).
+const (
+	exitConfig            = 3
+	exitUnavailable       = 4
+	exitNavigationTimeout = 5
+	exitScript            = 6
+	exitPlugin            = 7
+)
+
+var exitCodes = map[engine.ErrorKind]int{
+	engine.KindConfig:            exitConfig,
+	engine.KindUnavailable:       exitUnavailable,
+	engine.KindNavigationTimeout: exitNavigationTimeout,
+	engine.KindScript:            exitScript,
+	engine.KindPlugin:            exitPlugin,
+}
+
+// kindError gives a failure of the CLI itself, rather than of an engine
+// operation, a kind without changing its message
+type kindError struct {
+	kind engine.ErrorKind
+	err  error
+}
+
+func withKind(kind engine.ErrorKind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() error { return e.err }
+
+func (e *kindError) ErrorKind() engine.ErrorKind { return e.kind }
+
+// exitError carries the exit code of an error's kind to cli.Run
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+func (e *exitError) ExitCode() int { return e.code }
+
+// classify gives err the exit code of its kind. Errors that already choose
+// an exit code, such as usage errors, are left alone.
+func classify(err error) error {
+	if _, ok := err.(cli.ExitCoder); ok || err == nil {
+		return err
+	}
+	if code, ok := exitCodes[engine.KindOf(err)]; ok {
+		return &exitError{err: err, code: code}
+	}
+	return err
+}
+
+// classifyErrors makes every command in the tree exit with the code of the
+// kind of error it fails with
+func classifyErrors(c *cli.Command) {
+	if run := c.Run; run != nil {
+		c.Run = func(ctx *cli.Context) error { return classify(run(ctx)) }
+	}
+	for _, sub := range c.Commands {
+		classifyErrors(sub)
+	}
+}
@@ -3,157 +3,68 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
-)
-
-type PluginConfig struct {
-	Path    string                 `json:"path"`
-	Options map[string]interface{} `json:"options,omitempty"`
-}
 
-type Config struct {
-	Engine   string         `json:"engine"`
-	Timeout  int            `json:"timeout"` 
-	Headless bool           `json:"headless"`
-	Plugins  []PluginConfig `json:"plugins"`
-	Entries  []string       `json:"entries"`
-	Viewport struct {
-		Width  int `json:"width"`
-		Height int `json:"height"`
-	} `json:"viewport"`
-}
-
-type EngineStatus struct {
-	Name      string
-	Available bool
-	Path      string
-	Error     string
-}
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/nodebridge"
+)
 
 type PluginContext struct {
-	Engine   string   `json:"engine"`
-	Headless bool     `json:"headless"`
-	Plugins  []PluginConfig `json:"plugins"`
-	Timeout  int      `json:"timeout"`
-	Viewport struct {
-		Width  int `json:"width"`
-		Height int `json:"height"`
-	} `json:"viewport"`
-	Meta struct {
+	Engine   string                `json:"engine"`
+	Headless bool                  `json:"headless"`
+	Plugins  []engine.PluginConfig `json:"plugins"`
+	Timeout  int64                 `json:"timeout"` // milliseconds
+	Viewport engine.ViewportConfig `json:"viewport"`
+	Meta     struct {
 		Command string `json:"command"`
 		Script  string `json:"script,omitempty"`
 		URL     string `json:"url,omitempty"`
 	} `json:"meta"`
 }
 
-func loadConfig() Config {
-	data, err := os.ReadFile("phantomvite.config.json")
-	if err != nil {
-		return Config{
-			Engine:   "puppeteer",
-			Headless: true,
-			Timeout:  30000,
-			Viewport: struct {
-				Width  int `json:"width"`
-				Height int `json:"height"`
-			}{
-				Width:  1920,
-				Height: 1080,
-			},
-		}
-	}
-	var cfg Config
-	json.Unmarshal(data, &cfg)
-
-	if cfg.Engine == "" {
-		cfg.Engine = "puppeteer"
-	}
-	if cfg.Viewport.Width == 0 {
-		cfg.Viewport.Width = 1920
-	}
-	if cfg.Viewport.Height == 0 {
-		cfg.Viewport.Height = 1080
-	}
-	if cfg.Timeout == 0 {
-		cfg.Timeout = 30000
-	}
-
-	return cfg
-}
-
-func checkEngineStatus() []EngineStatus {
-	engines := []EngineStatus{
-		{Name: "puppeteer", Available: false},
-		{Name: "playwright", Available: false},
-		{Name: "selenium", Available: false},
-		{Name: "gemini", Available: false},
-	}
-
-	if puppeteerInstalled() {
-		engines[0].Available = true
-		engines[0].Path = "runtime/node_modules/puppeteer"
-	} else {
-		engines[0].Error = "Not installed. Run: cd runtime && npm install puppeteer"
-	}
-
-	if playwrightInstalled() {
-		engines[1].Available = true
-		engines[1].Path = "runtime/node_modules/playwright"
-	} else {
-		engines[1].Error = "Not installed. Run: cd runtime && npm install playwright"
-	}
-
-	if seleniumInstalled() {
-		engines[2].Available = true
-		engines[2].Path = "runtime-python"
-	} else {
-		engines[2].Error = "Not installed. Run: cd runtime-python && pip install selenium"
-	}
-
-	if _, err := exec.LookPath("gemini"); err == nil {
-		engines[3].Available = true
-		engines[3].Path = "system"
-	} else {
-		engines[3].Error = "Not installed. Run: npm install -g @google/gemini-cli"
+func newPluginContext(cfg config.Config, engineName, command string) PluginContext {
+	ctx := PluginContext{
+		Engine:   engineName,
+		Headless: cfg.Headless,
+		Plugins:  cfg.EnabledPlugins(),
+		Timeout:  time.Duration(cfg.Timeout).Milliseconds(),
+		Viewport: cfg.Viewport,
 	}
-
-	return engines
+	ctx.Meta.Command = command
+	return ctx
 }
 
-func LoadPlugins(cfg Config) ([]string, error) {
+// LoadPlugins returns the absolute paths of the enabled plugins, failing
+// with a plugin error when one cannot be found
+func LoadPlugins(cfg config.Config) ([]string, error) {
 	var loaded []string
-	for _, path := range cfg.Plugins {
+	for _, path := range cfg.EnabledPlugins() {
 		abs, err := filepath.Abs(path.Path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve plugin path %s: %v", path, err)
+			return nil, withKind(engine.KindPlugin, fmt.Errorf("failed to resolve plugin path %s: %v", path.Path, err))
 		}
 		if _, err := os.Stat(abs); os.IsNotExist(err) {
-			return nil, fmt.Errorf("plugin not found: %s", abs)
+			return nil, withKind(engine.KindPlugin, fmt.Errorf("plugin not found: %s", abs))
 		}
 		loaded = append(loaded, abs)
 	}
 	return loaded, nil
 }
 
-func injectPluginContext() {
-	cfg := loadConfig()
-	if len(cfg.Plugins) > 0 {
-		var pluginPaths []string
-		for _, plugin := range cfg.Plugins {
-			pluginPaths = append(pluginPaths, plugin.Path)
-		}
-		pluginEnv := strings.Join(pluginPaths, string(os.PathListSeparator))
-		os.Setenv("PHANTOM_PLUGINS", pluginEnv)
-	}
-}
-
-func ExecutePluginHooksWithContext(hookName string, pluginPaths []string, context PluginContext) {
+// ExecutePluginHooksWithContext runs hookName of every plugin, writing what
+// the plugins print to out. It stops at the first plugin whose hook throws
+// or fails to load, and returns a plugin error for it.
+func ExecutePluginHooksWithContext(hookName string, pluginPaths []string, context PluginContext, out io.Writer) error {
 	for _, plugin := range pluginPaths {
 		// Normalize for Windows paths with drive letters (D:\ etc)
 		importPath := plugin
@@ -169,208 +80,63 @@ func ExecutePluginHooksWithContext(hookName string, pluginPaths []string, contex
 			if (plugin.%s) await plugin.%s(%s);
 		  } catch (e) {
 			console.error("[Plugin Error]", e);
+			process.exitCode = 1;
 		  }
 		})()`, importPath, hookName, hookName, string(serialized)))
 
-		cmd.Stdout = os.Stdout
+		cmd.Stdout = out
 		cmd.Stderr = os.Stderr
-		cmd.Dir = "runtime"
-		_ = cmd.Run()
+		cmd.Dir = nodebridge.RuntimeDir()
+		if err := cmd.Run(); err != nil {
+			return withKind(engine.KindPlugin, fmt.Errorf("plugin %s failed in %s: %w", plugin, hookName, err))
+		}
 	}
+	return nil
 }
 
-func runPageWithPlugins(script string, hooks []string, command string) error {
-	cfg := loadConfig()
-	pluginPaths, _ := LoadPlugins(cfg)
-
-	ctx := PluginContext{
-	// existing fields
-	Engine:   cfg.Engine,
-	Headless: cfg.Headless,
-	Plugins:  cfg.Plugins,
-	Timeout:  cfg.Timeout,
-	Viewport: cfg.Viewport,
-	Meta: struct {
-		Command string `json:"command"`
-		Script  string `json:"script,omitempty"`
-		URL     string `json:"url,omitempty"`
-	}{
-		Command: "run",
-		Script:  script,
-	},
-}
-
-	for _, hook := range hooks {
-		ExecutePluginHooksWithContext(hook, pluginPaths, ctx)
+// validateEngine checks that the named engine can open pages on this
+// machine; its errors are of kind engine.KindUnavailable
+func validateEngine(name string) error {
+	driver, err := engine.Get(name)
+	if err != nil {
+		return withKind(engine.KindUnavailable, fmt.Errorf("unknown engine: %s", name))
 	}
-
-	err := runNodeScript(script)
-	ExecutePluginHooksWithContext("onExit", pluginPaths, ctx)
-	return err
-}
-
-// Add this function to your main.go file
-func writeTempScript(url string, engine string) (string, error) {
-	var code string
-	
-	switch engine {
-	case "puppeteer":
-		code = fmt.Sprintf(`import puppeteer from 'puppeteer';
-
-const pluginPaths = process.env["PHANTOM_PLUGINS"]?.split(",") ?? [];
-const plugins = [];
-
-for (const path of pluginPaths) {
-  try {
-    const mod = await import(path);
-    plugins.push(mod);
-  } catch (e) {
-    console.error("[Phantom Vite] Failed to load plugin:", path, e);
-  }
-}
-
-(async () => {
-  for (const p of plugins) {
-    if (typeof p.onStart === 'function') await p.onStart();
-  }
-
-  const browser = await puppeteer.launch({ headless: true });
-  const page = await browser.newPage();
-
-  const url = '%s';
-  await page.goto(url);
-
-  for (const p of plugins) {
-    if (typeof p.onPageLoad === 'function') await p.onPageLoad(page);
-  }
-
-  const title = await page.title();
-  console.log("[Phantom Vite] Title:", title);
-  await page.screenshot({ path: 'screenshot.png' });
-
-  await browser.close();
-
-  for (const p of plugins) {
-    if (typeof p.onExit === 'function') await p.onExit();
-  }
-})();`, url)
-
-	case "playwright":
-		code = fmt.Sprintf(`import { chromium } from 'playwright';
-
-const pluginPaths = process.env["PHANTOM_PLUGINS"]?.split(",") ?? [];
-const plugins = [];
-
-for (const path of pluginPaths) {
-  try {
-    const mod = await import(path);
-    plugins.push(mod);
-  } catch (e) {
-    console.error("[Phantom Vite] Failed to load plugin:", path, e);
-  }
-}
-
-(async () => {
-  for (const p of plugins) {
-    if (typeof p.onStart === 'function') await p.onStart();
-  }
-
-  const browser = await chromium.launch({ headless: true });
-  const page = await browser.newPage();
-
-  const url = '%s';
-  await page.goto(url);
-
-  for (const p of plugins) {
-    if (typeof p.onPageLoad === 'function') await p.onPageLoad(page);
-  }
-
-  const title = await page.title();
-  console.log("[Phantom Vite] Title:", title);
-  await page.screenshot({ path: 'screenshot.png' });
-
-  await browser.close();
-
-  for (const p of plugins) {
-    if (typeof p.onExit === 'function') await p.onExit();
-  }
-})();`, url)
-
-	case "selenium":
-		code = fmt.Sprintf(`from selenium import webdriver
-from selenium.webdriver.chrome.options import Options
-import os
-import json
-
-# Load plugins (simplified for Python)
-plugin_paths = os.environ.get("PHANTOM_PLUGINS", "").split(",") if os.environ.get("PHANTOM_PLUGINS") else []
-
-# Setup Chrome options
-chrome_options = Options()
-chrome_options.add_argument("--headless")
-chrome_options.add_argument("--no-sandbox")
-chrome_options.add_argument("--disable-dev-shm-usage")
-
-try:
-    driver = webdriver.Chrome(options=chrome_options)
-    
-    url = "%s"
-    driver.get(url)
-    
-    title = driver.title
-    print(f"[Phantom Vite] Title: {title}")
-    
-    driver.save_screenshot("screenshot.png")
-    
-finally:
-    driver.quit()
-`, url)
-
-	default:
-		return "", fmt.Errorf("unsupported engine: %s", engine)
+	if driver.New == nil {
+		return withKind(engine.KindUnavailable, fmt.Errorf("engine '%s' cannot open pages", name))
 	}
-
-	var tmpFile string
-	if engine == "selenium" {
-		tmpFile = "phantom-open.py"
-	} else {
-		tmpFile = "phantom-open.js"
+	if status := driver.Status(); !status.Available {
+		return withKind(engine.KindUnavailable, fmt.Errorf("engine '%s' is not available: %s", name, status.Error))
 	}
-	
-	err := os.WriteFile(tmpFile, []byte(code), 0644)
-	return tmpFile, err
+	return nil
 }
 
-func validateEngine(engine string) error {
-	status := checkEngineStatus()
-	for _, s := range status {
-		if s.Name == engine {
-			if !s.Available {
-				return fmt.Errorf("❌ Engine '%s' is not available: %s", engine, s.Error)
-			}
-			return nil
-		}
+// emulateDevice makes page emulate the configured device, if any
+func emulateDevice(page engine.Page, cfg config.Config) error {
+	if device, ok := engine.LookupDevice(cfg.Device); ok {
+		return page.EmulateDevice(device)
 	}
-	return fmt.Errorf("❌ Unknown engine: %s", engine)
+	return nil
 }
 
-func runEngineScript(path, engine string) error {
-	if engine == "selenium" {
-		cmd := exec.Command(resolveCommand("python3"), path)
-		cmd.Dir = "runtime-python"
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
+// openPage navigates a new page to url, emulating the configured device
+// first so the page loads with its metrics.
+func openPage(eng engine.Engine, cfg config.Config, url string, nav *engine.NavigationOptions) (engine.Page, error) {
+	page, err := eng.NewPage(context.Background())
+	if err != nil {
+		return nil, err
 	}
-
-	cmd := exec.Command("node", path)
-	cmd.Dir = "runtime"
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	if err := emulateDevice(page, cfg); err != nil {
+		page.Close()
+		return nil, err
+	}
+	if err := page.Navigate(url, nav); err != nil {
+		page.Close()
+		return nil, err
+	}
+	return page, nil
 }
 
-func runNodeScript(script string) error {
+func runNodeScript(script string, out io.Writer) error {
 	cmd := exec.Command("node", script)
 
 	// Handle absolute path
@@ -383,21 +149,21 @@ func runNodeScript(script string) error {
 		cmd.Dir = "."
 	} else {
 		// Relative custom scripts assumed in runtime
-		cmd.Dir = "runtime"
+		cmd.Dir = nodebridge.RuntimeDir()
 	}
 
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
-func writeContextFile(cfg Config, url string) (string, error) {
+func writeContextFile(cfg config.Config, url string) (string, error) {
 	context := map[string]interface{}{
 		"engine":   cfg.Engine,
 		"headless": cfg.Headless,
 		"viewport": cfg.Viewport,
-		"timeout":  cfg.Timeout,
-		"plugins":  cfg.Plugins,
+		"timeout":  time.Duration(cfg.Timeout).Milliseconds(),
+		"plugins":  cfg.EnabledPlugins(),
 		"url":      url,
 	}
 
@@ -411,16 +177,16 @@ func writeContextFile(cfg Config, url string) (string, error) {
 	return path, err
 }
 
-func runViteBuild() error {
-	fmt.Println("🔧 [Phantom Vite] Running Vite build...")
+func runViteBuild(out io.Writer) error {
+	fmt.Fprintln(out, "🔧 [Phantom Vite] Running Vite build...")
 	cmd := exec.Command("npx", "vite", "build")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
-func runViteBundle(entry string) error {
-	fmt.Printf("📦 [Phantom Vite] Bundling: %s\n", entry)
+func runViteBundle(entry string, out io.Writer) error {
+	fmt.Fprintf(out, "📦 [Phantom Vite] Bundling: %s\n", entry)
 	
 	// Create a temporary Vite config for this specific entry
 	tempConfig := fmt.Sprintf(`
@@ -455,26 +221,11 @@ export default defineConfig({
 	defer os.Remove(configFile)
 	
 	cmd := exec.Command("npx", "vite", "build", "--config", configFile)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
-func puppeteerInstalled() bool {
-	info, err := os.Stat("runtime/node_modules/puppeteer")
-	return err == nil && info.IsDir()
-}
-
-func playwrightInstalled() bool {
-	info, err := os.Stat("runtime/node_modules/playwright")
-	return err == nil && info.IsDir()
-}
-
-func seleniumInstalled() bool {
-	info, err := os.Stat("runtime-python")
-	return err == nil && info.IsDir()
-}
-
 func resolveCommand(name string) string {
 	if os.PathSeparator == '\\' {
 		if name == "python3" {
@@ -489,397 +240,9 @@ func fileExists(path string) bool {
 	return err == nil
 }
 
-func printUsage() {
-	fmt.Println("🕴️  Phantom Vite - Headless Browser CLI")
-	fmt.Println()
-	fmt.Println("Usage:")
-	fmt.Println("  phantom-vite open <url> [--engine <engine>]")
-	fmt.Println("  phantom-vite build")
-	fmt.Println("  phantom-vite bundle <file>")
-	fmt.Println("  phantom-vite serve <file>")
-	fmt.Println("  phantom-vite doctor")
-	fmt.Println("  phantom-vite engines")
-	fmt.Println("  phantom-vite agent <prompt>")
-	fmt.Println("  phantom-vite gemini <prompt>")
-	fmt.Println("  phantom-vite plugins")
-	fmt.Println("  phantom-vite <script.js>")
-	fmt.Println()
-	fmt.Println("Examples:")
-	fmt.Println("  phantom-vite open https://example.com")
-	fmt.Println("  phantom-vite open https://example.com --engine playwright")
-	fmt.Println("  phantom-vite build")
-	fmt.Println("  phantom-vite script.ts")
-}
-
 func main() {
-	if len(os.Args) < 2 {
-		printUsage()
-		os.Exit(1)
-	}
-
-	cfg := loadConfig()
-	
-	// Parse engine flag
-	engine := cfg.Engine
-	for i := 2; i < len(os.Args); i++ {
-		if os.Args[i] == "--engine" && i+1 < len(os.Args) {
-			engine = os.Args[i+1]
-			break
-		}
-	}
-
-	switch os.Args[1] {
-	case "doctor":
-		fmt.Println("🏥 [Phantom Vite] Health Check")
-		fmt.Println()
-		
-		// Check Go version
-		if cmd := exec.Command("go", "version"); cmd.Run() == nil {
-			fmt.Println("✅ Go runtime available")
-		} else {
-			fmt.Println("❌ Go runtime not found")
-		}
-		
-		// Check Node.js version
-		if cmd := exec.Command("node", "--version"); cmd.Run() == nil {
-			fmt.Println("✅ Node.js runtime available")
-		} else {
-			fmt.Println("❌ Node.js runtime not found")
-		}
-		
-		// Check Python version
-		if cmd := exec.Command(resolveCommand("python3"), "--version"); cmd.Run() == nil {
-			fmt.Println("✅ Python runtime available")
-		} else {
-			fmt.Println("❌ Python runtime not found")
-		}
-		
-		// Check engines
-		fmt.Println()
-		fmt.Println("🔧 Engine Status:")
-		statuses := checkEngineStatus()
-		for _, status := range statuses {
-			if status.Available {
-				fmt.Printf("  ✅ %s (%s)\n", status.Name, status.Path)
-			} else {
-				fmt.Printf("  ❌ %s - %s\n", status.Name, status.Error)
-			}
-		}
-		
-		// Check configuration
-		fmt.Println()
-		fmt.Println("⚙️  Configuration:")
-		fmt.Printf("  Default engine: %s\n", cfg.Engine)
-		fmt.Printf("  Headless mode: %t\n", cfg.Headless)
-		fmt.Printf("  Viewport: %dx%d\n", cfg.Viewport.Width, cfg.Viewport.Height)
-		fmt.Printf("  Timeout: %dms\n", cfg.Timeout)
-		
-		return
-
-	case "open":
-		args := os.Args[2:]
-		if len(args) < 1 {
-			fmt.Println("Usage: phantom-vite open <url> [--engine <engine>]")
-			return
-		}
-		
-		url := args[0]
-		
-		// Validate engine
-		if err := validateEngine(engine); err != nil {
-			fmt.Println(err)
-			fmt.Println("💡 Run 'phantom-vite doctor' to check your setup")
-			return
-		}
-		
-		scriptPath, err := writeTempScript(url, engine)
-		if err != nil {
-			fmt.Printf("❌ Failed to generate script: %v\n", err)
-			return
-		}
-		defer os.Remove(scriptPath) // Clean up temp file
-		
-		fmt.Printf("🚀 Opening %s with %s engine...\n", url, engine)
-		
-		start := time.Now()
-pluginPaths, _ := LoadPlugins(cfg)
-ctx := PluginContext{
-	Engine:   cfg.Engine,
-	Headless: cfg.Headless,
-	Plugins:  cfg.Plugins,
-	Timeout:  cfg.Timeout,
-	Viewport: cfg.Viewport,
-	Meta: struct {
-		Command string `json:"command"`
-		Script  string `json:"script,omitempty"`
-		URL     string `json:"url,omitempty"`
-	}{
-		Command: "open",
-	        URL:     url,
-	        Script:  scriptPath,
-	},
-}
-ExecutePluginHooksWithContext("onStart", pluginPaths, ctx)
-ctx.Meta.Command = "open"
-ctx.Meta.URL = url
-ExecutePluginHooksWithContext("onStart", pluginPaths, ctx)
-
-contextPath, err := writeContextFile(cfg, url)
-if err == nil {
-	os.Setenv("PHANTOM_CONTEXT_PATH", contextPath)
-}
-
-		if err := runEngineScript(scriptPath, engine); err != nil {
-			fmt.Printf("❌ Script execution failed: %v\n", err)
-			return
-		}
-
-		defer os.Remove("phantom.context.json")
-		fmt.Printf("✅ Completed in %v\n", time.Since(start))
-
-	case "engines":
-		fmt.Println("🔧 Supported Engines:")
-		statuses := checkEngineStatus()
-		for _, status := range statuses {
-			availability := "❌"
-			if status.Available {
-				availability = "✅"
-			}
-			fmt.Printf("  %s %s", availability, status.Name)
-			
-			switch status.Name {
-			case "puppeteer":
-				fmt.Print(" - Node.js, full Chrome control via DevTools protocol")
-			case "playwright":
-				fmt.Print(" - Node.js, cross-browser automation (Chrome, Firefox, Safari)")
-			case "selenium":
-				fmt.Print(" - Python, WebDriver-based, cross-language support")
-			case "gemini":
-				fmt.Print(" - CLI, Google AI integration for intelligent automation")
-			}
-			
-			if !status.Available {
-				fmt.Printf("\n    💡 %s", status.Error)
-			}
-			fmt.Println()
-		}
-		return
-
-	case "build":
-		fmt.Println("🔧 [Phantom Vite] Building project...")
-		start := time.Now()
-		if err := runViteBuild(); err != nil {
-			fmt.Printf("❌ Build failed: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Printf("✅ Build completed in %v\n", time.Since(start))
-
-	case "bundle":
-		args := os.Args[2:]
-		if len(args) < 1 {
-			fmt.Println("Usage: phantom-vite bundle <file> [--engine <engine>]")
-			return
-		}
-		
-		inputFile := args[0]
-		if !fileExists(inputFile) {
-			fmt.Printf("❌ File not found: %s\n", inputFile)
-			return
-		}
-		
-		fmt.Printf("📦 Bundling %s for %s engine...\n", inputFile, engine)
-		
-		start := time.Now()
-		if err := runViteBundle(inputFile); err != nil {
-			fmt.Printf("❌ Bundling failed: %v\n", err)
-			return
-		}
-		
-		fmt.Printf("✅ Bundling completed in %v\n", time.Since(start))
-
-	case "serve":
-		if len(os.Args) < 3 {
-			fmt.Println("Usage: phantom-vite serve <file>")
-			os.Exit(1)
-		}
-		file := os.Args[2]
-		
-		if !fileExists(file) {
-			fmt.Printf("❌ File not found: %s\n", file)
-			return
-		}
-		
-		fmt.Printf("🌐 Serving %s...\n", file)
-		cmd := exec.Command("npx", "vite", "preview", "--config", file)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
-		
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("❌ Serve error: %v\n", err)
-			os.Exit(1)
-		}
-
-case "agent":
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: phantom-vite agent <prompt>")
-		os.Exit(1)
-	}
-	prompt := strings.Join(os.Args[2:], " ")
-	fmt.Printf("🤖 Launching AI agent with prompt: %s\n", prompt)
-
-	pluginPaths, _ := LoadPlugins(cfg)
-	context := PluginContext{
-	Engine:   cfg.Engine,
-	Headless: cfg.Headless,
-	Plugins:  cfg.Plugins,
-	Timeout:  cfg.Timeout,
-	Viewport: cfg.Viewport,
-	Meta: struct {
-		Command string `json:"command"`
-		Script  string `json:"script,omitempty"`
-		URL     string `json:"url,omitempty"`
-	}{
-		Command: "agent",
-	},
-}
-	context.Meta.Command = "agent"
-ExecutePluginHooksWithContext("onStart", pluginPaths, context)
-
-	cmd := exec.Command(resolveCommand("python3"), "python/agent.py", prompt)
-	cmd.Dir = filepath.Join(filepath.Dir(os.Args[0]), "..") // Set working directory to project root
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("❌ Agent error: %v\n", err)
-		os.Exit(1)
-	}
-
-case "gemini":
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: phantom-vite gemini <prompt>")
-		os.Exit(1)
-	}
-	prompt := strings.Join(os.Args[2:], " ")
-	fmt.Printf("✨ Passing to Gemini CLI: %s\n", prompt)
-
-	pluginPaths, _ := LoadPlugins(cfg)
-	context := PluginContext{
-	Engine:   cfg.Engine,
-	Headless: cfg.Headless,
-	Plugins:  cfg.Plugins,
-	Timeout:  cfg.Timeout,
-	Viewport: cfg.Viewport,
-	Meta: struct {
-		Command string `json:"command"`
-		Script  string `json:"script,omitempty"`
-		URL     string `json:"url,omitempty"`
-	}{
-		Command: "gemini",
-	},
-}
-	context.Meta.Command = "gemini"
-ExecutePluginHooksWithContext("onStart", pluginPaths, context)
-
-	cmd := exec.Command("gemini", prompt)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("❌ Gemini CLI error: %v\n", err)
-	}
-
-	case "plugins":
-		cfg := loadConfig()
-		if len(cfg.Plugins) == 0 {
-			fmt.Println("📦 No plugins defined in phantomvite.config.json")
-			fmt.Println("💡 Add plugins to your config file:")
-			fmt.Println(`{
-  "plugins": [
-    "./plugins/seo.js",
-    "./plugins/logger.js",
-    "./plugins/performance.js"
-  ]
-}`)
-			return
-		}
-		
-		fmt.Println("📦 Plugin Status:")
-		for _, path := range cfg.Plugins {
-			if fileExists(path.Path) {
-				fmt.Printf("  ✅ %s\n", path)
-			} else {
-				fmt.Printf("  ❌ %s (not found)\n", path)
-			}
-		}
-
-default:
-	script := os.Args[1]
-
-	if !fileExists(script) {
-		fmt.Printf("❌ Script not found: %s\n", script)
-		fmt.Println("💡 Make sure the file path is correct")
-		return
-	}
-
-	ext := filepath.Ext(script)
-
-	if ext == ".ts" {
-		fmt.Printf("🔧 TypeScript detected, bundling %s...\n", script)
-
-		if err := runViteBundle(script); err != nil {
-			fmt.Printf("❌ Failed to bundle: %v\n", err)
-			return
-		}
-
-		baseName := strings.TrimSuffix(filepath.Base(script), ".ts")
-		bundledScript := filepath.Join("dist", baseName+".js")
-
-		if !fileExists(bundledScript) {
-			fmt.Printf("❌ Bundled file not found: %s\n", bundledScript)
-			if files, err := os.ReadDir("dist"); err == nil {
-				fmt.Println("📁 Files in dist directory:")
-				for _, file := range files {
-					fmt.Printf("  - %s\n", file.Name())
-				}
-			}
-			return
-		}
-
-		script = bundledScript
-		fmt.Printf("✅ Using bundled script: %s\n", script)
-	}
-
-	fmt.Printf("🚀 Running script: %s\n", script)
-	start := time.Now()
-
-	// ✅ Inject context before running the script
-	context := PluginContext{
-	Engine:   cfg.Engine,
-	Headless: cfg.Headless,
-	Plugins:  cfg.Plugins,
-	Timeout:  cfg.Timeout,
-	Viewport: cfg.Viewport,
-	Meta: struct {
-		Command string `json:"command"`
-		Script  string `json:"script,omitempty"`
-		URL     string `json:"url,omitempty"`
-	}{
-		Command: "run",
-		Script:  script,
-	},
-}
-	pluginPaths, _ := LoadPlugins(cfg)
-	ExecutePluginHooksWithContext("onStart", pluginPaths, context)
-
-	if err := runNodeScript(script); err != nil {
-		fmt.Printf("❌ Script execution failed: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("✅ Script completed in %v\n", time.Since(start))
+	if dir := projectRuntime(); dir != "" {
+		nodebridge.SetRuntimeDir(dir)
 	}
+	os.Exit(cli.Run(newRootCommand(), os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -0,0 +1,473 @@
+// output.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/crawl"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/pool"
+)
+
+// Output formats for the global --output flag
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func outputFlag() cli.Flag {
+	return cli.Flag{Name: "output", Value: "format", Default: outputText, Values: []string{outputText, outputJSON},
+		Usage: "print results as text or as one JSON document on stdout"}
+}
+
+// jsonOutput reports whether --output json was given
+func jsonOutput(ctx *cli.Context) bool {
+	return ctx.String("output") == outputJSON
+}
+
+// logOutput is where progress messages go: stdout for text output, stderr
+// for JSON output so stdout carries nothing but the document.
+func logOutput(ctx *cli.Context) io.Writer {
+	if jsonOutput(ctx) {
+		return ctx.Stderr
+	}
+	return ctx.Stdout
+}
+
+// textOnly fails with a usage error under --output json, for commands
+// whose stdout is the output of the program they run
+func textOnly(ctx *cli.Context) error {
+	if jsonOutput(ctx) {
+		return ctx.Usagef("'%s' does not support --output json; its output is that of the program it runs", ctx.Command.Path())
+	}
+	return nil
+}
+
+// A report is a command result, printed as text or marshaled as JSON. The
+// JSON field names are part of the CLI's interface; add fields, don't
+// rename them.
+type report interface {
+	writeText(w io.Writer)
+}
+
+// writeReport prints r in the format chosen with --output
+func writeReport(ctx *cli.Context, r report) error {
+	if !jsonOutput(ctx) {
+		r.writeText(ctx.Stdout)
+		return nil
+	}
+	return encodeJSON(ctx.Stdout, r)
+}
+
+// encodeJSON writes v as indented JSON, leaving HTML characters in URLs
+// and page text alone
+func encodeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// milliseconds is a duration written as whole milliseconds in JSON
+type milliseconds time.Duration
+
+func (ms milliseconds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(ms).Milliseconds())
+}
+
+func (ms milliseconds) String() string {
+	return time.Duration(ms).Round(time.Millisecond).String()
+}
+
+// doctorReport is the result of 'phantom-vite doctor'
+type doctorReport struct {
+	Runtimes []runtimeStatus       `json:"runtimes"`
+	Engines  []engine.EngineStatus `json:"engines"`
+	Config   doctorConfig          `json:"config"`
+}
+
+// runtimeStatus tells whether a language runtime the CLI shells out to is
+// installed
+type runtimeStatus struct {
+	Name      string `json:"name"` // go, node or python
+	Available bool   `json:"available"`
+}
+
+type doctorConfig struct {
+	Engine   string                `json:"engine"`
+	Headless bool                  `json:"headless"`
+	Viewport engine.ViewportConfig `json:"viewport"`
+	Timeout  milliseconds          `json:"timeout_ms"`
+}
+
+func (r *doctorReport) writeText(w io.Writer) {
+	fmt.Fprintln(w, "🏥 [Phantom Vite] Health Check")
+	fmt.Fprintln(w)
+	for _, rt := range r.Runtimes {
+		name := runtimeNames[rt.Name]
+		if rt.Available {
+			fmt.Fprintf(w, "✅ %s runtime available\n", name)
+		} else {
+			fmt.Fprintf(w, "❌ %s runtime not found\n", name)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "🔧 Engine Status:")
+	for _, status := range r.Engines {
+		if status.Available {
+			fmt.Fprintf(w, "  ✅ %s (%s)\n", status.Name, status.Path)
+		} else {
+			fmt.Fprintf(w, "  ❌ %s - %s\n", status.Name, status.Error)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "⚙️  Configuration:")
+	fmt.Fprintf(w, "  Default engine: %s\n", r.Config.Engine)
+	fmt.Fprintf(w, "  Headless mode: %t\n", r.Config.Headless)
+	fmt.Fprintf(w, "  Viewport: %dx%d\n", r.Config.Viewport.Width, r.Config.Viewport.Height)
+	fmt.Fprintf(w, "  Timeout: %v\n", time.Duration(r.Config.Timeout))
+}
+
+var runtimeNames = map[string]string{"go": "Go", "node": "Node.js", "python": "Python"}
+
+// enginesReport is the result of 'phantom-vite engines'
+type enginesReport struct {
+	Engines []engineInfo `json:"engines"`
+}
+
+type engineInfo struct {
+	engine.EngineStatus
+	Description string `json:"description,omitempty"`
+}
+
+func (r *enginesReport) writeText(w io.Writer) {
+	fmt.Fprintln(w, "🔧 Supported Engines:")
+	for _, e := range r.Engines {
+		availability := "❌"
+		if e.Available {
+			availability = "✅"
+		}
+		fmt.Fprintf(w, "  %s %s", availability, e.Name)
+		if e.Description != "" {
+			fmt.Fprintf(w, " - %s", e.Description)
+		}
+		if !e.Available {
+			fmt.Fprintf(w, "\n    💡 %s", e.Error)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// pluginsReport is the result of 'phantom-vite plugins'
+type pluginsReport struct {
+	Plugins []pluginStatus `json:"plugins"`
+}
+
+type pluginStatus struct {
+	Path    string `json:"path"`
+	Name    string `json:"name,omitempty"`
+	Enabled bool   `json:"enabled"`
+	Found   bool   `json:"found"`
+}
+
+func (r *pluginsReport) writeText(w io.Writer) {
+	if len(r.Plugins) == 0 {
+		fmt.Fprintln(w, "📦 No plugins defined in phantomvite.config.json")
+		fmt.Fprintln(w, "💡 Add plugins to your config file:")
+		fmt.Fprintln(w, `{
+  "plugins": [
+    {"path": "./plugins/seo.js"},
+    {"path": "./plugins/logger.js"},
+    {"path": "./plugins/performance.js", "enabled": false}
+  ]
+}`)
+		return
+	}
+
+	fmt.Fprintln(w, "📦 Plugin Status:")
+	for _, plugin := range r.Plugins {
+		switch {
+		case !plugin.Enabled:
+			fmt.Fprintf(w, "  ⏸️  %s (disabled)\n", plugin.Path)
+		case plugin.Found:
+			fmt.Fprintf(w, "  ✅ %s\n", plugin.Path)
+		default:
+			fmt.Fprintf(w, "  ❌ %s (not found)\n", plugin.Path)
+		}
+	}
+}
+
+// pageReport is what 'open' and 'pdf' report about the page they load.
+// When the command fails, Error says why, Kind classifies it when it can,
+// and the fields after them are only set for the steps that completed.
+type pageReport struct {
+	URL    string           `json:"url"`
+	Engine string           `json:"engine"`
+	Error  string           `json:"error,omitempty"`
+	Kind   engine.ErrorKind `json:"error_kind,omitempty"`
+	Title  string           `json:"title,omitempty"`
+}
+
+// openReport is the result of 'phantom-vite open'
+type openReport struct {
+	pageReport
+	Screenshot string      `json:"screenshot,omitempty"` // file path
+	Timings    openTimings `json:"timings"`
+}
+
+// openTimings are the durations of each step of 'open'
+type openTimings struct {
+	Launch     milliseconds `json:"launch_ms"`
+	Navigate   milliseconds `json:"navigate_ms"`
+	Screenshot milliseconds `json:"screenshot_ms"`
+	Total      milliseconds `json:"total_ms"`
+}
+
+func (r *openReport) writeText(w io.Writer) {
+	if r.Title != "" {
+		fmt.Fprintln(w, "[Phantom Vite] Title:", r.Title)
+	}
+	if r.Screenshot != "" {
+		fmt.Fprintln(w, "📸 Screenshot:", r.Screenshot)
+	}
+	fmt.Fprintf(w, "✅ Completed in %v\n", r.Timings.Total)
+}
+
+// pdfReport is the result of 'phantom-vite pdf'
+type pdfReport struct {
+	pageReport
+	PDF     string     `json:"pdf,omitempty"` // file path
+	Timings pdfTimings `json:"timings"`
+}
+
+// pdfTimings are the durations of each step of 'pdf'
+type pdfTimings struct {
+	Launch   milliseconds `json:"launch_ms"`
+	Navigate milliseconds `json:"navigate_ms"`
+	PDF      milliseconds `json:"pdf_ms"`
+	Total    milliseconds `json:"total_ms"`
+}
+
+func (r *pdfReport) writeText(w io.Writer) {
+	if r.Title != "" {
+		fmt.Fprintln(w, "[Phantom Vite] Title:", r.Title)
+	}
+	if r.PDF != "" {
+		fmt.Fprintln(w, "📄 PDF:", r.PDF)
+	}
+	fmt.Fprintf(w, "✅ Completed in %v\n", r.Timings.Total)
+}
+
+// batchReport is the result of 'phantom-vite batch', also saved as
+// summary.json in Dir. Results are in the order of the URL file.
+type batchReport struct {
+	Engine  string        `json:"engine"`
+	Dir     string        `json:"dir"`
+	OK      int           `json:"ok"`
+	Failed  int           `json:"failed"`
+	Results []batchResult `json:"results"`
+	Timings batchTimings  `json:"timings"`
+	Pool    pool.Stats    `json:"pool"`
+}
+
+// batchResult is what happened to one URL of a batch
+type batchResult struct {
+	URL        string             `json:"url"`
+	Line       int                `json:"line"`   // of the URL file
+	Status     string             `json:"status"` // "ok" or "failed"
+	Error      string             `json:"error,omitempty"`
+	Kind       engine.ErrorKind   `json:"error_kind,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Screenshot string             `json:"screenshot,omitempty"` // file path
+	Timings    batchResultTimings `json:"timings"`
+}
+
+// batchTimings are the durations of a whole batch. Launch adds up the
+// launches of every browser.
+type batchTimings struct {
+	Launch milliseconds `json:"launch_ms"`
+	Total  milliseconds `json:"total_ms"`
+}
+
+// batchResultTimings are the durations of each step for one URL
+type batchResultTimings struct {
+	Navigate   milliseconds `json:"navigate_ms"`
+	Screenshot milliseconds `json:"screenshot_ms"`
+	Total      milliseconds `json:"total_ms"`
+}
+
+func (r *batchReport) writeText(w io.Writer) {
+	fmt.Fprintf(w, "✅ %d of %d pages captured in %v; summary in %s\n",
+		r.OK, len(r.Results), r.Timings.Total, filepath.Join(r.Dir, batchSummary))
+}
+
+// crawlReport is the result of 'phantom-vite crawl', also saved as File:
+// the crawl graph with counts of the pages that loaded and failed
+type crawlReport struct {
+	Engine string `json:"engine"`
+	File   string `json:"file"`
+	*crawl.Result
+	OK      int          `json:"ok"`
+	Failed  int          `json:"failed"`
+	Timings crawlTimings `json:"timings"`
+	Pool    pool.Stats   `json:"pool"`
+}
+
+// crawlTimings are the durations of a whole crawl
+type crawlTimings struct {
+	Total milliseconds `json:"total_ms"`
+}
+
+func (r *crawlReport) writeText(w io.Writer) {
+	fmt.Fprintf(w, "✅ Crawled %d pages (%d failed, %d blocked by robots.txt) in %v; graph in %s\n",
+		len(r.Pages), r.Failed, len(r.Blocked), r.Timings.Total, r.File)
+	if r.Truncated {
+		fmt.Fprintln(w, "💡 --max-pages left links unvisited")
+	}
+}
+
+// scrapeReport is the result of 'phantom-vite scrape' with --out; without
+// it, stdout carries the records instead
+type scrapeReport struct {
+	URL     string        `json:"url"`
+	Engine  string        `json:"engine"`
+	File    string        `json:"file"`
+	Format  string        `json:"format"`
+	Pages   int           `json:"pages"`
+	Records int           `json:"records"`
+	Timings scrapeTimings `json:"timings"`
+}
+
+// scrapeTimings are the durations of a scrape
+type scrapeTimings struct {
+	Launch milliseconds `json:"launch_ms"`
+	Total  milliseconds `json:"total_ms"`
+}
+
+func (r *scrapeReport) writeText(w io.Writer) {
+	fmt.Fprintf(w, "✅ Scraped %d records from %d pages in %v; %s in %s\n", r.Records, r.Pages, r.Timings.Total, r.Format, r.File)
+}
+
+// stepsReport is the result of 'phantom-vite steps'. Files are in the
+// order of the arguments; a stopped run leaves out those it did not reach.
+type stepsReport struct {
+	Engine  string       `json:"engine"`
+	Passed  int          `json:"passed"`
+	Failed  int          `json:"failed"`
+	Files   []stepsFile  `json:"files"`
+	Timings stepsTimings `json:"timings"`
+}
+
+// stepsFile is what happened to one step file; Line is the line of the
+// step that failed
+type stepsFile struct {
+	Path    string           `json:"path"`
+	Status  string           `json:"status"` // "ok" or "failed"
+	Error   string           `json:"error,omitempty"`
+	Kind    engine.ErrorKind `json:"error_kind,omitempty"`
+	Line    int              `json:"line,omitempty"`
+	Timings stepsTimings     `json:"timings"`
+}
+
+// stepsTimings are the durations of a run or a file
+type stepsTimings struct {
+	Total milliseconds `json:"total_ms"`
+}
+
+func (r *stepsReport) writeText(w io.Writer) {
+	mark := "✅"
+	if r.Failed > 0 {
+		mark = "❌"
+	}
+	fmt.Fprintf(w, "%s %d of %d step files passed in %v\n", mark, r.Passed, len(r.Files), r.Timings.Total)
+}
+
+// buildReport is the result of 'phantom-vite build', and of 'bundle' with
+// the bundled file and engine set
+type buildReport struct {
+	File    string       `json:"file,omitempty"`
+	Engine  string       `json:"engine,omitempty"`
+	Timings buildTimings `json:"timings"`
+}
+
+type buildTimings struct {
+	Total milliseconds `json:"total_ms"`
+}
+
+func (r *buildReport) writeText(w io.Writer) {
+	if r.File == "" {
+		fmt.Fprintf(w, "✅ Build completed in %v\n", r.Timings.Total)
+		return
+	}
+	fmt.Fprintf(w, "✅ Bundling completed in %v\n", r.Timings.Total)
+}
+
+// serverReport is printed when 'phantom-vite server' shuts down
+type serverReport struct {
+	Engine string       `json:"engine"`
+	Addr   string       `json:"addr"`
+	Uptime milliseconds `json:"uptime_ms"`
+	Pool   pool.Stats   `json:"pool"`
+}
+
+func (r *serverReport) writeText(w io.Writer) {
+	fmt.Fprintf(w, "✅ Served %d requests in %v\n", r.Pool.Acquired, r.Uptime)
+}
+
+// configReport is the result of 'phantom-vite config show'. Config is the
+// effective configuration; Values is set instead with --origin.
+type configReport struct {
+	Config *config.Config `json:"config,omitempty"`
+	Values []config.Value `json:"values,omitempty"`
+}
+
+func (r *configReport) writeText(w io.Writer) {
+	if r.Values == nil {
+		data, _ := json.MarshalIndent(r.Config, "", "  ")
+		fmt.Fprintln(w, string(data))
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, v := range r.Values {
+		value, _ := json.Marshal(v.Value)
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Key, value, v.Origin)
+	}
+	tw.Flush()
+}
+
+// validateReport is the result of 'phantom-vite config validate'
+type validateReport struct {
+	File     string               `json:"file"`
+	Valid    bool                 `json:"valid"`
+	Problems []*config.FieldError `json:"problems"`
+}
+
+// writeConfigReport is the result of 'phantom-vite config init' and
+// 'config convert', which sets the file it converted
+type writeConfigReport struct {
+	File   string `json:"file"`
+	Format string `json:"format"`
+	Source string `json:"source,omitempty"`
+}
+
+func (r *writeConfigReport) writeText(w io.Writer) {
+	fmt.Fprintf(w, "✅ Wrote %s\n", r.File)
+}
+
+func (r *validateReport) writeText(w io.Writer) {
+	if r.Valid {
+		fmt.Fprintf(w, "✅ %s is valid\n", r.File)
+		return
+	}
+	err := &config.SourceError{Source: r.File, Err: config.Errors(r.Problems)}
+	fmt.Fprintln(w, err.Error())
+}
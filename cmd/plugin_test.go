@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
 )
 
 func TestLoadPlugins_ValidPaths(t *testing.T) {
@@ -20,7 +23,7 @@ func TestLoadPlugins_ValidPaths(t *testing.T) {
 	}
 	defer os.Remove(tempPlugin)
 
-	cfg := Config{Plugins: []PluginConfig{{Path: tempPlugin}}}
+	cfg := config.Config{Plugins: []engine.PluginConfig{{Path: tempPlugin, Enabled: true}}}
 
 	paths, err := LoadPlugins(cfg)
 	if err != nil {
@@ -33,7 +36,7 @@ func TestLoadPlugins_ValidPaths(t *testing.T) {
 }
 
 func TestLoadPlugins_InvalidPath(t *testing.T) {
-	cfg := Config{Plugins: []PluginConfig{{Path: "nonexistent/plugin.js"}}}
+	cfg := config.Config{Plugins: []engine.PluginConfig{{Path: "nonexistent/plugin.js", Enabled: true}}}
 
 	_, err := LoadPlugins(cfg)
 	if err == nil {
@@ -0,0 +1,138 @@
+// scrape.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/scrape"
+)
+
+func scrapeCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "scrape",
+		Summary: "Extract records from a page as a JSON spec declares them",
+		Help: "The spec names the elements of the records with \"items\" and what each\n" +
+			"record holds with \"fields\". A field is a CSS selector for the text of its\n" +
+			"first match, or an object with \"selector\", \"attr\" or \"property\" to\n" +
+			"read instead of the text, \"list\" for every match and \"fields\" for a\n" +
+			"nested object. \"next\" is the selector of the link to the next page,\n" +
+			"followed up to \"max_pages\" pages (10 by default):\n" +
+			"  {\"items\": \"article\", \"fields\": {\"title\": \"h2\",\n" +
+			"   \"url\": {\"selector\": \"a\", \"attr\": \"href\"}}, \"next\": \"a[rel=next]\"}\n" +
+			"Records go to stdout, or to --out; CSV has a column per top-level field,\n" +
+			"with lists and objects as JSON. Plugins run onStart and onExit.",
+		Args: []cli.Arg{{Name: "url", Usage: "page to start at"}},
+		Flags: append(navigationFlags(),
+			cli.Flag{Name: "spec", Value: "file", Complete: cli.CompleteFile, Usage: "extraction spec (required)"},
+			cli.Flag{Name: "format", Value: "format", Default: scrape.FormatJSON, Values: scrape.Formats, Usage: "record format"},
+			cli.Flag{Name: "out", Value: "file", Complete: cli.CompleteFile, Usage: "where to write the records instead of stdout"},
+			cli.Flag{Name: "max-pages", Value: "n", Default: "0", Usage: "pages to read following \"next\"; 0 keeps the spec's max_pages"},
+		),
+		Examples: []string{
+			"phantom-vite scrape https://example.com/products --spec products.json",
+			"phantom-vite scrape https://example.com/products --spec products.json --format csv --out products.csv",
+			"phantom-vite scrape https://news.example.com --spec stories.json --format ndjson --max-pages 3 | jq .title",
+		},
+		Run: runScrape,
+	}
+}
+
+func runScrape(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	if ctx.String("spec") == "" {
+		return ctx.Usagef("--spec is required")
+	}
+	maxPages, err := ctx.Int("max-pages")
+	if err != nil {
+		return err
+	}
+	if maxPages < 0 {
+		return ctx.Usagef("--max-pages must be at least 0, got %d", maxPages)
+	}
+	spec, err := scrape.LoadSpec(ctx.String("spec"))
+	if err != nil {
+		return withKind(engine.KindConfig, err)
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	// Without --out, stdout carries the records, so progress goes to stderr.
+	r := &scrapeReport{URL: ctx.Arg("url"), Engine: cfg.Engine, File: ctx.String("out"), Format: ctx.String("format")}
+	var out io.Writer = ctx.Stdout
+	log := ctx.Stderr
+	if r.File != "" {
+		f, err := os.Create(r.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out, log = f, logOutput(ctx)
+	}
+	records, err := scrape.NewWriter(out, r.Format, spec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(log, "🚀 Scraping %s with %s engine...\n", r.URL, cfg.Engine)
+	start := time.Now()
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, cfg.Engine, "scrape")
+	pctx.Meta.URL = r.URL
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	step := time.Now()
+	eng, err := engine.Open(cfg.Engine, cfg.EngineConfig())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	defer eng.Close()
+	page, err := eng.NewPage(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to open page: %w", err)
+	}
+	if err := emulateDevice(page, cfg); err != nil {
+		return err
+	}
+	r.Timings.Launch = milliseconds(time.Since(step))
+
+	r.Pages, err = scrape.Scrape(page, r.URL, spec, scrape.Options{
+		Navigation: navigationOptions(ctx, cfg),
+		MaxPages:   maxPages,
+		Log:        log,
+	}, records.Write)
+	r.Records = records.Count()
+	if closeErr := records.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	r.Timings.Total = milliseconds(time.Since(start))
+
+	if err := ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+	if r.File == "" {
+		return nil
+	}
+	return writeReport(ctx, r)
+}
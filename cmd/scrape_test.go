@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// listing is a paged listing: every page has a heading, and all but the
+// last link to the next one
+func listing(url string) *enginetest.Element {
+	doc := &enginetest.Element{Matches: map[string][]*enginetest.Element{
+		"h1": {{Text: " " + url + " "}},
+	}}
+	if !strings.Contains(url, "page=2") {
+		doc.Matches["a.next"] = []*enginetest.Element{{Attrs: map[string]string{"href": "?page=2"}}}
+	}
+	return doc
+}
+
+func TestScrape(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	useEngine(t, &enginetest.Engine{Document: listing})
+	os.WriteFile("spec.json", []byte(`{"fields": {"heading": "h1", "next": {"selector": "a.next", "attr": "href"}}, "next": "a.next"}`), 0644)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"scrape", "https://example.com/list", "--engine", "fake", "--spec", "spec.json", "--format", "csv"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitOK {
+		t.Fatalf("expected exit code %d, got %d\n%s", cli.ExitOK, code, stderr.String())
+	}
+	want := "heading,next\n" +
+		"https://example.com/list,?page=2\n" +
+		"https://example.com/list?page=2,\n"
+	if stdout.String() != want {
+		t.Errorf("expected the records of both pages on stdout:\n%s\ngot\n%s", want, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "[2] https://example.com/list?page=2: 1 records") {
+		t.Errorf("expected progress on stderr, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	args = []string{"scrape", "https://example.com/list", "--engine", "fake", "--spec", "spec.json", "--max-pages", "1", "--format", "ndjson", "--out", "records.ndjson", "--output", "json"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitOK {
+		t.Fatalf("expected exit code %d, got %d\n%s", cli.ExitOK, code, stderr.String())
+	}
+	if data, _ := os.ReadFile("records.ndjson"); string(data) != `{"heading":"https://example.com/list","next":"?page=2"}`+"\n" {
+		t.Errorf("unexpected records %q", data)
+	}
+	if !strings.Contains(stdout.String(), `"records": 1`) || !strings.Contains(stdout.String(), `"pages": 1`) {
+		t.Errorf("expected the report on stdout, got %s", stdout.String())
+	}
+}
+
+func TestScrapeErrors(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	os.WriteFile("bad.json", []byte(`{"fields": {}}`), 0644)
+
+	for _, tc := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"scrape", "https://example.com", "--engine", "fake"}, cli.ExitUsage},
+		{[]string{"scrape", "https://example.com", "--engine", "fake", "--spec", "bad.json"}, exitConfig},
+		{[]string{"scrape", "https://example.com", "--engine", "fake", "--spec", "bad.json", "--format", "xml"}, cli.ExitUsage},
+		{[]string{"scrape", "https://example.com", "--engine", "fake", "--spec", "bad.json", "--max-pages", "-1"}, cli.ExitUsage},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := cli.Run(newRootCommand(), tc.args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d\n%s", tc.args, tc.code, code, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// server.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/pool"
+	"phantomvite/pkg/remote"
+	"phantomvite/pkg/remote/remotepb"
+	"phantomvite/pkg/server"
+)
+
+func serverCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "server",
+		Summary: "Serve screenshots, PDFs, content and script results over HTTP",
+		Help: "Endpoints take a JSON body with the url to load and how to load it\n" +
+			"(timeout, wait_until, wait_for_selector, referer):\n" +
+			"  POST /screenshot  with screenshot options such as format and full_page\n" +
+			"  POST /pdf         with PDF options such as format and margin\n" +
+			"  POST /content     returns the url, title and HTML of the page\n" +
+			"  POST /evaluate    with a script; returns its result\n" +
+			"  GET  /health      returns the state of the browser pool\n" +
+			"Failures are JSON {\"error\", \"error_kind\"}. Requests wait for a free page\n" +
+			"within --request-timeout. Each request runs in a browser of its own, so\n" +
+			"--concurrency browsers run at once. On SIGINT or SIGTERM the server stops taking\n" +
+			"requests and finishes running ones within --shutdown-timeout.\n" +
+			"With --grpc-addr it also serves the Browser gRPC service of\n" +
+			"pkg/remote/remotepb, whose sessions open any registered engine.",
+		Flags: []cli.Flag{
+			{Name: "addr", Value: "host:port", Default: "127.0.0.1:8080", Usage: "address to listen on"},
+			{Name: "concurrency", Value: "n", Default: "4", Usage: "requests served at the same time, each in its own browser; others wait"},
+			{Name: "max-uses", Value: "n", Default: "0", Usage: "replace a browser after it has loaded n pages; 0 keeps it"},
+			{Name: "request-timeout", Value: "duration", Default: "60s", Usage: "limit of each request, including the wait for a page"},
+			{Name: "shutdown-timeout", Value: "duration", Default: "30s", Usage: "how long running requests may finish on shutdown"},
+			{Name: "grpc-addr", Value: "host:port", Usage: "also serve the Browser gRPC service on this address"},
+		},
+		Examples: []string{
+			"phantom-vite server",
+			"phantom-vite server --addr :9000 --concurrency 8",
+			"phantom-vite server --grpc-addr 127.0.0.1:9090",
+			"curl -d '{\"url\": \"https://example.com\", \"full_page\": true}' localhost:8080/screenshot > page.png",
+		},
+		Run: runServer,
+	}
+}
+
+func runServer(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	var concurrency, maxUses int
+	for _, f := range []struct {
+		name string
+		n    *int
+		min  int
+	}{{"concurrency", &concurrency, 1}, {"max-uses", &maxUses, 0}} {
+		if *f.n, err = ctx.Int(f.name); err != nil {
+			return err
+		}
+		if *f.n < f.min {
+			return ctx.Usagef("--%s must be at least %d, got %d", f.name, f.min, *f.n)
+		}
+	}
+	var requestTimeout, shutdownTimeout config.Duration
+	for _, f := range []struct {
+		name string
+		d    *config.Duration
+	}{{"request-timeout", &requestTimeout}, {"shutdown-timeout", &shutdownTimeout}} {
+		if *f.d, err = config.ParseDuration(ctx.String(f.name)); err != nil || *f.d <= 0 {
+			return ctx.Usagef("--%s must be a positive duration such as 30s, got %q", f.name, ctx.String(f.name))
+		}
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	// Pages of a browser share its cookie jar, so requests get a browser each
+	pages, err := pool.New(pool.Config{
+		Launch:          pool.Open(cfg.Engine, cfg.EngineConfig()),
+		Browsers:        concurrency,
+		PagesPerBrowser: 1,
+		MaxUses:         maxUses,
+		HealthCheck:     time.Minute,
+	})
+	if err != nil {
+		return err
+	}
+	defer pages.Close()
+
+	listener, err := net.Listen("tcp", ctx.String("addr"))
+	if err != nil {
+		return err
+	}
+	log := logOutput(ctx)
+	srv := &http.Server{
+		Handler: server.New(server.Config{
+			Pages:          pages,
+			RequestTimeout: time.Duration(requestTimeout),
+			Device:         cfg.Device,
+			Log:            log,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	served := make(chan error, 2)
+	go func() { served <- srv.Serve(listener) }()
+
+	var sessions *remote.Server
+	var gs *grpc.Server
+	if addr := ctx.String("grpc-addr"); addr != "" {
+		grpcListener, err := net.Listen("tcp", addr)
+		if err != nil {
+			srv.Close()
+			return err
+		}
+		sessions = remote.NewServer(cfg.EngineConfig())
+		defer sessions.Close()
+		gs = grpc.NewServer()
+		remotepb.RegisterBrowserServer(gs, sessions)
+		go func() { served <- gs.Serve(grpcListener) }()
+		fmt.Fprintf(log, "🔌 Serving the Browser gRPC service on %s\n", grpcListener.Addr())
+	}
+
+	start := time.Now()
+	fmt.Fprintf(log, "🚀 Serving %s engine on http://%s (Ctrl+C to stop)\n", cfg.Engine, listener.Addr())
+	select {
+	case err := <-served:
+		srv.Close()
+		if gs != nil {
+			gs.Stop()
+		}
+		return err
+	case <-stop.Done():
+	}
+
+	fmt.Fprintf(log, "🛑 Shutting down, waiting up to %v for running requests...\n", time.Duration(shutdownTimeout))
+	shutdown, done := context.WithTimeout(context.Background(), time.Duration(shutdownTimeout))
+	defer done()
+	// HTTP and gRPC drain at the same time, under the one deadline
+	grpcStopped := make(chan struct{})
+	if gs != nil {
+		go func() {
+			gs.GracefulStop()
+			close(grpcStopped)
+		}()
+	} else {
+		close(grpcStopped)
+	}
+	err = srv.Shutdown(shutdown)
+	select {
+	case <-grpcStopped:
+	case <-shutdown.Done():
+		if gs != nil {
+			gs.Stop()
+		}
+		<-grpcStopped
+		if err == nil {
+			err = shutdown.Err()
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("requests still running after %v; closing the browsers under them", time.Duration(shutdownTimeout))
+	}
+	r := &serverReport{Engine: cfg.Engine, Addr: listener.Addr().String(), Uptime: milliseconds(time.Since(start)), Pool: pages.Stats()}
+	if err := writeReport(ctx, r); err != nil {
+		return err
+	}
+	return err
+}
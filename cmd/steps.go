@@ -0,0 +1,143 @@
+// steps.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/steps"
+)
+
+func stepsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "steps",
+		Summary: "Run .gemini step files, one browser step per line",
+		Help: "Each line of a step file is a step, optionally after the word gemini:\n" +
+			"  gemini open https://example.com\n" +
+			"  gemini expect-title \"Example Domain\"\n" +
+			"The steps are open URL, click SELECTOR, type SELECTOR TEXT, wait DURATION,\n" +
+			"wait SELECTOR [TIMEOUT], expect-title TITLE, expect-text [SELECTOR] TEXT,\n" +
+			"screenshot FILE [full-page] and set-viewport WIDTHxHEIGHT. Quote arguments\n" +
+			"with spaces; lines starting with # are comments. expect-text looks for\n" +
+			"TEXT in the page, or in the first element matching SELECTOR. wait\n" +
+			"SELECTOR waits up to --timeout for a visible match.\n\n" +
+			"Every file is checked before any runs, then each runs on a new page and\n" +
+			"stops at its first failing step. Errors name the file and line of the\n" +
+			"step. Plugins run onStart and onExit. The exit status is 1 when a file\n" +
+			"fails.",
+		Args:  []cli.Arg{{Name: "files", Variadic: true, Complete: cli.CompleteFile, Usage: ".gemini step files"}},
+		Flags: navigationFlags(),
+		Examples: []string{
+			"phantom-vite steps scripts/gemini-test.gemini",
+			"phantom-vite steps smoke/*.gemini --engine playwright --timeout 10s",
+			"phantom-vite steps smoke/*.gemini --output json",
+		},
+		Run: runSteps,
+	}
+}
+
+func runSteps(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	scripts := make([]*steps.Script, len(ctx.Args))
+	for i, file := range ctx.Args {
+		if scripts[i], err = steps.ParseFile(file); err != nil {
+			return withKind(engine.KindScript, err)
+		}
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	log := logOutput(ctx)
+	r := &stepsReport{Engine: cfg.Engine, Files: make([]stepsFile, len(scripts))}
+	fmt.Fprintf(log, "🧪 Running %d step files with %s engine...\n", len(scripts), cfg.Engine)
+	start := time.Now()
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, cfg.Engine, "steps")
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	eng, err := engine.Open(cfg.Engine, cfg.EngineConfig())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	defer eng.Close()
+
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	opts := steps.Options{
+		Navigation: navigationOptions(ctx, cfg),
+		Timeout:    time.Duration(cfg.Timeout),
+		Log:        log,
+	}
+	for i, script := range scripts {
+		if stop.Err() != nil {
+			r.Files = r.Files[:i]
+			break
+		}
+		fmt.Fprintf(log, "📄 %s\n", script.Path)
+		res := &r.Files[i]
+		res.Path = script.Path
+		step := time.Now()
+		err := runStepFile(stop, eng, script, opts, cfg)
+		res.Timings.Total = milliseconds(time.Since(step))
+		if err == nil {
+			res.Status = batchOK
+			r.Passed++
+			continue
+		}
+		res.Status, res.Error, res.Kind = batchFailed, err.Error(), engine.KindOf(err)
+		var stepErr *steps.Error
+		if errors.As(err, &stepErr) {
+			res.Line = stepErr.Line
+		}
+		r.Failed++
+		fmt.Fprintf(log, "  ❌ %v\n", err)
+	}
+	r.Timings.Total = milliseconds(time.Since(start))
+
+	if err := ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+	if err := writeReport(ctx, r); err != nil {
+		return err
+	}
+	switch {
+	case stop.Err() != nil:
+		return fmt.Errorf("stopped after %d of %d step files", len(r.Files), len(scripts))
+	case r.Failed > 0:
+		return fmt.Errorf("%d of %d step files failed", r.Failed, len(scripts))
+	}
+	return nil
+}
+
+// runStepFile runs script on a new page of eng
+func runStepFile(ctx context.Context, eng engine.Engine, script *steps.Script, opts steps.Options, cfg config.Config) error {
+	page, err := eng.NewPage(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to open page: %w", err)
+	}
+	defer page.Close()
+	if err := emulateDevice(page, cfg); err != nil {
+		return err
+	}
+	return script.Run(ctx, page, opts)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+func TestStepsCommand(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	useEngine(t, &enginetest.Engine{Document: func(url string) *enginetest.Element {
+		return &enginetest.Element{Matches: map[string][]*enginetest.Element{"h1": {{Text: url}}}}
+	}})
+	os.WriteFile("home.gemini", []byte(`# Gemini test file
+gemini open https://example.com
+gemini expect-title "Title of https://example.com"
+gemini expect-text h1 example.com
+gemini screenshot shots/home.png
+`), 0644)
+	os.WriteFile("broken.gemini", []byte("gemini open https://example.com\n\ngemini expect-title \"Example Domain\"\n"), 0644)
+	os.WriteFile("slow.gemini", []byte("gemini open https://slow.example.com\n"), 0644)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"steps", "home.gemini", "--engine", "fake"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitOK {
+		t.Fatalf("expected exit code %d, got %d\n%s%s", cli.ExitOK, code, stdout.String(), stderr.String())
+	}
+	for _, want := range []string{"📄 home.gemini\n", "  ✅ 3: expect-title \"Title of https://example.com\" (", "✅ 1 of 1 step files passed in "} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected %q in\n%s", want, stdout.String())
+		}
+	}
+	if data, err := os.ReadFile("shots/home.png"); err != nil || string(data) != "png" {
+		t.Errorf("expected a PNG screenshot, got %q, %v", data, err)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	args = []string{"steps", "home.gemini", "broken.gemini", "slow.gemini", "--engine", "fake", "--output", "json"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitError {
+		t.Fatalf("expected exit code %d, got %d\n%s", cli.ExitError, code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `  ❌ broken.gemini:3: expected the title "Example Domain", got "Title of https://example.com"`) {
+		t.Errorf("expected the failing line on stderr, got\n%s", stderr.String())
+	}
+	var r struct {
+		Passed, Failed int
+		Files          []struct {
+			Path, Status, Error string
+			Kind                string `json:"error_kind"`
+			Line                int
+		}
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatalf("%v\n%s", err, stdout.String())
+	}
+	if r.Passed != 1 || r.Failed != 2 || len(r.Files) != 3 {
+		t.Fatalf("expected 1 passed and 2 failed, got %s", stdout.String())
+	}
+	if f := r.Files[1]; f.Path != "broken.gemini" || f.Status != "failed" || f.Line != 3 {
+		t.Errorf("expected broken.gemini to fail at line 3, got %+v", f)
+	}
+	if f := r.Files[2]; f.Line != 1 || f.Kind != "navigation_timeout" {
+		t.Errorf("expected slow.gemini to time out at line 1, got %+v", f)
+	}
+
+	// Every file is parsed before the engine starts
+	os.WriteFile("typo.gemini", []byte("gemini open https://example.com\ngemini clik button\n"), 0644)
+	stderr.Reset()
+	args = []string{"steps", "home.gemini", "typo.gemini", "--engine", "fake"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != exitScript {
+		t.Fatalf("expected exit code %d, got %d\n%s", exitScript, code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `typo.gemini:2: unknown step "clik"`) {
+		t.Errorf("expected the line of the typo, got\n%s", stderr.String())
+	}
+}
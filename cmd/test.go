@@ -0,0 +1,230 @@
+// test.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"phantomvite/pkg/cli"
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/nodebridge"
+	"phantomvite/pkg/phantomtest"
+)
+
+func testCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "test",
+		Summary: "Run *.phantom.js and *.phantom.ts test files",
+		Help: "Test files use describe, test and expect, and drive pages of the configured\n" +
+			"engine through the phantom module:\n" +
+			"  import { phantom } from 'phantom-vite';\n" +
+			"  const page = await phantom.goto('https://example.com');\n" +
+			"Pages have title, url, content, evaluate, click, type, fill,\n" +
+			"waitForSelector, screenshot and close, and are closed when their test\n" +
+			"ends. Files are bundled with Vite and run one after another, each in its\n" +
+			"own Node.js process. A test fails after --test-timeout, or the timeout it\n" +
+			"passes as the third argument of test, in milliseconds. Without patterns,\n" +
+			"every test file under the current directory runs, skipping node_modules,\n" +
+			"dist and hidden directories. Plugins run onStart and onExit. The exit\n" +
+			"status is 1 when a test or file fails.\n\n" +
+			"--reporter picks the results format: console, junit (JUnit XML for\n" +
+			"Jenkins and GitLab), tap (TAP version 13) or json, the default with\n" +
+			"--output json. Each has the duration of every test, and its failure\n" +
+			"message, stack and screenshots. The results go to --reporter-out or\n" +
+			"stdout; other reporters than console also print the console's lines, on\n" +
+			"stdout when the results go to a file and on stderr when they don't.",
+		Args: []cli.Arg{{Name: "patterns", Optional: true, Variadic: true, Complete: cli.CompleteFile,
+			Usage: "test files, directories or globs; ** matches any number of directories"}},
+		Flags: append(navigationFlags(),
+			cli.Flag{Name: "test-timeout", Value: "duration", Default: "30s", Usage: "limit of each test"},
+			cli.Flag{Name: "reporter", Value: "name", Default: phantomtest.ReporterConsole, Values: phantomtest.ReporterNames, Usage: "format of the results"},
+			cli.Flag{Name: "reporter-out", Value: "file", Complete: cli.CompleteFile, Usage: "where to write the results instead of stdout"},
+		),
+		Examples: []string{
+			"phantom-vite test",
+			"phantom-vite test tests/example.phantom.js",
+			"phantom-vite test 'tests/**/*.phantom.ts' --test-timeout 1m --engine playwright",
+			"phantom-vite test --reporter junit --reporter-out results.xml",
+			"phantom-vite test --reporter tap | tap-summary",
+			"phantom-vite test --output json > results.json",
+		},
+		Run: runTests,
+	}
+}
+
+func runTests(ctx *cli.Context) error {
+	resolved, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := resolved.Config
+	timeout, err := config.ParseDuration(ctx.String("test-timeout"))
+	if err != nil || timeout <= 0 {
+		return ctx.Usagef("--test-timeout must be a duration such as 30s, got %q", ctx.String("test-timeout"))
+	}
+	files, err := phantomtest.Discover(ctx.Args)
+	if err != nil {
+		return err
+	}
+	if err := validateEngine(cfg.Engine); err != nil {
+		if jsonOutput(ctx) {
+			return err
+		}
+		return fmt.Errorf("%w\n💡 Run 'phantom-vite doctor' to check your setup", err)
+	}
+
+	reporterName := ctx.String("reporter")
+	if !ctx.IsSet("reporter") && jsonOutput(ctx) {
+		reporterName = phantomtest.ReporterJSON
+	}
+	var results io.Writer = ctx.Stdout
+	if file := ctx.String("reporter-out"); file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		results = f
+	}
+	reporter, err := phantomtest.NewReporter(reporterName, results)
+	if err != nil {
+		return err
+	}
+	// Bundling, the output of the tests and the console's lines go where
+	// the results don't
+	log := ctx.Stdout
+	if reporterName != phantomtest.ReporterConsole {
+		if results == ctx.Stdout {
+			log = ctx.Stderr
+		}
+		console, _ := phantomtest.NewReporter(phantomtest.ReporterConsole, log)
+		reporter = phantomtest.MultiReporter(console, reporter)
+	}
+
+	fmt.Fprintf(log, "🧪 Running %d test files with %s engine...\n", len(files), cfg.Engine)
+	pluginPaths, err := LoadPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	pctx := newPluginContext(cfg, cfg.Engine, "test")
+	if err := ExecutePluginHooksWithContext("onStart", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+
+	eng, err := engine.Open(cfg.Engine, cfg.EngineConfig())
+	if err != nil {
+		return fmt.Errorf("failed to start engine: %w", err)
+	}
+	defer eng.Close()
+
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	result, runErr := phantomtest.Run(stop, files, phantomtest.Config{
+		Engine:     eng,
+		Bundle:     func(files []string) ([]string, error) { return bundleTests(files, log) },
+		RuntimeDir: nodebridge.RuntimeDir(),
+		Timeout:    time.Duration(timeout),
+		Navigation: navigationOptions(ctx, cfg),
+		Prepare:    func(page engine.Page) error { return emulateDevice(page, cfg) },
+		Reporter:   reporter,
+		Log:        log,
+	})
+	switch {
+	case result == nil:
+		return withKind(engine.KindScript, runErr)
+	case errors.Is(runErr, context.Canceled):
+		fmt.Fprintln(log, "🛑 Stopped; the results cover the tests run so far")
+	case runErr != nil:
+		return runErr
+	}
+
+	if err := ExecutePluginHooksWithContext("onExit", pluginPaths, pctx, log); err != nil {
+		return err
+	}
+	if !result.OK() || runErr != nil {
+		return cli.ExitStatus(cli.ExitError)
+	}
+	return nil
+}
+
+// testBundleDir holds the bundled test files of the last run. It is inside
+// the project so that their imports resolve from its node_modules.
+var testBundleDir = filepath.Join("dist", "phantom-test")
+
+// bundleTests builds the test files with Vite into ES modules for Node,
+// with the 'phantom-vite' import resolved to the runtime's phantom module.
+// Tests replace it, as they cannot run Vite.
+var bundleTests = func(files []string, log io.Writer) ([]string, error) {
+	module, err := filepath.Abs(filepath.Join(nodebridge.RuntimeDir(), phantomtest.ModuleScript))
+	if err != nil {
+		return nil, err
+	}
+	input := map[string]string{}
+	modules := make([]string, len(files))
+	for i, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%03d-%s", i+1, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+		input[name] = abs
+		modules[i] = filepath.Join(testBundleDir, name+".mjs")
+	}
+	// Strings in JSON are valid JavaScript, whatever the path separators
+	quote := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+
+	tempConfig := fmt.Sprintf(`
+import { defineConfig } from 'vite';
+
+export default defineConfig({
+  logLevel: 'warn',
+  resolve: {
+    alias: { %s: %s }
+  },
+  build: {
+    ssr: true,
+    target: 'node20',
+    outDir: %s,
+    emptyOutDir: true,
+    sourcemap: 'inline',
+    minify: false,
+    rollupOptions: {
+      input: %s,
+      output: {
+        format: 'es',
+        entryFileNames: '[name].mjs'
+      }
+    }
+  }
+});
+`, quote(phantomtest.Module), quote(module), quote(filepath.ToSlash(testBundleDir)), quote(input))
+
+	configFile := "vite.config.phantom-test.mjs"
+	if err := os.WriteFile(configFile, []byte(tempConfig), 0644); err != nil {
+		return nil, fmt.Errorf("failed to create temp config: %w", err)
+	}
+	defer os.Remove(configFile)
+
+	fmt.Fprintf(log, "📦 [Phantom Vite] Bundling %d test files...\n", len(files))
+	cmd := exec.Command("npx", "vite", "build", "--config", configFile)
+	cmd.Stdout = log
+	cmd.Stderr = log
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"phantomvite/pkg/cli"
+)
+
+func TestTestCommand(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+	runtime, _ := filepath.Abs(filepath.Join("..", "runtime"))
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("PHANTOM_RUNTIME_DIR", runtime)
+
+	// Vite is not installed here, so the import is rewritten instead
+	bundle := bundleTests
+	t.Cleanup(func() { bundleTests = bundle })
+	bundleTests = func(files []string, _ io.Writer) ([]string, error) {
+		var modules []string
+		for i, file := range files {
+			src, _ := os.ReadFile(file)
+			module := filepath.Join(dir, strconv.Itoa(i)+".mjs")
+			code := strings.ReplaceAll(string(src), "'phantom-vite'", strconv.Quote(filepath.Join(runtime, "phantom.mjs")))
+			if err := os.WriteFile(module, []byte(code), 0644); err != nil {
+				return nil, err
+			}
+			modules = append(modules, module)
+		}
+		return modules, nil
+	}
+
+	os.MkdirAll("tests", 0755)
+	os.WriteFile(filepath.Join("tests", "home.phantom.js"), []byte(`import { phantom } from 'phantom-vite';
+
+describe('home', () => {
+  test('has a title', async () => {
+    const page = await phantom.goto('https://example.com/');
+    expect(await page.title()).toBe('Title of https://example.com/');
+    await page.screenshot('shots/home.png');
+  });
+});
+`), 0644)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"test", "--engine", "fake", "--output", "json"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitOK {
+		t.Fatalf("expected exit code %d, got %d\n%s", cli.ExitOK, code, stderr.String())
+	}
+	var r struct {
+		Engine string
+		Passed int
+		Files  []struct {
+			Path  string
+			Tests []struct{ Screenshots []string }
+		}
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatalf("%v\n%s", err, stdout.String())
+	}
+	if r.Engine != "fake" || r.Passed != 1 || len(r.Files) != 1 || r.Files[0].Path != filepath.Join("tests", "home.phantom.js") {
+		t.Errorf("expected the test of tests/home.phantom.js to pass, got %+v", r)
+	}
+	if shots := r.Files[0].Tests[0].Screenshots; len(shots) != 1 || shots[0] != "shots/home.png" {
+		t.Errorf("expected the screenshot of the test, got %q", shots)
+	}
+	if !strings.Contains(stderr.String(), "✅ home › has a title") {
+		t.Errorf("expected progress on stderr, got %q", stderr.String())
+	}
+	if data, _ := os.ReadFile(filepath.Join("shots", "home.png")); string(data) != "png" {
+		t.Errorf("expected a png screenshot, got %q", data)
+	}
+
+	os.WriteFile(filepath.Join("tests", "slow.phantom.ts"), []byte(`test('waits', () => new Promise(() => {}));`), 0644)
+	stdout.Reset()
+	args = []string{"test", "tests/*.ts", "--engine", "fake", "--test-timeout", "100ms"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitError {
+		t.Fatalf("expected exit code %d for a failed test, got %d\n%s", cli.ExitError, code, stderr.String())
+	}
+	for _, want := range []string{"❌ waits (", "timed out after 100ms", "❌ 0 passed, 1 failed, 0 skipped in"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	args = []string{"test", "tests", "--engine", "fake", "--test-timeout", "100ms", "--reporter", "junit", "--reporter-out", "results.xml"}
+	if code := cli.Run(newRootCommand(), args, &stdout, &stderr); code != cli.ExitError {
+		t.Fatalf("expected exit code %d for a failed test, got %d\n%s", cli.ExitError, code, stderr.String())
+	}
+	xml, _ := os.ReadFile("results.xml")
+	for _, want := range []string{`<testsuites name="phantom-vite" tests="2" failures="1" errors="0" skipped="0"`, `<failure message="timed out after 100ms">`, "[[ATTACHMENT|shots/home.png]]"} {
+		if !strings.Contains(string(xml), want) {
+			t.Errorf("expected %q in results.xml:\n%s", want, xml)
+		}
+	}
+	if !strings.Contains(stdout.String(), "✅ home › has a title") {
+		t.Errorf("expected the console's lines on stdout, got %q", stdout.String())
+	}
+
+	if code := cli.Run(newRootCommand(), []string{"test", "missing", "--engine", "fake"}, &stdout, &stderr); code != cli.ExitError {
+		t.Errorf("expected exit code %d without test files, got %d", cli.ExitError, code)
+	}
+}
@@ -0,0 +1,352 @@
+// Package cli is the command framework behind phantom-vite: a tree of
+// commands that each declare their flags, arguments, examples and help
+// text, with parsing, help output, exit codes and shell completion built
+// from that declaration.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Exit codes returned by Run. Errors that implement ExitCoder choose their
+// own.
+const (
+	ExitOK    = 0 // the command succeeded
+	ExitError = 1 // the command ran and failed
+	ExitUsage = 2 // the command line was wrong
+)
+
+// ExitCoder is implemented by errors that map to a specific exit code
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitStatus is an error that only sets the exit code, for commands that
+// have already reported what went wrong. Run prints nothing for it.
+type ExitStatus int
+
+func (e ExitStatus) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+
+func (e ExitStatus) ExitCode() int { return int(e) }
+
+// A Command is a node in the command tree
+type Command struct {
+	Name     string
+	Summary  string // one line, shown in command lists
+	Help     string // longer description, shown by help; Summary when empty
+	Args     []Arg
+	Flags    []Flag // for this command; the root's flags apply to every command
+	Examples []string
+	Commands []*Command
+	Hidden   bool // left out of command lists and completion
+
+	// Run executes the command. A command without Run needs a subcommand.
+	Run func(ctx *Context) error
+
+	parent *Command
+}
+
+// A Flag is a --name option
+type Flag struct {
+	Name     string // without dashes
+	Value    string // placeholder in help, e.g. "file"; ignored for Bool flags
+	Usage    string
+	Env      string   // environment variable with the same effect, shown in help
+	Default  string   // value when the flag is not given
+	Bool     bool     // may be given without a value: --name means --name=true
+	Values   []string // allowed values; also offered by completion
+	Complete string   // CompleteFile to complete file names
+}
+
+// An Arg is a positional argument
+type Arg struct {
+	Name     string
+	Usage    string
+	Optional bool
+	Variadic bool     // takes the rest of the arguments; must be last
+	Values   []string // allowed values; also offered by completion
+	Complete string   // CompleteFile to complete file names
+}
+
+// CompleteFile makes completion offer file names for an argument or flag
+const CompleteFile = "file"
+
+// FlagValue is a flag given on the command line
+type FlagValue struct {
+	Flag  *Flag
+	Value string
+}
+
+// Context is a parsed command line
+type Context struct {
+	Command *Command
+	Args    []string
+	Set     []FlagValue // every flag given, in command-line order
+	Help    bool        // -h or --help was given
+
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// UsageError is a command line that does not fit the command. Run prints
+// it with a pointer to the command's help and exits with ExitUsage.
+type UsageError struct {
+	Command *Command
+	Message string
+}
+
+func (e *UsageError) Error() string { return e.Message }
+
+func (e *UsageError) ExitCode() int { return ExitUsage }
+
+// Usagef returns a UsageError for the context's command
+func (c *Context) Usagef(format string, args ...interface{}) error {
+	return &UsageError{Command: c.Command, Message: fmt.Sprintf(format, args...)}
+}
+
+// Path returns the command names from the root, e.g. "phantom-vite config show"
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+// Root returns the top of the command tree
+func (c *Command) Root() *Command {
+	for c.parent != nil {
+		c = c.parent
+	}
+	return c
+}
+
+// Find returns the subcommand with the given name
+func (c *Command) Find(name string) *Command {
+	for _, sub := range c.Commands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// link sets the parent of every command below c
+func (c *Command) link() {
+	for _, sub := range c.Commands {
+		sub.parent = c
+		sub.link()
+	}
+}
+
+// lookupFlag finds a flag of c or, failing that, of the root
+func (c *Command) lookupFlag(name string) *Flag {
+	for _, cmd := range []*Command{c, c.Root()} {
+		for i := range cmd.Flags {
+			if cmd.Flags[i].Name == name {
+				return &cmd.Flags[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Parse finds the command args select and parses its flags and arguments.
+// Flags may appear anywhere; those before a subcommand name must belong to
+// the root. Arguments after "--" are taken literally.
+func (c *Command) Parse(args []string) (*Context, error) {
+	c.link()
+	ctx := &Context{Command: c}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			ctx.Args = append(ctx.Args, args[i+1:]...)
+			i = len(args)
+
+		case arg == "-h" || arg == "--help":
+			ctx.Help = true
+
+		case strings.HasPrefix(arg, "--") && len(arg) > 2:
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			flag := ctx.Command.lookupFlag(name)
+			if flag == nil {
+				return ctx, ctx.Usagef("unknown flag --%s", name)
+			}
+			if !hasValue {
+				switch {
+				case flag.Bool:
+					value = "true"
+				case i+1 < len(args):
+					i++
+					value = args[i]
+				default:
+					return ctx, ctx.Usagef("flag --%s needs a value", name)
+				}
+			}
+			if flag.Bool {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return ctx, ctx.Usagef("flag --%s expects true or false, got %q", name, value)
+				}
+			}
+			if len(flag.Values) > 0 && !slices.Contains(flag.Values, value) {
+				return ctx, ctx.Usagef("flag --%s must be one of %s, got %q", name, strings.Join(flag.Values, ", "), value)
+			}
+			ctx.Set = append(ctx.Set, FlagValue{Flag: flag, Value: value})
+
+		default:
+			if sub := ctx.Command.Find(arg); sub != nil && len(ctx.Args) == 0 {
+				ctx.Command = sub
+				continue
+			}
+			ctx.Args = append(ctx.Args, arg)
+		}
+	}
+	if ctx.Help {
+		return ctx, nil
+	}
+	return ctx, ctx.checkArgs()
+}
+
+// checkArgs matches the positional arguments against the command's Args
+func (c *Context) checkArgs() error {
+	cmd := c.Command
+	if cmd.Run == nil {
+		if len(c.Args) > 0 {
+			return c.Usagef("unknown command %q for '%s'", c.Args[0], cmd.Path())
+		}
+		return c.Usagef("'%s' needs a command", cmd.Path())
+	}
+
+	required, variadic := 0, false
+	for _, a := range cmd.Args {
+		if !a.Optional {
+			required++
+		}
+		variadic = variadic || a.Variadic
+	}
+	switch {
+	case len(c.Args) < required:
+		return c.Usagef("missing <%s>", cmd.Args[len(c.Args)].Name)
+	case len(c.Args) > len(cmd.Args) && !variadic:
+		return c.Usagef("unexpected argument %q", c.Args[len(cmd.Args)])
+	}
+	for i, value := range c.Args {
+		a := cmd.Args[min(i, len(cmd.Args)-1)]
+		if len(a.Values) > 0 && !slices.Contains(a.Values, value) {
+			return c.Usagef("<%s> must be one of %s, got %q", a.Name, strings.Join(a.Values, ", "), value)
+		}
+	}
+	return nil
+}
+
+// Arg returns the named positional argument, or "" when it was not given.
+// A variadic argument is joined with spaces.
+func (c *Context) Arg(name string) string {
+	for i, a := range c.Command.Args {
+		if a.Name != name || i >= len(c.Args) {
+			continue
+		}
+		if a.Variadic {
+			return strings.Join(c.Args[i:], " ")
+		}
+		return c.Args[i]
+	}
+	return ""
+}
+
+// IsSet reports whether the flag was given on the command line
+func (c *Context) IsSet(name string) bool {
+	for _, f := range c.Set {
+		if f.Flag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the last value given for a flag, or its default
+func (c *Context) String(name string) string {
+	value := ""
+	if flag := c.Command.lookupFlag(name); flag != nil {
+		value = flag.Default
+	}
+	for _, f := range c.Set {
+		if f.Flag.Name == name {
+			value = f.Value
+		}
+	}
+	return value
+}
+
+// Bool returns the value of a boolean flag
+func (c *Context) Bool(name string) bool {
+	b, _ := strconv.ParseBool(c.String(name))
+	return b
+}
+
+// Int returns the value of an integer flag, or a UsageError when it is not
+// a number.
+func (c *Context) Int(name string) (int, error) {
+	value := c.String(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, c.Usagef("flag --%s expects a number, got %q", name, value)
+	}
+	return n, nil
+}
+
+// Run parses args against the tree rooted at root, runs the selected
+// command and returns the process exit code. Errors are printed to stderr.
+func Run(root *Command, args []string, stdout, stderr io.Writer) int {
+	ctx, err := root.Parse(args)
+	if ctx != nil {
+		ctx.Stdout, ctx.Stderr = stdout, stderr
+	}
+	if err == nil {
+		switch {
+		case ctx.Help:
+			ctx.Command.WriteHelp(stdout)
+			return ExitOK
+		default:
+			err = ctx.Command.Run(ctx)
+		}
+	}
+	if err == nil {
+		return ExitOK
+	}
+
+	var status ExitStatus
+	if errors.As(err, &status) {
+		return int(status)
+	}
+	fmt.Fprintf(stderr, "❌ %v\n", err)
+	var usage *UsageError
+	if errors.As(err, &usage) && usage.Command != nil {
+		fmt.Fprintf(stderr, "💡 Run '%s' for usage\n", helpCommandLine(usage.Command))
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return ExitError
+}
+
+// helpCommandLine is the command that shows help for cmd
+func helpCommandLine(cmd *Command) string {
+	root := cmd.Root()
+	if root.Find("help") == nil {
+		return cmd.Path() + " --help"
+	}
+	if cmd == root {
+		return root.Name + " help"
+	}
+	return root.Name + " help" + strings.TrimPrefix(cmd.Path(), root.Name)
+}
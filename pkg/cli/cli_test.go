@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// testTree is a small command tree:
+//
+//	tool [script] --verbose --engine <name>
+//	tool get <url> --out <file> --retries <n>
+//	tool say <words>...
+//	tool remote add <name> [url]
+func testTree() *Command {
+	run := func(ctx *Context) error { return nil }
+	return &Command{
+		Name: "tool",
+		Args: []Arg{{Name: "script", Optional: true, Complete: CompleteFile}},
+		Flags: []Flag{
+			{Name: "verbose", Bool: true, Usage: "say more"},
+			{Name: "engine", Value: "name", Usage: "engine to use", Values: []string{"cdp", "selenium"}},
+		},
+		Run: run,
+		Commands: []*Command{
+			{
+				Name: "get",
+				Args: []Arg{{Name: "url"}},
+				Flags: []Flag{
+					{Name: "out", Value: "file", Default: "page.html", Complete: CompleteFile},
+					{Name: "retries", Value: "n"},
+				},
+				Run: run,
+			},
+			{Name: "say", Args: []Arg{{Name: "words", Variadic: true}}, Run: run},
+			{
+				Name: "remote",
+				Commands: []*Command{
+					{Name: "add", Args: []Arg{{Name: "name"}, {Name: "url", Optional: true}}, Run: run},
+				},
+			},
+			HelpCommand(),
+			CompletionCommand(),
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		args    []string
+		command string
+		rest    []string
+		flags   map[string]string
+	}{
+		{[]string{}, "tool", nil, nil},
+		{[]string{"run.js"}, "tool", []string{"run.js"}, nil},
+		{[]string{"get", "http://x"}, "tool get", []string{"http://x"}, nil},
+		{[]string{"--verbose", "get", "--out", "a.html", "http://x"}, "tool get", []string{"http://x"},
+			map[string]string{"verbose": "true", "out": "a.html"}},
+		{[]string{"get", "http://x", "--out=b.html", "--engine=cdp", "--verbose=false"}, "tool get", []string{"http://x"},
+			map[string]string{"out": "b.html", "engine": "cdp", "verbose": "false"}},
+		{[]string{"say", "hello", "--verbose", "world"}, "tool say", []string{"hello", "world"}, map[string]string{"verbose": "true"}},
+		{[]string{"say", "--", "--not-a-flag", "get"}, "tool say", []string{"--not-a-flag", "get"}, nil},
+		{[]string{"remote", "add", "origin"}, "tool remote add", []string{"origin"}, nil},
+		// a subcommand name after an argument is an argument
+		{[]string{"say", "get"}, "tool say", []string{"get"}, nil},
+	} {
+		ctx, err := testTree().Parse(tc.args)
+		if err != nil {
+			t.Errorf("%q: %v", tc.args, err)
+			continue
+		}
+		if got := ctx.Command.Path(); got != tc.command {
+			t.Errorf("%q: expected command %q, got %q", tc.args, tc.command, got)
+		}
+		if strings.Join(ctx.Args, " ") != strings.Join(tc.rest, " ") {
+			t.Errorf("%q: expected args %q, got %q", tc.args, tc.rest, ctx.Args)
+		}
+		for name, want := range tc.flags {
+			if got := ctx.String(name); got != want {
+				t.Errorf("%q: expected --%s=%q, got %q", tc.args, name, want, got)
+			}
+		}
+		if len(ctx.Set) != len(tc.flags) {
+			t.Errorf("%q: expected %d flags, got %d", tc.args, len(tc.flags), len(ctx.Set))
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		args    []string
+		command string
+		message string
+	}{
+		{[]string{"get"}, "tool get", "missing <url>"},
+		{[]string{"get", "a", "b"}, "tool get", `unexpected argument "b"`},
+		{[]string{"say"}, "tool say", "missing <words>"},
+		{[]string{"get", "a", "--outt", "x"}, "tool get", "unknown flag --outt"},
+		{[]string{"get", "a", "--out"}, "tool get", "flag --out needs a value"},
+		{[]string{"--engine", "webkit"}, "tool", `flag --engine must be one of cdp, selenium, got "webkit"`},
+		{[]string{"--verbose=maybe"}, "tool", `flag --verbose expects true or false, got "maybe"`},
+		// flags are looked up on the command seen so far
+		{[]string{"--out", "x", "get", "a"}, "tool", "unknown flag --out"},
+		{[]string{"remote"}, "tool remote", "'tool remote' needs a command"},
+		{[]string{"remote", "rm"}, "tool remote", `unknown command "rm" for 'tool remote'`},
+		{[]string{"completion", "tcsh"}, "tool completion", `<shell> must be one of bash, zsh, fish, got "tcsh"`},
+	} {
+		_, err := testTree().Parse(tc.args)
+		var usage *UsageError
+		if !errors.As(err, &usage) {
+			t.Errorf("%q: expected a UsageError, got %v", tc.args, err)
+			continue
+		}
+		if usage.Message != tc.message || usage.Command.Path() != tc.command {
+			t.Errorf("%q: expected %q for %q, got %q for %q", tc.args, tc.message, tc.command, usage.Message, usage.Command.Path())
+		}
+	}
+}
+
+func TestContextValues(t *testing.T) {
+	ctx, err := testTree().Parse([]string{"get", "http://x", "--retries", "3", "--out", "a", "--out", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Arg("url") != "http://x" || ctx.String("out") != "b" || !ctx.IsSet("out") || ctx.IsSet("verbose") {
+		t.Errorf("unexpected values: url=%q out=%q", ctx.Arg("url"), ctx.String("out"))
+	}
+	if n, err := ctx.Int("retries"); n != 3 || err != nil {
+		t.Errorf("expected 3 retries, got %d, %v", n, err)
+	}
+
+	ctx, _ = testTree().Parse([]string{"get", "http://x", "--retries", "many"})
+	if ctx.String("out") != "page.html" {
+		t.Errorf("expected the default, got %q", ctx.String("out"))
+	}
+	if _, err := ctx.Int("retries"); err == nil || err.(*UsageError).Message != `flag --retries expects a number, got "many"` {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+
+	ctx, _ = testTree().Parse([]string{"say", "hello", "world"})
+	if ctx.Arg("words") != "hello world" {
+		t.Errorf("expected the variadic argument joined, got %q", ctx.Arg("words"))
+	}
+}
+
+type codedError struct{}
+
+func (codedError) Error() string { return "engine unavailable" }
+func (codedError) ExitCode() int { return 4 }
+
+func TestRunExitCodes(t *testing.T) {
+	root := testTree()
+	root.Find("get").Run = func(ctx *Context) error {
+		switch ctx.Arg("url") {
+		case "fail":
+			return errors.New("navigation failed")
+		case "coded":
+			return codedError{}
+		case "silent":
+			return ExitStatus(3)
+		}
+		return nil
+	}
+
+	for _, tc := range []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{"get", "ok"}, ExitOK, ""},
+		{[]string{"get", "fail"}, ExitError, "❌ navigation failed\n"},
+		{[]string{"get", "coded"}, 4, "❌ engine unavailable\n"},
+		{[]string{"get", "silent"}, 3, ""},
+		{[]string{"get"}, ExitUsage, "❌ missing <url>\n💡 Run 'tool help get' for usage\n"},
+		{[]string{"get", "--help"}, ExitOK, ""},
+		{[]string{"help", "nope"}, ExitUsage, "❌ unknown command \"nope\" for 'tool'\n💡 Run 'tool help' for usage\n"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(root, tc.args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%q: expected exit code %d, got %d", tc.args, tc.code, code)
+		}
+		if stderr.String() != tc.stderr {
+			t.Errorf("%q: expected stderr %q, got %q", tc.args, tc.stderr, stderr.String())
+		}
+	}
+}
+
+func TestHelp(t *testing.T) {
+	var a, b bytes.Buffer
+	Run(testTree(), []string{"help", "get"}, &a, &b)
+	Run(testTree(), []string{"get", "-h"}, &b, &b)
+	if a.String() != b.String() {
+		t.Errorf("'help get' and 'get -h' differ:\n%s\n---\n%s", a.String(), b.String())
+	}
+	for _, want := range []string{
+		"Usage:\n  tool get <url> [flags]\n",
+		"  --out <file>    (default page.html)\n",
+		"Global flags:\n  --verbose        say more\n",
+		"  --engine <name>  engine to use (cdp, selenium)\n",
+	} {
+		if !strings.Contains(a.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, a.String())
+		}
+	}
+
+	a.Reset()
+	Run(testTree(), []string{"help", "remote"}, &a, &a)
+	if !strings.Contains(a.String(), "tool remote <command> [flags]") || !strings.Contains(a.String(), "Commands:\n  add") {
+		t.Errorf("unexpected help:\n%s", a.String())
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range Shells {
+		var out bytes.Buffer
+		if code := Run(testTree(), []string{"completion", shell}, &out, &out); code != ExitOK {
+			t.Fatalf("%s: exit code %d: %s", shell, code, out.String())
+		}
+		script := out.String()
+		for _, want := range []string{"remote", "/remote/add", "retries", "cdp selenium"} {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s: expected %q in the script", shell, want)
+			}
+		}
+
+		if _, err := exec.LookPath(shell); err != nil || shell == "fish" {
+			continue
+		}
+		check := exec.Command(shell, "-n")
+		check.Stdin = strings.NewReader(script)
+		if output, err := check.CombinedOutput(); err != nil {
+			t.Errorf("%s: script does not parse: %v\n%s", shell, err, output)
+		}
+	}
+}
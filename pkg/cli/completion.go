@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Shells lists the shells completion scripts are generated for
+var Shells = []string{"bash", "zsh", "fish"}
+
+// completionNode is one command as the completion scripts see it: its path
+// such as "/config/show", and what can follow it.
+type completionNode struct {
+	cmd   *Command
+	path  string
+	flags []Flag // own flags and the root's
+	words []string
+	files bool
+}
+
+func completionNodes(root *Command) []completionNode {
+	root.link()
+	var nodes []completionNode
+	var walk func(c *Command, path string)
+	walk = func(c *Command, path string) {
+		n := completionNode{cmd: c, path: path, flags: c.Flags}
+		if c != root {
+			n.flags = append(append([]Flag{}, c.Flags...), root.Flags...)
+		}
+		for _, sub := range visible(c.Commands) {
+			n.words = append(n.words, sub.Name)
+		}
+		if c.Run != nil {
+			for _, a := range c.Args {
+				n.words = append(n.words, a.Values...)
+				n.files = n.files || a.Complete == CompleteFile
+			}
+		}
+		nodes = append(nodes, n)
+		for _, sub := range visible(c.Commands) {
+			walk(sub, path+"/"+sub.Name)
+		}
+	}
+	walk(root, "")
+	return nodes
+}
+
+// valueFlags returns every flag that takes a value, by name
+func valueFlags(nodes []completionNode) []Flag {
+	seen := make(map[string]Flag)
+	for _, n := range nodes {
+		for _, f := range n.flags {
+			if !f.Bool {
+				seen[f.Name] = f
+			}
+		}
+	}
+	flags := make([]Flag, 0, len(seen))
+	for _, f := range seen {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+func commandPaths(nodes []completionNode) string {
+	var paths []string
+	for _, n := range nodes[1:] {
+		paths = append(paths, n.path)
+	}
+	return strings.Join(paths, "|")
+}
+
+func flagNames(flags []Flag) []string {
+	names := make([]string, 0, len(flags)+1)
+	for _, f := range flags {
+		names = append(names, "--"+f.Name)
+	}
+	return append(names, "--help")
+}
+
+// WriteCompletion writes the completion script for shell
+func WriteCompletion(w io.Writer, root *Command, shell string) error {
+	switch shell {
+	case "bash":
+		writeBash(w, root)
+	case "zsh":
+		writeZsh(w, root)
+	case "fish":
+		writeFish(w, root)
+	default:
+		return fmt.Errorf("unsupported shell %q, use one of %s", shell, strings.Join(Shells, ", "))
+	}
+	return nil
+}
+
+func funcName(root *Command) string {
+	return "_" + strings.NewReplacer("-", "_", ".", "_").Replace(root.Name)
+}
+
+func writeBash(w io.Writer, root *Command) {
+	nodes := completionNodes(root)
+	fn := funcName(root)
+
+	fmt.Fprintf(w, "# bash completion for %s. Load it with:\n#   source <(%s completion bash)\n", root.Name, root.Name)
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintf(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "    local cmdpath=\"\" word i\n")
+	fmt.Fprintf(w, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(w, "        word=\"${COMP_WORDS[i]}\"\n")
+	fmt.Fprintf(w, "        case \"$cmdpath/$word\" in\n")
+	fmt.Fprintf(w, "            %s) cmdpath=\"$cmdpath/$word\" ;;\n", commandPaths(nodes))
+	fmt.Fprintf(w, "        esac\n    done\n\n")
+
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, f := range valueFlags(nodes) {
+		switch {
+		case len(f.Values) > 0:
+			fmt.Fprintf(w, "        --%s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")); return ;;\n", f.Name, strings.Join(f.Values, " "))
+		case f.Complete == CompleteFile:
+			fmt.Fprintf(w, "        --%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.Name)
+		default:
+			fmt.Fprintf(w, "        --%s) COMPREPLY=(); return ;;\n", f.Name)
+		}
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	fmt.Fprintf(w, "    local flags=\"\" words=\"\" files=\"\"\n")
+	fmt.Fprintf(w, "    case \"$cmdpath\" in\n")
+	for _, n := range nodes {
+		files := ""
+		if n.files {
+			files = "; files=1"
+		}
+		fmt.Fprintf(w, "        %q) flags=%q; words=%q%s ;;\n", n.path, strings.Join(flagNames(n.flags), " "), strings.Join(n.words, " "), files)
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    else\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "        [[ -n \"$files\" ]] && COMPREPLY+=($(compgen -f -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    fi\n}\n")
+	fmt.Fprintf(w, "complete -F %s %s\n", fn, root.Name)
+}
+
+func writeZsh(w io.Writer, root *Command) {
+	nodes := completionNodes(root)
+	fn := funcName(root)
+
+	fmt.Fprintf(w, "#compdef %s\n", root.Name)
+	fmt.Fprintf(w, "# zsh completion for %s. Load it with:\n#   source <(%s completion zsh)\n", root.Name, root.Name)
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintf(w, "    local cur=\"${words[CURRENT]}\" prev=\"${words[CURRENT-1]}\"\n")
+	fmt.Fprintf(w, "    local cmdpath=\"\" word i\n")
+	fmt.Fprintf(w, "    for ((i = 2; i < CURRENT; i++)); do\n")
+	fmt.Fprintf(w, "        word=\"${words[i]}\"\n")
+	fmt.Fprintf(w, "        case \"$cmdpath/$word\" in\n")
+	fmt.Fprintf(w, "            %s) cmdpath=\"$cmdpath/$word\" ;;\n", commandPaths(nodes))
+	fmt.Fprintf(w, "        esac\n    done\n\n")
+
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, f := range valueFlags(nodes) {
+		switch {
+		case len(f.Values) > 0:
+			fmt.Fprintf(w, "        --%s) compadd -- %s; return ;;\n", f.Name, strings.Join(f.Values, " "))
+		case f.Complete == CompleteFile:
+			fmt.Fprintf(w, "        --%s) _files; return ;;\n", f.Name)
+		default:
+			fmt.Fprintf(w, "        --%s) return ;;\n", f.Name)
+		}
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	fmt.Fprintf(w, "    local -a flags cmds\n    local files=\"\"\n")
+	fmt.Fprintf(w, "    case \"$cmdpath\" in\n")
+	for _, n := range nodes {
+		files := ""
+		if n.files {
+			files = "; files=1"
+		}
+		fmt.Fprintf(w, "        %q) flags=(%s); cmds=(%s)%s ;;\n", n.path, strings.Join(flagNames(n.flags), " "), strings.Join(n.words, " "), files)
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(w, "        compadd -- \"${flags[@]}\"\n")
+	fmt.Fprintf(w, "    else\n")
+	fmt.Fprintf(w, "        compadd -- \"${cmds[@]}\"\n")
+	fmt.Fprintf(w, "        [[ -n \"$files\" ]] && _files\n")
+	fmt.Fprintf(w, "    fi\n}\n")
+	fmt.Fprintf(w, "compdef %s %s\n", fn, root.Name)
+}
+
+func writeFish(w io.Writer, root *Command) {
+	nodes := completionNodes(root)
+	fn := "_" + funcName(root) + "_path"
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", `\'`) + "'" }
+
+	fmt.Fprintf(w, "# fish completion for %s. Load it with:\n#   %s completion fish | source\n", root.Name, root.Name)
+	fmt.Fprintf(w, "function %s\n", fn)
+	fmt.Fprintf(w, "    set -l cmdpath ''\n")
+	fmt.Fprintf(w, "    for word in (commandline -opc)[2..-1]\n")
+	fmt.Fprintf(w, "        switch \"$cmdpath/$word\"\n")
+	fmt.Fprintf(w, "            case %s\n", strings.ReplaceAll(commandPaths(nodes), "|", " "))
+	fmt.Fprintf(w, "                set cmdpath \"$cmdpath/$word\"\n")
+	fmt.Fprintf(w, "        end\n    end\n    echo \"root$cmdpath\"\nend\n\n")
+	fmt.Fprintf(w, "complete -c %s -f\n", root.Name)
+
+	for _, n := range nodes {
+		// the path is prefixed so it is never empty for test
+		cond := fmt.Sprintf("-n 'test (%s) = %q'", fn, "root"+n.path)
+		for _, sub := range visible(n.cmd.Commands) {
+			fmt.Fprintf(w, "complete -c %s %s -a %s -d %s\n", root.Name, cond, sub.Name, quote(sub.Summary))
+		}
+		if n.cmd.Run != nil {
+			for _, a := range n.cmd.Args {
+				if len(a.Values) > 0 {
+					fmt.Fprintf(w, "complete -c %s %s -a %s\n", root.Name, cond, quote(strings.Join(a.Values, " ")))
+				}
+				if a.Complete == CompleteFile {
+					fmt.Fprintf(w, "complete -c %s %s -F\n", root.Name, cond)
+				}
+			}
+		}
+		flags := n.cmd.Flags
+		if n.cmd == root {
+			cond = "" // the root's flags apply everywhere
+		}
+		for _, f := range flags {
+			line := fmt.Sprintf("complete -c %s", root.Name)
+			if cond != "" {
+				line += " " + cond
+			}
+			line += " -l " + f.Name
+			switch {
+			case f.Bool:
+			case len(f.Values) > 0:
+				line += " -x -a " + quote(strings.Join(f.Values, " "))
+			case f.Complete == CompleteFile:
+				line += " -r -F"
+			default:
+				line += " -x"
+			}
+			fmt.Fprintf(w, "%s -d %s\n", line, quote(f.Usage))
+		}
+	}
+}
+
+// CompletionCommand returns a 'completion <shell>' command that prints the
+// completion script for the tree it is added to.
+func CompletionCommand() *Command {
+	return &Command{
+		Name:    "completion",
+		Summary: "Print a shell completion script",
+		Help: "Print a completion script for bash, zsh or fish.\n\n" +
+			"bash: source <(phantom-vite completion bash)   (add it to ~/.bashrc)\n" +
+			"zsh:  source <(phantom-vite completion zsh)    (add it to ~/.zshrc after compinit)\n" +
+			"fish: phantom-vite completion fish > ~/.config/fish/completions/phantom-vite.fish",
+		Args: []Arg{{Name: "shell", Values: Shells}},
+		Run: func(ctx *Context) error {
+			return WriteCompletion(ctx.Stdout, ctx.Command.Root(), ctx.Arg("shell"))
+		},
+	}
+}
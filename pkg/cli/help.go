@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage returns the synopsis of a command, e.g.
+// "phantom-vite config convert [file] [flags]"
+func (c *Command) Usage() string {
+	parts := []string{c.Path()}
+	if c.Run == nil && len(c.Commands) > 0 {
+		parts = append(parts, "<command>")
+	}
+	if c.Run != nil {
+		for _, a := range c.Args {
+			name := a.Name
+			if a.Variadic {
+				name += "..."
+			}
+			if a.Optional {
+				parts = append(parts, "["+name+"]")
+			} else {
+				parts = append(parts, "<"+name+">")
+			}
+		}
+	}
+	if len(c.Flags) > 0 || len(c.Root().Flags) > 0 {
+		parts = append(parts, "[flags]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// WriteHelp writes the full help of a command
+func (c *Command) WriteHelp(w io.Writer) {
+	c.Root().link()
+	root := c.Root()
+
+	help := c.Help
+	if help == "" {
+		help = c.Summary
+	}
+	if help != "" {
+		fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(help))
+	}
+	fmt.Fprintf(w, "Usage:\n  %s\n", c.Usage())
+
+	if args := describedArgs(c); len(args) > 0 {
+		fmt.Fprintln(w, "\nArguments:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, a := range args {
+			fmt.Fprintf(tw, "  %s\t%s\n", a.Name, a.Usage)
+		}
+		tw.Flush()
+	}
+
+	if commands := visible(c.Commands); len(commands) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, sub := range commands {
+			fmt.Fprintf(tw, "  %s\t%s\n", sub.Name, sub.Summary)
+		}
+		tw.Flush()
+	}
+
+	if c != root && len(c.Flags) > 0 {
+		fmt.Fprintln(w, "\nFlags:")
+		writeFlags(w, c.Flags)
+	}
+	if len(root.Flags) > 0 {
+		if c == root {
+			fmt.Fprintln(w, "\nFlags (any command):")
+		} else {
+			fmt.Fprintln(w, "\nGlobal flags:")
+		}
+		writeFlags(w, root.Flags)
+	}
+
+	if len(c.Examples) > 0 {
+		fmt.Fprintln(w, "\nExamples:")
+		for _, ex := range c.Examples {
+			fmt.Fprintf(w, "  %s\n", ex)
+		}
+	}
+	if len(c.Commands) > 0 && root.Find("help") != nil {
+		fmt.Fprintf(w, "\nRun '%s <command>' for more about a command.\n", helpCommandLine(c))
+	}
+}
+
+func writeFlags(w io.Writer, flags []Flag) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range flags {
+		name := "--" + f.Name
+		if !f.Bool {
+			placeholder := f.Value
+			if placeholder == "" {
+				placeholder = "value"
+			}
+			name += " <" + placeholder + ">"
+		}
+		usage := f.Usage
+		if len(f.Values) > 0 && !strings.Contains(usage, f.Values[0]) {
+			usage += " (" + strings.Join(f.Values, ", ") + ")"
+		}
+		if f.Default != "" && !f.Bool {
+			usage += fmt.Sprintf(" (default %s)", f.Default)
+		}
+		if f.Env != "" {
+			usage += " (env " + f.Env + ")"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", name, usage)
+	}
+	fmt.Fprintf(tw, "  %s\t%s\n", "-h, --help", "show help")
+	tw.Flush()
+}
+
+// describedArgs returns the arguments that have a usage text
+func describedArgs(c *Command) []Arg {
+	var args []Arg
+	for _, a := range c.Args {
+		if a.Usage != "" {
+			args = append(args, a)
+		}
+	}
+	return args
+}
+
+func visible(commands []*Command) []*Command {
+	var shown []*Command
+	for _, c := range commands {
+		if !c.Hidden {
+			shown = append(shown, c)
+		}
+	}
+	return shown
+}
+
+// HelpCommand returns a 'help [command...]' command for the tree it is
+// added to.
+func HelpCommand() *Command {
+	return &Command{
+		Name:    "help",
+		Summary: "Show help for a command",
+		Args:    []Arg{{Name: "command", Optional: true, Variadic: true}},
+		Examples: []string{
+			"phantom-vite help open",
+			"phantom-vite help config convert",
+		},
+		Run: func(ctx *Context) error {
+			cmd := ctx.Command.Root()
+			for _, name := range ctx.Args {
+				sub := cmd.Find(name)
+				if sub == nil {
+					return &UsageError{Command: cmd, Message: fmt.Sprintf("unknown command %q for '%s'", name, cmd.Path())}
+				}
+				cmd = sub
+			}
+			cmd.WriteHelp(ctx.Stdout)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,152 @@
+// Package config loads and validates phantomvite.config.json (or .yaml,
+// .yml and .toml). It is the one place the CLI configuration is defined;
+// engines receive the browser part of it through EngineConfig.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// DefaultFile is the project config file 'config init' writes; see
+// ProjectFiles for the others that are read
+const DefaultFile = "phantomvite.config.json"
+
+// Config is the contents of a phantomvite config file
+type Config struct {
+	Engine   string                `json:"engine"`
+	Headless bool                  `json:"headless"`
+	Viewport engine.ViewportConfig `json:"viewport"`
+	Timeout  Duration              `json:"timeout"`
+
+	UserAgent      string            `json:"user_agent,omitempty"`
+	ExtraHeaders   map[string]string `json:"extra_headers,omitempty"`
+	Proxy          string            `json:"proxy,omitempty"`
+	ExecutablePath string            `json:"executable_path,omitempty"`
+	Args           []string          `json:"args,omitempty"`
+
+	// Device names a predefined device whose viewport and user agent are
+	// used unless the same layer sets them too.
+	Device string `json:"device,omitempty"`
+
+	Plugins []engine.PluginConfig `json:"plugins,omitempty"`
+	Entries []string              `json:"entries,omitempty"`
+
+	// Profile selects one of Profiles; --profile and PHANTOM_PROFILE win
+	Profile  string                     `json:"profile,omitempty"`
+	Profiles map[string]json.RawMessage `json:"profiles,omitempty"`
+}
+
+// Default returns the configuration used when no file is present
+func Default() Config {
+	ec := engine.DefaultConfig()
+	return Config{
+		Engine:   ec.Engine,
+		Headless: ec.Headless,
+		Viewport: ec.Viewport,
+		Timeout:  Duration(ec.Timeout),
+	}
+}
+
+// Load reads and validates the config file at path, in the format its
+// extension names. A missing file is not an error and yields Default().
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := parseFormat(FormatOf(path), data)
+	if err != nil {
+		return Config{}, &SourceError{Source: path, Err: err}
+	}
+	return cfg, nil
+}
+
+// Parse decodes a JSON config file over the defaults and validates the
+// result. Keys that do not exist in Config are rejected. The returned
+// Errors list every problem, each with its line and column in data.
+func Parse(data []byte) (Config, error) {
+	return parseFormat(FormatJSON, data)
+}
+
+// parseFormat is Parse for any format. YAML and TOML files are converted
+// to JSON first so every format decodes and validates the same way.
+func parseFormat(format string, data []byte) (Config, error) {
+	doc, pos, err := toJSON(format, data)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg := Default()
+	_, err = decode(doc, &cfg)
+	errs, _ := err.(Errors)
+	if len(errs) == 1 && errs[0].Key == "" {
+		return Config{}, err // not JSON, nothing to validate
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err.(Errors)...)
+	}
+	if len(errs) > 0 {
+		locate(errs, pos)
+		return Config{}, errs
+	}
+	return cfg, nil
+}
+
+// EngineConfig returns the engine launch configuration
+func (c Config) EngineConfig() engine.Config {
+	return engine.Config{
+		Engine:         c.Engine,
+		Headless:       c.Headless,
+		Viewport:       c.Viewport,
+		Timeout:        time.Duration(c.Timeout),
+		UserAgent:      c.UserAgent,
+		ExtraHeaders:   c.ExtraHeaders,
+		Proxy:          c.Proxy,
+		ExecutablePath: c.ExecutablePath,
+		Args:           c.Args,
+		Plugins:        c.Plugins,
+	}
+}
+
+// EnabledPlugins returns the plugins that are not switched off
+func (c Config) EnabledPlugins() []engine.PluginConfig {
+	var enabled []engine.PluginConfig
+	for _, p := range c.Plugins {
+		if p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled
+}
+
+// Duration is a time.Duration written in config files either as a number of
+// milliseconds or as a Go duration string such as "10s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if _, err := strconv.ParseInt(text, 10, 64); err != nil {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf(`expected milliseconds or a duration string like "10s"`)
+		}
+	}
+	parsed, err := ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine/enginetest"
+)
+
+func TestParseFullConfig(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+  "engine": "conformance",
+  "headless": false,
+  "timeout": 7000,
+  "viewport": {"width": 1366, "height": 768},
+  "user_agent": "PhantomConformance/1.0",
+  "extra_headers": {"X-Phantom-Conformance": "yes"},
+  "proxy": "http://127.0.0.1:3128",
+  "executable_path": "/opt/phantom/browser",
+  "args": ["--phantom-conformance-flag"],
+  "plugins": [{"path": "plugins/conformance.js", "name": "conformance"}]
+}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if got, want := cfg.EngineConfig(), enginetest.FullConfig(); !reflect.DeepEqual(got, want) {
+		t.Errorf("engine config mismatch:\n got  %+v\n want %+v", got, want)
+	}
+}
+
+func TestParseKeepsDefaults(t *testing.T) {
+	cfg, err := Parse([]byte(`{"engine": "playwright"}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	want := Default()
+	want.Engine = "playwright"
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("expected defaults for omitted keys, got %+v", cfg)
+	}
+}
+
+func TestTimeoutFormats(t *testing.T) {
+	for input, want := range map[string]time.Duration{
+		`1500`:    1500 * time.Millisecond,
+		`"10s"`:   10 * time.Second,
+		`"1m30s"`: 90 * time.Second,
+	} {
+		cfg, err := Parse([]byte(`{"timeout": ` + input + `}`))
+		if err != nil {
+			t.Errorf("timeout %s: %v", input, err)
+			continue
+		}
+		if time.Duration(cfg.Timeout) != want {
+			t.Errorf("timeout %s: expected %v, got %v", input, want, time.Duration(cfg.Timeout))
+		}
+	}
+}
+
+func TestUnknownAndMistypedKeys(t *testing.T) {
+	_, err := Parse([]byte(`{
+  "enigne": "cdp",
+  "headless": "yes",
+  "viewport": {"widht": 800},
+  "timeout": "soon",
+  "plugins": [{"path": "a.js", "enabeld": true}]
+}`))
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+
+	want := map[string]string{
+		"enigne":             "unknown field",
+		"headless":           "expected a boolean, got a string",
+		"viewport.widht":     "unknown field",
+		"timeout":            `invalid duration "soon"`,
+		"plugins[0].enabeld": "unknown field",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d:\n%v", len(want), len(errs), err)
+	}
+	for _, e := range errs {
+		if want[e.Key] != e.Message {
+			t.Errorf("%s: expected %q, got %q", e.Key, want[e.Key], e.Message)
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	_, err := Parse([]byte(`{
+  "engine": "",
+  "viewport": {"width": 0, "height": 720},
+  "timeout": 0,
+  "proxy": "ftp://proxy:21",
+  "plugins": [{"path": ""}]
+}`))
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+
+	var keys []string
+	for _, e := range errs {
+		keys = append(keys, e.Key)
+	}
+	if got := strings.Join(keys, ","); got != "engine,viewport.width,timeout,proxy,plugins[0].path" {
+		t.Errorf("unexpected error keys %s:\n%v", got, err)
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	_, err := Parse([]byte(`// local overrides
+{
+  "headless": "yes",
+  /* the viewport */ "viewport": {"width": 0},
+  "plugins": [
+    {"path": "a.js"},
+    {"path": "", "enabeld": true}
+  ],
+  "proxy": "ftp://proxy:21"
+}`))
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+
+	want := []string{
+		`3:3: headless: expected a boolean, got a string`,
+		`4:35: viewport.width: must be greater than 0, got 0`,
+		`7:6: plugins[1].path: must not be empty`,
+		`7:18: plugins[1].enabeld: unknown field`,
+		`9:3: proxy: unsupported scheme "ftp", use one of http, https, socks4, socks5`,
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestPluginsEnabledByDefault(t *testing.T) {
+	cfg, err := Parse([]byte(`{"plugins": [{"path": "a.js"}, {"path": "b.js", "enabled": false}]}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	enabled := cfg.EnabledPlugins()
+	if len(enabled) != 1 || enabled[0].Path != "a.js" {
+		t.Errorf("expected only a.js to be enabled, got %+v", enabled)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := Load(filepath.Join(dir, DefaultFile))
+	if err != nil || !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("expected defaults for a missing file, got %+v, %v", cfg, err)
+	}
+
+	path := filepath.Join(dir, DefaultFile)
+	os.WriteFile(path, []byte(`{"viewport": {"width": -1}}`), 0644)
+	_, err = Load(path)
+	if err == nil || !strings.Contains(err.Error(), DefaultFile+":1:15: viewport.width: must be greater than 0") {
+		t.Errorf("expected error naming the file and key, got %v", err)
+	}
+
+	os.WriteFile(path, []byte(`{"engine": "cdp",}`), 0644)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), DefaultFile+":1:18: invalid JSON") {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+}
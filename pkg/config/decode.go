@@ -0,0 +1,293 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+// FieldError reports a problem with one key of a config file
+type FieldError struct {
+	Key     string `json:"key,omitempty"` // dotted path such as "viewport.width" or "plugins[0].path"
+	Message string `json:"message"`
+
+	// Line and Column locate the key in the file, both 1-based; zero when
+	// the key is not in the file, such as a default that fails validation.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+func (e *FieldError) Error() string {
+	msg := e.Message
+	if e.Key != "" {
+		msg = e.Key + ": " + msg
+	}
+	if e.Line > 0 {
+		msg = fmt.Sprintf("%d:%d: %s", e.Line, e.Column, msg)
+	}
+	return msg
+}
+
+// Errors is every problem found in a config file, in file order when the
+// problems have positions
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// elementDefaults seeds new slice elements before they are decoded, so a
+// plugin entry without "enabled" is switched on.
+var elementDefaults = map[reflect.Type]func() interface{}{
+	reflect.TypeOf(engine.PluginConfig{}): func() interface{} { return engine.PluginConfig{Enabled: true} },
+}
+
+// decode unmarshals data into v like encoding/json, but collects an error
+// for every unknown key and every mistyped value instead of stopping at the
+// first one. Keys are matched exactly against the json tags and comments
+// are allowed. It returns the leaf keys the data set, see leafKeys, even
+// when it also returns errors. Only syntax errors carry a position; see
+// locate for the rest.
+func decode(data []byte, v interface{}) ([]string, error) {
+	data = stripComments(data)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(data) {
+		var target interface{}
+		err := json.Unmarshal(data, &target)
+		fe := &FieldError{Message: "invalid JSON: " + strings.TrimPrefix(err.Error(), "json: ")}
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			// Offset counts the offending byte
+			fe.Line, fe.Column = lineColumn(data, max(int(syntax.Offset)-1, 0))
+		}
+		return nil, Errors{fe}
+	}
+
+	d := &decoder{}
+	d.value(data, reflect.ValueOf(v).Elem(), "")
+	if len(d.errs) > 0 {
+		return d.set, d.errs
+	}
+	return d.set, nil
+}
+
+type decoder struct {
+	errs Errors
+	set  []string
+}
+
+func (d *decoder) fail(key, message string) {
+	d.errs = append(d.errs, &FieldError{Key: key, Message: message})
+}
+
+func (d *decoder) value(raw json.RawMessage, v reflect.Value, key string) {
+	if v.Kind() != reflect.Struct || isLeaf(v.Type()) {
+		d.set = append(d.set, key)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+			d.fail(key, strings.TrimPrefix(err.Error(), "json: "))
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil || object == nil && !isNull(raw) {
+			d.fail(key, "expected an object, got "+describe(raw))
+			return
+		}
+		fields := fieldsByTag(v.Type())
+		for _, name := range sortedKeys(object) {
+			if key == "" && name == "$schema" {
+				continue // for editors, see Schema
+			}
+			index, ok := fields[name]
+			if !ok {
+				d.fail(join(key, name), "unknown field")
+				continue
+			}
+			d.value(object[name], v.Field(index), join(key, name))
+		}
+
+	case reflect.Slice:
+		if isNull(raw) {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			d.fail(key, "expected an array, got "+describe(raw))
+			return
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		elements := &decoder{}
+		for i, item := range items {
+			if seed, ok := elementDefaults[v.Type().Elem()]; ok {
+				slice.Index(i).Set(reflect.ValueOf(seed()))
+			}
+			elements.value(item, slice.Index(i), fmt.Sprintf("%s[%d]", key, i))
+		}
+		d.errs = append(d.errs, elements.errs...)
+		v.Set(slice)
+
+	case reflect.Map:
+		if isNull(raw) {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			d.fail(key, "expected an object, got "+describe(raw))
+			return
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(object))
+		entries := &decoder{}
+		for _, name := range sortedKeys(object) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			entries.value(object[name], elem, join(key, name))
+			m.SetMapIndex(reflect.ValueOf(name), elem)
+		}
+		d.errs = append(d.errs, entries.errs...)
+		v.Set(m)
+
+	default:
+		if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+			d.fail(key, "expected "+kindName(v.Type())+", got "+describe(raw))
+		}
+	}
+}
+
+// isLeaf reports whether a struct type is decoded as a single value
+func isLeaf(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(unmarshalerType)
+}
+
+// leafKeys lists the keys of a struct type down to its non-struct values.
+// Slices and maps are leaves: a layer replaces them as a whole.
+func leafKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		ft := t.Field(i).Type
+		if ft.Kind() == reflect.Struct && !isLeaf(ft) {
+			keys = append(keys, leafKeys(ft, join(prefix, name))...)
+		} else {
+			keys = append(keys, join(prefix, name))
+		}
+	}
+	return keys
+}
+
+// lookup returns the value at a dotted struct key
+func lookup(v reflect.Value, key string) reflect.Value {
+	for _, name := range strings.Split(key, ".") {
+		v = v.Field(fieldsByTag(v.Type())[name])
+	}
+	return v
+}
+
+// fieldsByTag maps json names to struct field indexes
+func fieldsByTag(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name != "" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// jsonName returns the key a struct field is written under, or "" when the
+// field is not serialized.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
+
+func isNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+// describe names the JSON type of raw for error messages
+func describe(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return "nothing"
+	}
+	switch trimmed[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case '"':
+		return "a string"
+	case 't', 'f':
+		return "a boolean"
+	case 'n':
+		return "null"
+	}
+	return "a number"
+}
+
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Interface:
+		return "a value"
+	}
+	return t.Kind().String()
+}
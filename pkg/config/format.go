@@ -0,0 +1,299 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config file formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// ProjectFiles are the project config file names, looked for in this order
+// in each directory. The first one found wins.
+var ProjectFiles = []string{
+	DefaultFile,
+	"phantomvite.config.yaml",
+	"phantomvite.config.yml",
+	"phantomvite.config.toml",
+}
+
+// UserFiles are the config file names looked for in the user config
+// directory, in order.
+var UserFiles = []string{UserFile, "config.yaml", "config.yml", "config.toml"}
+
+// FormatOf returns the format of a config file from its extension; anything
+// unknown is read as JSON.
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	}
+	return FormatJSON
+}
+
+// toJSON converts a config file to JSON for decode, together with the
+// position of each key in the original file.
+func toJSON(format string, data []byte) ([]byte, map[string]position, error) {
+	switch format {
+	case FormatYAML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, nil, Errors{yamlError(err)}
+		}
+		if len(doc.Content) == 0 {
+			return nil, nil, nil // empty file
+		}
+		var v interface{}
+		if err := doc.Decode(&v); err != nil {
+			return nil, nil, Errors{yamlError(err)}
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, Errors{{Message: "invalid YAML: keys must be strings", Line: 1, Column: 1}}
+		}
+		pos := make(map[string]position)
+		yamlPositions(doc.Content[0], "", pos)
+		return out, pos, nil
+
+	case FormatTOML:
+		var v map[string]interface{}
+		if _, err := toml.Decode(string(data), &v); err != nil {
+			fe := &FieldError{Message: "invalid TOML: " + err.Error()}
+			var parseErr toml.ParseError
+			if errors.As(err, &parseErr) {
+				fe.Message = "invalid TOML: " + parseErr.Message
+				fe.Line, fe.Column = parseErr.Position.Line, parseErr.Position.Col
+			}
+			return nil, nil, Errors{fe}
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		return out, tomlPositions(data), nil
+	}
+
+	data = stripComments(data)
+	return data, positions(data), nil
+}
+
+var yamlLine = regexp.MustCompile(`^yaml: (?:unmarshal errors:\n\s*)?line (\d+): `)
+
+// yamlError turns a yaml.v3 error into a FieldError with its line
+func yamlError(err error) *FieldError {
+	fe := &FieldError{Message: "invalid YAML: " + strings.TrimPrefix(err.Error(), "yaml: ")}
+	if m := yamlLine.FindStringSubmatch(err.Error()); m != nil {
+		fe.Line, _ = strconv.Atoi(m[1])
+		fe.Column = 1
+		fe.Message = "invalid YAML: " + err.Error()[len(m[0]):]
+	}
+	return fe
+}
+
+// yamlPositions records the position of every key below a YAML node
+func yamlPositions(n *yaml.Node, path string, pos map[string]position) {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := join(path, n.Content[i].Value)
+			pos[key] = position{n.Content[i].Line, n.Content[i].Column}
+			yamlPositions(n.Content[i+1], key, pos)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			key := fmt.Sprintf("%s[%d]", path, i)
+			pos[key] = position{item.Line, item.Column}
+			yamlPositions(item, key, pos)
+		}
+	}
+}
+
+// tomlPositions finds keys by scanning lines: [table] and [[array]]
+// headers set the path and "key = value" lines add to it. Keys inside
+// inline tables and multi-line values are located by their parent.
+func tomlPositions(data []byte) map[string]position {
+	pos := make(map[string]position)
+	arrays := make(map[string]int)
+	table := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+		switch {
+		case trimmed == "" || trimmed[0] == '#':
+		case strings.HasPrefix(trimmed, "[["):
+			name := tomlKey(strings.TrimSuffix(strings.SplitN(trimmed[2:], "]]", 2)[0], "]]"))
+			table = fmt.Sprintf("%s[%d]", name, arrays[name])
+			arrays[name]++
+			pos[table] = position{i + 1, column}
+			if _, ok := pos[name]; !ok {
+				pos[name] = position{i + 1, column}
+			}
+		case trimmed[0] == '[':
+			table = tomlKey(strings.SplitN(trimmed[1:], "]", 2)[0])
+			pos[table] = position{i + 1, column}
+		default:
+			if key, _, ok := strings.Cut(trimmed, "="); ok {
+				pos[join(table, tomlKey(key))] = position{i + 1, column}
+			}
+		}
+	}
+	return pos
+}
+
+// tomlKey normalizes a possibly dotted and quoted TOML key to a key path
+func tomlKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Convert re-encodes a config file in another format. The file must be
+// valid; key order is kept except when converting from TOML, and YAML
+// comments survive a YAML to YAML conversion only.
+func Convert(from string, data []byte, to string) ([]byte, error) {
+	if _, err := parseFormat(from, data); err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	switch from {
+	case FormatTOML:
+		var v map[string]interface{}
+		if _, err := toml.Decode(string(data), &v); err != nil {
+			return nil, err
+		}
+		if err := doc.Encode(v); err != nil {
+			return nil, err
+		}
+	default:
+		// JSON without comments is YAML, and parsing it as YAML keeps
+		// the key order.
+		if from == FormatJSON {
+			data = stripComments(data)
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	}
+	root := &doc
+	if doc.Kind == yaml.DocumentNode {
+		if len(doc.Content) == 0 {
+			root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		} else {
+			root = doc.Content[0]
+		}
+	}
+
+	switch to {
+	case FormatYAML:
+		if from != FormatYAML {
+			blockStyle(root)
+		}
+		var b bytes.Buffer
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(root); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), enc.Close()
+
+	case FormatJSON:
+		var b bytes.Buffer
+		if err := writeJSON(&b, root); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, b.Bytes(), "", "  "); err != nil {
+			return nil, err
+		}
+		out.WriteByte('\n')
+		return out.Bytes(), nil
+
+	case FormatTOML:
+		var v map[string]interface{}
+		if err := root.Decode(&v); err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		enc := toml.NewEncoder(&b)
+		enc.Indent = ""
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown format %q, use json, yaml or toml", to)
+}
+
+// blockStyle drops the flow style and quoting a node parsed from JSON
+// carries, so it is written as idiomatic YAML.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// writeJSON writes a YAML node as compact JSON in document order
+func writeJSON(b *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return writeJSON(b, n.Alias)
+	case yaml.MappingNode:
+		b.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(n.Content[i].Value)
+			b.Write(key)
+			b.WriteByte(':')
+			if err := writeJSON(b, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeJSON(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	default:
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+	}
+	return nil
+}
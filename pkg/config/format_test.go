@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const formatJSON = `{
+  "engine": "playwright",
+  "headless": false,
+  "viewport": {"width": 1280, "height": 720},
+  "timeout": "10s",
+  "extra_headers": {"Accept-Language": "en-US"},
+  "args": ["--disable-gpu"],
+  "plugins": [{"path": "a.js", "options": {"level": 2}}]
+}`
+
+const formatYAML = `# team settings
+engine: playwright
+headless: false
+viewport:
+  width: 1280
+  height: 720
+timeout: 10s
+extra_headers:
+  Accept-Language: en-US
+args:
+  - --disable-gpu
+plugins:
+  - path: a.js
+    options:
+      level: 2
+`
+
+const formatTOML = `# team settings
+engine = "playwright"
+headless = false
+timeout = "10s"
+args = ["--disable-gpu"]
+
+[viewport]
+width = 1280
+height = 720
+
+[extra_headers]
+Accept-Language = "en-US"
+
+[[plugins]]
+path = "a.js"
+options = { level = 2 }
+`
+
+func TestFormatsDecodeAlike(t *testing.T) {
+	want, err := Parse([]byte(formatJSON))
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	for format, data := range map[string]string{FormatYAML: formatYAML, FormatTOML: formatTOML} {
+		got, err := parseFormat(format, []byte(data))
+		if err != nil {
+			t.Errorf("%s: %v", format, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", format, want, got)
+		}
+	}
+}
+
+func TestFormatErrorPositions(t *testing.T) {
+	for _, tc := range []struct {
+		format, data string
+		want         []string
+	}{
+		{FormatYAML, "engine: cdp\nviewport:\n  widht: 10\n  height: 0\nplugins:\n  - path: ''\n", []string{
+			"3:3: viewport.widht: unknown field",
+			"4:3: viewport.height: must be greater than 0, got 0",
+			"6:5: plugins[0].path: must not be empty",
+		}},
+		{FormatYAML, "engine: cdp\n\tviewport: 1\n", []string{
+			"2:1: invalid YAML: found a tab character that violates indentation",
+		}},
+		{FormatTOML, "timeout = \"soon\"\n\n[viewport]\n  widht = 10\n\n[[plugins]]\npath = \"\"\n", []string{
+			`1:1: timeout: invalid duration "soon"`,
+			"4:3: viewport.widht: unknown field",
+			"7:1: plugins[0].path: must not be empty",
+		}},
+		{FormatTOML, "engine = \n", []string{
+			"1:10: invalid TOML: expected value but found '\\n' instead",
+		}},
+	} {
+		_, err := parseFormat(tc.format, []byte(tc.data))
+		var errs Errors
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected Errors, got %v", tc.format, err)
+			continue
+		}
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tc.format, strings.Join(tc.want, "\n"), strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	out, err := Convert(FormatJSON, []byte("// ours\n"+formatJSON), FormatYAML)
+	if err != nil {
+		t.Fatalf("convert to yaml: %v", err)
+	}
+	want := `engine: playwright
+headless: false
+viewport:
+  width: 1280
+  height: 720
+timeout: 10s
+extra_headers:
+  Accept-Language: en-US
+args:
+  - --disable-gpu
+plugins:
+  - path: a.js
+    options:
+      level: 2
+`
+	if string(out) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out)
+	}
+
+	original, _ := Parse([]byte(formatJSON))
+	for _, to := range []string{FormatJSON, FormatYAML, FormatTOML} {
+		for _, from := range []string{FormatJSON, FormatYAML, FormatTOML} {
+			data := map[string]string{FormatJSON: formatJSON, FormatYAML: formatYAML, FormatTOML: formatTOML}[from]
+			out, err := Convert(from, []byte(data), to)
+			if err != nil {
+				t.Errorf("%s to %s: %v", from, to, err)
+				continue
+			}
+			cfg, err := parseFormat(to, out)
+			if err != nil || !reflect.DeepEqual(cfg, original) {
+				t.Errorf("%s to %s does not round-trip: %v\n%s", from, to, err, out)
+			}
+		}
+	}
+
+	if _, err := Convert(FormatJSON, []byte(`{"viewport": {"width": 0}}`), FormatYAML); err == nil {
+		t.Error("expected an invalid file not to convert")
+	}
+}
+
+func TestFindProjectFileFormats(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	os.MkdirAll(sub, 0755)
+
+	yamlFile := filepath.Join(dir, "phantomvite.config.yml")
+	writeFile(t, yamlFile, "engine: cdp\n")
+	if got := FindProjectFile(sub); got != yamlFile {
+		t.Errorf("expected %s, got %q", yamlFile, got)
+	}
+	res, err := Resolver{UserDir: t.TempDir(), WorkDir: sub, Environ: []string{}}.Resolve()
+	if err != nil || res.Config.Engine != "cdp" || res.Origins["engine"].Line != 1 {
+		t.Errorf("expected engine cdp from line 1 of the YAML file, got %+v, %v", res, err)
+	}
+
+	jsonFile := filepath.Join(dir, DefaultFile)
+	writeFile(t, jsonFile, `{"engine": "selenium"}`)
+	if got := FindProjectFile(sub); got != jsonFile {
+		t.Errorf("expected JSON to win, got %q", got)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+
+// gen_schema writes phantomvite.schema.json from the config types. Run it
+// with 'go generate ./pkg/config' after changing Config.
+package main
+
+import (
+	"log"
+	"os"
+
+	"phantomvite/pkg/config"
+)
+
+func main() {
+	data, err := config.MarshalSchema()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(config.SchemaFile, data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
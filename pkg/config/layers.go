@@ -0,0 +1,410 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+// Layers in order of precedence, lowest first. A device's values take the
+// precedence of the layer that selected the device.
+const (
+	LayerDefault = "default"
+	LayerUser    = "user"
+	LayerProject = "project"
+	LayerProfile = "profile"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+	LayerDevice  = "device"
+)
+
+// UserFile is the name of the JSON config file in the user config
+// directory; see UserFiles for the others
+const UserFile = "config.json"
+
+// Origin says which layer set a value and where exactly
+type Origin struct {
+	Layer  string `json:"layer"`
+	Source string `json:"source,omitempty"` // file path, variable or flag
+
+	// Line and Column locate the key when Source is a file
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+func (o Origin) String() string {
+	if o.Source == "" {
+		return o.Layer
+	}
+	if o.Line > 0 {
+		return fmt.Sprintf("%s %s:%d:%d", o.Layer, o.Source, o.Line, o.Column)
+	}
+	return o.Layer + " " + o.Source
+}
+
+// SourceError is an error in one configuration source, such as a file or
+// the environment.
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	var errs Errors
+	if !errors.As(e.Err, &errs) {
+		return e.Source + ": " + e.Err.Error()
+	}
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		// file:line:column: key: message, like a compiler
+		sep := ": "
+		if err.Line > 0 {
+			sep = ":"
+		}
+		lines[i] = e.Source + sep + err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *SourceError) Unwrap() error { return e.Err }
+
+// Resolver builds the effective configuration from, in order: built-in
+// defaults, the user config file, the nearest project config file, the
+// selected profile, PHANTOM_* environment variables and command-line flags.
+type Resolver struct {
+	UserDir string      // holds the user config file; "" uses UserConfigDir
+	WorkDir string      // where the project file search starts; "" uses the working directory
+	Environ []string    // KEY=value pairs; nil uses os.Environ
+	Flags   []FlagValue // the config flags of the command line, in order
+}
+
+// Resolved is the effective configuration and the origin of every key
+type Resolved struct {
+	Config  Config
+	Origins map[string]Origin
+	Files   []string // config files that were read, lowest precedence first
+}
+
+// UserConfigDir returns the phantomvite directory in the user's config
+// directory, $XDG_CONFIG_HOME/phantomvite on Linux.
+func UserConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "phantomvite"), nil
+}
+
+// FindProjectFile walks up from dir and returns the first project config
+// file it finds, see ProjectFiles, or "" when there is none.
+func FindProjectFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if path := findFile(dir, ProjectFiles); path != "" {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// findFile returns the first of names that is a file in dir
+func findFile(dir string, names []string) string {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Resolve merges every layer and validates the result
+func (r Resolver) Resolve() (*Resolved, error) {
+	res := &Resolved{Config: Default(), Origins: make(map[string]Origin)}
+	for _, key := range leafKeys(reflect.TypeOf(Config{}), "") {
+		res.Origins[key] = Origin{Layer: LayerDefault}
+	}
+
+	userDir := r.UserDir
+	if userDir == "" {
+		userDir, _ = UserConfigDir()
+	}
+	if path := findFile(userDir, UserFiles); userDir != "" && path != "" {
+		if err := res.applyFile(LayerUser, path); err != nil {
+			return nil, err
+		}
+	}
+
+	workDir := r.WorkDir
+	if workDir == "" {
+		workDir, _ = os.Getwd()
+	}
+	if path := FindProjectFile(workDir); path != "" {
+		if err := res.applyFile(LayerProject, path); err != nil {
+			return nil, err
+		}
+	}
+
+	environ := r.Environ
+	if environ == nil {
+		environ = os.Environ()
+	}
+	if err := res.applyProfile(r.profileName(environ)); err != nil {
+		return nil, err
+	}
+	if err := res.applyEnv(environ); err != nil {
+		return nil, err
+	}
+	if err := res.applyFlags(r.Flags); err != nil {
+		return nil, err
+	}
+
+	if err := res.Config.Validate(); err != nil {
+		return nil, res.annotate(err)
+	}
+	return res, nil
+}
+
+// applyFile decodes a config file of any format over the current values.
+// Relative plugin paths are resolved against the file's directory.
+func (res *Resolved) applyFile(layer, path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return &SourceError{Source: path, Err: err}
+	}
+
+	doc, pos, err := toJSON(FormatOf(path), data)
+	if err != nil {
+		return &SourceError{Source: path, Err: err}
+	}
+	profiles := res.Config.Profiles
+	set, err := decode(doc, &res.Config)
+	if err != nil {
+		if errs, ok := err.(Errors); ok {
+			locate(errs, pos)
+		}
+		return &SourceError{Source: path, Err: err}
+	}
+	for _, key := range set {
+		origin := Origin{Layer: layer, Source: path}
+		if at, ok := pos[key]; ok {
+			origin.Line, origin.Column = at.line, at.column
+		}
+		res.Origins[key] = origin
+	}
+	res.applyDevice(set)
+	for _, key := range set {
+		if key == "profiles" {
+			// Profiles merge by name so a project can add to the user's.
+			for name, raw := range profiles {
+				if _, ok := res.Config.Profiles[name]; !ok {
+					res.Config.Profiles[name] = raw
+				}
+			}
+		}
+		if key == "plugins" {
+			for i, p := range res.Config.Plugins {
+				if p.Path != "" && !filepath.IsAbs(p.Path) {
+					res.Config.Plugins[i].Path = filepath.Join(filepath.Dir(path), p.Path)
+				}
+			}
+		}
+	}
+	res.Files = append(res.Files, path)
+	return nil
+}
+
+func (res *Resolved) applyEnv(environ []string) error {
+	values := make(map[string]string)
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			values[k] = v
+		}
+	}
+
+	var errs Errors
+	var set []string
+	for i := range Settings {
+		s := &Settings[i]
+		value, ok := values[s.Env]
+		if !ok || value == "" {
+			continue
+		}
+		if err := s.apply(&res.Config, value); err != nil {
+			errs = append(errs, &FieldError{Key: s.Env, Message: err.Error()})
+			continue
+		}
+		res.record(s.Keys, Origin{Layer: LayerEnv, Source: s.Env})
+		set = append(set, s.Keys...)
+	}
+	if len(errs) > 0 {
+		return &SourceError{Source: "environment", Err: errs}
+	}
+	res.applyDevice(set)
+	return nil
+}
+
+func (res *Resolved) applyFlags(flags []FlagValue) error {
+	var errs Errors
+	var set []string
+	for _, f := range flags {
+		if err := f.Setting.apply(&res.Config, f.Value); err != nil {
+			errs = append(errs, &FieldError{Key: "--" + f.Setting.Flag, Message: err.Error()})
+			continue
+		}
+		res.record(f.Setting.Keys, Origin{Layer: LayerFlag, Source: "--" + f.Setting.Flag})
+		set = append(set, f.Setting.Keys...)
+	}
+	if len(errs) > 0 {
+		return &SourceError{Source: "command line", Err: errs}
+	}
+	res.applyDevice(set)
+	return nil
+}
+
+// profileName picks the profile to apply: --profile, then PHANTOM_PROFILE,
+// then the "profile" key of the config files.
+func (r Resolver) profileName(environ []string) string {
+	name := ""
+	for _, kv := range environ {
+		if v, ok := strings.CutPrefix(kv, ProfileEnv+"="); ok && v != "" {
+			name = v
+		}
+	}
+	for _, f := range r.Flags {
+		if f.Setting.Flag == "profile" {
+			name = f.Value
+		}
+	}
+	return name
+}
+
+// applyProfile decodes the named profile over the file layers
+func (res *Resolved) applyProfile(name string) error {
+	if name == "" {
+		name = res.Config.Profile
+	}
+	if name == "" {
+		return nil
+	}
+	raw, ok := res.Config.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(res.Config.Profiles))
+		for n := range res.Config.Profiles {
+			names = append(names, strconv.Quote(n))
+		}
+		sort.Strings(names)
+		available := "no profiles are defined"
+		if len(names) > 0 {
+			available = "available: " + strings.Join(names, ", ")
+		}
+		return &SourceError{Source: "profile", Err: fmt.Errorf("unknown profile %q (%s)", name, available)}
+	}
+	if errs := checkProfile(name, raw); len(errs) > 0 {
+		return &SourceError{Source: res.Origins["profiles"].Source, Err: errs}
+	}
+
+	set, _ := decode(raw, &res.Config)
+	res.Config.Profile = name
+	res.record(set, Origin{Layer: LayerProfile, Source: name})
+	res.applyDevice(set)
+	return nil
+}
+
+// record notes the origin of keys a layer set
+func (res *Resolved) record(keys []string, origin Origin) {
+	for _, key := range keys {
+		res.Origins[key] = origin
+	}
+}
+
+// applyDevice runs after each layer. When the layer selected a device, the
+// device fills in the viewport and user agent the layer did not set itself.
+func (res *Resolved) applyDevice(keys []string) {
+	if !slices.Contains(keys, "device") {
+		return
+	}
+	device, ok := engine.LookupDevice(res.Config.Device)
+	if !ok {
+		return // reported by Validate
+	}
+	from := Origin{Layer: LayerDevice, Source: device.Name}
+	if !slices.Contains(keys, "viewport.width") {
+		res.Config.Viewport.Width = device.Viewport.Width
+		res.Origins["viewport.width"] = from
+	}
+	if !slices.Contains(keys, "viewport.height") {
+		res.Config.Viewport.Height = device.Viewport.Height
+		res.Origins["viewport.height"] = from
+	}
+	if !slices.Contains(keys, "user_agent") {
+		res.Config.UserAgent = device.UserAgent
+		res.Origins["user_agent"] = from
+	}
+}
+
+// annotate adds the origin of each invalid key to validation errors
+func (res *Resolved) annotate(err error) error {
+	var errs Errors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	for _, e := range errs {
+		// Nested keys such as profiles.ci.timeout take the origin of the
+		// nearest recorded parent.
+		key, _, _ := strings.Cut(e.Key, "[")
+		for {
+			if origin, ok := res.Origins[key]; ok {
+				if origin.Layer != LayerDefault {
+					e.Message += " (set by " + origin.String() + ")"
+				}
+				break
+			}
+			i := strings.LastIndex(key, ".")
+			if i < 0 {
+				break
+			}
+			key = key[:i]
+		}
+	}
+	return errs
+}
+
+// Value is one effective config value as shown by 'config show'
+type Value struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Origin Origin      `json:"origin"`
+}
+
+// Values lists every leaf key with its effective value and origin
+func (res *Resolved) Values() []Value {
+	root := reflect.ValueOf(res.Config)
+	var values []Value
+	for _, key := range leafKeys(root.Type(), "") {
+		values = append(values, Value{
+			Key:    key,
+			Value:  lookup(root, key).Interface(),
+			Origin: res.Origins[key],
+		})
+	}
+	return values
+}
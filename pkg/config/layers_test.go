@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// flag is the config flag --name with value
+func flag(name, value string) FlagValue {
+	return FlagValue{Setting: LookupSetting(name), Value: value}
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	root := t.TempDir()
+	userDir := filepath.Join(root, "user")
+	writeFile(t, filepath.Join(userDir, UserFile), `{"engine": "cdp", "headless": false, "timeout": 5000, "proxy": "http://user:1"}`)
+	project := filepath.Join(root, "project", DefaultFile)
+	writeFile(t, project, `{"engine": "playwright", "viewport": {"width": 800, "height": 600}}`)
+	workDir := filepath.Join(root, "project", "src", "pages")
+	os.MkdirAll(workDir, 0755)
+
+	res, err := Resolver{
+		UserDir: userDir,
+		WorkDir: workDir,
+		Environ: []string{"PHANTOM_TIMEOUT=10s", "PHANTOM_RUNTIME_DIR=/elsewhere"},
+		Flags:   []FlagValue{flag("viewport", "1280x720"), flag("headless", "true")},
+	}.Resolve()
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	cfg := res.Config
+	if cfg.Engine != "playwright" || !cfg.Headless || cfg.Viewport.Width != 1280 ||
+		time.Duration(cfg.Timeout) != 10*time.Second || cfg.Proxy != "http://user:1" {
+		t.Errorf("unexpected effective config %+v", cfg)
+	}
+
+	userFile := filepath.Join(userDir, UserFile)
+	for key, want := range map[string]Origin{
+		"engine":          {Layer: LayerProject, Source: project},
+		"headless":        {Layer: LayerFlag, Source: "--headless"},
+		"viewport.width":  {Layer: LayerFlag, Source: "--viewport"},
+		"viewport.height": {Layer: LayerFlag, Source: "--viewport"},
+		"timeout":         {Layer: LayerEnv, Source: "PHANTOM_TIMEOUT"},
+		"proxy":           {Layer: LayerUser, Source: userFile},
+		"user_agent":      {Layer: LayerDefault},
+	} {
+		got := res.Origins[key]
+		got.Line, got.Column = 0, 0
+		if got != want {
+			t.Errorf("%s: expected origin %v, got %v", key, want, got)
+		}
+	}
+	if origin := res.Origins["engine"]; origin.Line != 1 || origin.Column != 2 {
+		t.Errorf("expected engine at 1:2 of the project file, got %d:%d", origin.Line, origin.Column)
+	}
+	if !reflect.DeepEqual(res.Files, []string{userFile, project}) {
+		t.Errorf("unexpected files %v", res.Files)
+	}
+}
+
+func TestResolveReportsBadEnvAndFlags(t *testing.T) {
+	_, err := Resolver{UserDir: t.TempDir(), WorkDir: t.TempDir(), Environ: []string{"PHANTOM_HEADLESS=maybe"}}.Resolve()
+	var srcErr *SourceError
+	if !errors.As(err, &srcErr) || srcErr.Source != "environment" || !strings.Contains(err.Error(), "PHANTOM_HEADLESS: expected true or false") {
+		t.Errorf("expected environment error, got %v", err)
+	}
+
+	flags := []FlagValue{flag("viewport", "0x720")}
+	_, err = Resolver{UserDir: t.TempDir(), WorkDir: t.TempDir(), Environ: []string{}, Flags: flags}.Resolve()
+	if err == nil || !strings.Contains(err.Error(), "viewport.width: must be greater than 0, got 0 (set by flag --viewport)") {
+		t.Errorf("expected validation error naming the flag, got %v", err)
+	}
+}
+
+func TestResolvePluginPathsRelativeToFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, DefaultFile), `{"plugins": [{"path": "plugins/seo.js"}]}`)
+	sub := filepath.Join(root, "nested")
+	os.MkdirAll(sub, 0755)
+
+	res, err := Resolver{UserDir: t.TempDir(), WorkDir: sub, Environ: []string{}}.Resolve()
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if want := filepath.Join(root, "plugins", "seo.js"); res.Config.Plugins[0].Path != want {
+		t.Errorf("expected plugin path %s, got %s", want, res.Config.Plugins[0].Path)
+	}
+}
+
+const profilesConfig = `{
+  "timeout": 5000,
+  "profiles": {
+    "ci": {"headless": true, "timeout": "60s"},
+    "mobile": {"device": "iPhone 12", "headless": false},
+    "tablet": {"device": "DeviceIPadPro", "viewport": {"width": 800, "height": 1000}}
+  }
+}`
+
+func resolveProfile(t *testing.T, environ []string, flags ...FlagValue) (*Resolved, error) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, DefaultFile), profilesConfig)
+	return Resolver{UserDir: t.TempDir(), WorkDir: dir, Environ: environ, Flags: flags}.Resolve()
+}
+
+func TestProfileSelection(t *testing.T) {
+	res, err := resolveProfile(t, []string{"PHANTOM_PROFILE=ci"})
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if time.Duration(res.Config.Timeout) != time.Minute || res.Origins["timeout"] != (Origin{Layer: LayerProfile, Source: "ci"}) {
+		t.Errorf("expected ci profile timeout, got %v from %v", time.Duration(res.Config.Timeout), res.Origins["timeout"])
+	}
+
+	// The flag wins over the environment, and later layers over the profile.
+	res, err = resolveProfile(t, []string{"PHANTOM_PROFILE=ci"}, flag("profile", "mobile"), flag("headless", "true"))
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	cfg := res.Config
+	if cfg.Profile != "mobile" || !cfg.Headless || time.Duration(cfg.Timeout) != 5*time.Second {
+		t.Errorf("unexpected config for mobile profile %+v", cfg)
+	}
+	if cfg.Viewport.Width != engine.DeviceIPhone12.Viewport.Width || cfg.UserAgent != engine.DeviceIPhone12.UserAgent {
+		t.Errorf("expected iPhone 12 viewport and user agent, got %+v", cfg)
+	}
+	if got := res.Origins["user_agent"]; got != (Origin{Layer: LayerDevice, Source: "iPhone 12"}) {
+		t.Errorf("unexpected user_agent origin %v", got)
+	}
+}
+
+func TestProfileKeysOverrideDevice(t *testing.T) {
+	res, err := resolveProfile(t, nil, flag("profile", "tablet"))
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if res.Config.Viewport.Width != 800 || res.Config.UserAgent != engine.DeviceIPadPro.UserAgent {
+		t.Errorf("expected profile viewport with device user agent, got %+v", res.Config)
+	}
+
+	// A device flag fills in what the other flags leave unset.
+	res, err = resolveProfile(t, nil, flag("viewport", "500x500"), flag("device", "pixel-5"))
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if res.Config.Viewport.Width != 500 || res.Config.UserAgent != engine.DevicePixel5.UserAgent {
+		t.Errorf("expected flag viewport with device user agent, got %+v", res.Config)
+	}
+}
+
+func TestProfileErrors(t *testing.T) {
+	_, err := resolveProfile(t, nil, flag("profile", "staging"))
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "staging" (available: "ci", "mobile", "tablet")`) {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+
+	_, err = Parse([]byte(`{"profiles": {"bad": {"device": "Nokia", "viewPort": {}, "profile": "x"}}}`))
+	for _, want := range []string{
+		"profiles.bad.viewPort: unknown field",
+		"profiles.bad.profile: cannot be set inside a profile",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q, got %v", want, err)
+		}
+	}
+	_, err = Parse([]byte(`{"profiles": {"bad": {"device": "Nokia"}}}`))
+	if err == nil || !strings.Contains(err.Error(), `profiles.bad.device: unknown device "Nokia"`) {
+		t.Errorf("expected unknown device error, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// stripComments blanks out // and /* */ comments outside of strings so
+// config files can be commented. Comment bytes become spaces and newlines
+// are kept, which leaves every offset, line and column unchanged.
+func stripComments(data []byte) []byte {
+	if !bytes.Contains(data, []byte("/")) {
+		return data
+	}
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			stop := len(out)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+	return out
+}
+
+// position is a 1-based line and column in a config file
+type position struct {
+	line, column int
+}
+
+// positions maps every key path in a JSON document to where its key (or,
+// for array elements, its value) starts. It gives up quietly on malformed
+// input.
+func positions(data []byte) map[string]position {
+	pos := make(map[string]position)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	// start skips the separators between the decoder and the next token
+	start := func() position {
+		offset := int(dec.InputOffset())
+		for offset < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+			offset++
+		}
+		line, column := lineColumn(data, offset)
+		return position{line, column}
+	}
+
+	var walk func(path string) bool
+	walk = func(path string) bool {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				at := start()
+				key, err := dec.Token()
+				if err != nil {
+					return false
+				}
+				name, _ := key.(string)
+				pos[join(path, name)] = at
+				if !walk(join(path, name)) {
+					return false
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				element := fmt.Sprintf("%s[%d]", path, i)
+				pos[element] = start()
+				if !walk(element) {
+					return false
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err == nil
+	}
+	walk("")
+	return pos
+}
+
+// lineColumn converts a byte offset to a 1-based line and column
+func lineColumn(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := offset - bytes.LastIndexByte(data[:offset], '\n')
+	return line, column
+}
+
+// locate sets the line and column of each error from the positions of the
+// file it was found in and sorts them by position. Keys missing from the
+// file fall back to their nearest parent that is present, or go last.
+func locate(errs Errors, pos map[string]position) {
+	for _, e := range errs {
+		if e.Line > 0 {
+			continue
+		}
+		for key := e.Key; key != ""; key = parentKey(key) {
+			if at, ok := pos[key]; ok {
+				e.Line, e.Column = at.line, at.column
+				break
+			}
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if (a.Line == 0) != (b.Line == 0) {
+			return b.Line == 0
+		}
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+}
+
+// parentKey drops the last segment of a key path
+func parentKey(key string) string {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' || key[i] == '[' {
+			return key[:i]
+		}
+	}
+	return ""
+}
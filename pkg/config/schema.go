@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+//go:generate go run gen_schema.go
+
+// SchemaFile is the generated schema checked in next to this package
+const SchemaFile = "phantomvite.schema.json"
+
+// docs describes each key in the schema and the starter config
+var docs = map[string]string{
+	"$schema":           "JSON Schema the file follows, for editors",
+	"engine":            "Automation engine, see 'phantom-vite engines'",
+	"headless":          "Run the browser without a window",
+	"viewport":          "Browser viewport size in CSS pixels",
+	"viewport.width":    "Viewport width in CSS pixels",
+	"viewport.height":   "Viewport height in CSS pixels",
+	"timeout":           `Timeout for each operation: milliseconds or a duration string such as "10s"`,
+	"user_agent":        "User agent sent by every page",
+	"extra_headers":     "HTTP headers added to every request",
+	"proxy":             "Proxy server URL, e.g. http://127.0.0.1:3128",
+	"executable_path":   "Browser executable to launch instead of the engine's default",
+	"args":              "Extra command-line arguments for the browser",
+	"device":            "Predefined device whose viewport and user agent are used unless set alongside it",
+	"plugins":           "Plugins loaded for every command",
+	"plugins[].path":    "Plugin script, relative to the config file",
+	"plugins[].name":    "Display name of the plugin",
+	"plugins[].enabled": "Load the plugin; defaults to true",
+	"plugins[].options": "Options passed to the plugin",
+	"entries":           "Scripts built by 'phantom-vite build'",
+	"profile":           "Profile applied by default; --profile and PHANTOM_PROFILE win",
+	"profiles":          "Named sets of keys applied over the file with --profile NAME",
+}
+
+// constraints adds to the schema what Validate checks beyond types
+var constraints = map[string]map[string]interface{}{
+	"engine":            {"minLength": 1},
+	"viewport.width":    {"minimum": 1},
+	"viewport.height":   {"minimum": 1},
+	"proxy":             {"pattern": "^(http|https|socks4|socks5)://[^/]"},
+	"args[]":            {"minLength": 1},
+	"plugins[]":         {"required": []string{"path"}},
+	"plugins[].path":    {"minLength": 1},
+	"plugins[].enabled": {"default": true},
+	"extra_headers":     {"propertyNames": map[string]interface{}{"pattern": "^[^ :\\r\\n]+$"}},
+}
+
+var (
+	durationType   = reflect.TypeOf(Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schema returns a JSON Schema (draft-07) for config files. It is built
+// from Config, so it accepts the same keys and types as Parse.
+func Schema() map[string]interface{} {
+	root := reflect.ValueOf(Default())
+	s := objectSchema(root.Type(), "", root, nil)
+	s["$schema"] = "http://json-schema.org/draft-07/schema#"
+	s["title"] = "phantomvite config"
+	s["properties"].(map[string]interface{})["$schema"] = map[string]interface{}{
+		"type": "string", "description": docs["$schema"],
+	}
+
+	// A profile is a config file without profiles of its own
+	profile := objectSchema(root.Type(), "", reflect.Value{}, []string{"profile", "profiles"})
+	profile["description"] = "Keys applied over the config file when the profile is selected"
+	s["definitions"] = map[string]interface{}{"profile": profile}
+	return s
+}
+
+// MarshalSchema returns Schema as indented JSON, as written to SchemaFile
+func MarshalSchema() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// objectSchema describes a struct type. defaults holds the default values
+// to record, or is invalid when there are none.
+func objectSchema(t reflect.Type, key string, defaults reflect.Value, skip []string) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" || slices.Contains(skip, name) {
+			continue
+		}
+		var value reflect.Value
+		if defaults.IsValid() {
+			value = defaults.Field(i)
+		}
+		properties[name] = typeSchema(t.Field(i).Type, join(key, name), value)
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func typeSchema(t reflect.Type, key string, value reflect.Value) map[string]interface{} {
+	var s map[string]interface{}
+	switch {
+	case t == durationType:
+		s = map[string]interface{}{
+			"type":    []string{"integer", "string"},
+			"minimum": 1,
+			"pattern": `^([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$`,
+		}
+	case t == rawMessageType:
+		s = map[string]interface{}{"$ref": "#/definitions/profile"}
+	case t.Kind() == reflect.Struct:
+		s = objectSchema(t, key, value, nil)
+	case t.Kind() == reflect.Slice:
+		s = map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), key+"[]", reflect.Value{})}
+	case t.Kind() == reflect.Map:
+		s = map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), key+".*", reflect.Value{})}
+	case t.Kind() == reflect.Interface:
+		s = map[string]interface{}{}
+	default:
+		s = map[string]interface{}{"type": schemaType(t.Kind())}
+	}
+
+	if key == "device" {
+		names := make([]string, len(engine.Devices))
+		for i, d := range engine.Devices {
+			names[i] = d.Name
+		}
+		s["examples"] = names
+	}
+	if doc, ok := docs[key]; ok {
+		s["description"] = doc
+	}
+	for k, v := range constraints[key] {
+		s[k] = v
+	}
+	if value.IsValid() && t.Kind() != reflect.Struct && !value.IsZero() {
+		s["default"] = value.Interface()
+	}
+	return s
+}
+
+func schemaType(k reflect.Kind) string {
+	switch k {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return strings.ToLower(k.String())
+}
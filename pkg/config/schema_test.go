@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaFileUpToDate(t *testing.T) {
+	want, err := MarshalSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(SchemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date, run 'go generate ./pkg/config'", SchemaFile)
+	}
+}
+
+func TestSchemaDescribesEveryKey(t *testing.T) {
+	schema := Schema()
+	for _, key := range leafKeys(reflect.TypeOf(Config{}), "") {
+		node := schema
+		for _, name := range strings.Split(key, ".") {
+			next, ok := node["properties"].(map[string]interface{})[name].(map[string]interface{})
+			if !ok {
+				t.Fatalf("%s: missing from schema", key)
+			}
+			node = next
+		}
+		if node["description"] == nil {
+			t.Errorf("%s: no description", key)
+		}
+	}
+}
+
+func TestStarterIsValid(t *testing.T) {
+	cfg, err := Parse(Starter())
+	if err != nil {
+		t.Fatalf("starter config does not parse: %v", err)
+	}
+	if _, ok := cfg.Profiles["debug"]; !ok {
+		t.Errorf("expected the debug profile, got %v", cfg.Profiles)
+	}
+	cfg.Profiles = nil
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("starter should hold the defaults, got %+v", cfg)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Setting is a config value that can also be given as a PHANTOM_*
+// environment variable or a command-line flag.
+type Setting struct {
+	Flag  string   // flag name without dashes, e.g. "timeout"
+	Env   string   // environment variable, e.g. "PHANTOM_TIMEOUT"
+	Keys  []string // config keys the value sets
+	Value string   // placeholder for the value in help, e.g. "WxH"
+	Usage string
+	Bool  bool // the flag may be given without a value
+
+	apply func(c *Config, value string) error
+}
+
+// ProfileEnv selects a profile like the --profile flag
+const ProfileEnv = "PHANTOM_PROFILE"
+
+// Settings lists every value that environment variables and flags can set
+var Settings = []Setting{
+	{
+		Flag: "engine", Env: "PHANTOM_ENGINE", Keys: []string{"engine"}, Value: "name",
+		Usage: "automation engine (see 'engines')",
+		apply: func(c *Config, v string) error { c.Engine = v; return nil },
+	},
+	{
+		Flag: "headless", Env: "PHANTOM_HEADLESS", Keys: []string{"headless"}, Bool: true,
+		Usage: "run the browser without a window (--headless=false to show it)",
+		apply: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected true or false, got %q", v)
+			}
+			c.Headless = b
+			return nil
+		},
+	},
+	{
+		Flag: "viewport", Env: "PHANTOM_VIEWPORT", Keys: []string{"viewport.width", "viewport.height"}, Value: "WxH",
+		Usage: "viewport size as WIDTHxHEIGHT, e.g. 1280x720",
+		apply: func(c *Config, v string) error {
+			w, h, ok := strings.Cut(strings.ToLower(v), "x")
+			width, werr := strconv.Atoi(strings.TrimSpace(w))
+			height, herr := strconv.Atoi(strings.TrimSpace(h))
+			if !ok || werr != nil || herr != nil {
+				return fmt.Errorf("expected WIDTHxHEIGHT, got %q", v)
+			}
+			c.Viewport.Width, c.Viewport.Height = width, height
+			return nil
+		},
+	},
+	{
+		Flag: "timeout", Env: "PHANTOM_TIMEOUT", Keys: []string{"timeout"}, Value: "duration",
+		Usage: `operation timeout, e.g. 10s or 500ms (a bare number is milliseconds)`,
+		apply: func(c *Config, v string) error {
+			d, err := ParseDuration(v)
+			if err != nil {
+				return err
+			}
+			c.Timeout = d
+			return nil
+		},
+	},
+	{
+		Flag: "device", Env: "PHANTOM_DEVICE", Keys: []string{"device"}, Value: "name",
+		Usage: `predefined device to emulate, e.g. "iPhone 12" or pixel-5`,
+		apply: func(c *Config, v string) error { c.Device = v; return nil },
+	},
+	{
+		Flag: "user-agent", Env: "PHANTOM_USER_AGENT", Keys: []string{"user_agent"}, Value: "string",
+		Usage: "user agent sent by every page",
+		apply: func(c *Config, v string) error { c.UserAgent = v; return nil },
+	},
+	{
+		Flag: "proxy", Env: "PHANTOM_PROXY", Keys: []string{"proxy"}, Value: "url",
+		Usage: "proxy server URL, e.g. http://127.0.0.1:3128",
+		apply: func(c *Config, v string) error { c.Proxy = v; return nil },
+	},
+	{
+		Flag: "executable-path", Env: "PHANTOM_EXECUTABLE_PATH", Keys: []string{"executable_path"}, Value: "file",
+		Usage: "browser executable to launch",
+		apply: func(c *Config, v string) error { c.ExecutablePath = v; return nil },
+	},
+	{
+		Flag: "browser-args", Env: "PHANTOM_BROWSER_ARGS", Keys: []string{"args"}, Value: "args",
+		Usage: "extra browser arguments, separated by spaces",
+		apply: func(c *Config, v string) error { c.Args = strings.Fields(v); return nil },
+	},
+	{
+		Flag: "profile", Env: ProfileEnv, Keys: []string{"profile"}, Value: "name",
+		Usage: "named profile from the config file's profiles",
+		apply: func(c *Config, v string) error { c.Profile = v; return nil },
+	},
+}
+
+// ParseDuration parses a Go duration string, or a bare number of
+// milliseconds as config files have always used.
+func ParseDuration(s string) (Duration, error) {
+	s = strings.TrimSpace(s)
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Duration(time.Duration(ms) * time.Millisecond), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return Duration(d), nil
+}
+
+// FlagValue is a config flag given on the command line
+type FlagValue struct {
+	Setting *Setting
+	Value   string
+}
+
+// LookupSetting returns the setting with the given flag name, or nil
+func LookupSetting(name string) *Setting {
+	for i := range Settings {
+		if Settings[i].Flag == name {
+			return &Settings[i]
+		}
+	}
+	return nil
+}
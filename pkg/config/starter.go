@@ -0,0 +1,48 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Starter returns a commented config file holding the defaults, with the
+// optional keys commented out, as written by 'config init'.
+func Starter() []byte {
+	def := Default()
+	value := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// phantom-vite configuration. Comments are allowed; run\n")
+	fmt.Fprintf(&b, "// 'phantom-vite config validate' after editing and\n")
+	fmt.Fprintf(&b, "// 'phantom-vite config schema' for every key.\n")
+	fmt.Fprintf(&b, "{\n")
+	key := func(name, v string) {
+		fmt.Fprintf(&b, "  // %s\n  %q: %s,\n\n", docs[name], name, v)
+	}
+	optional := func(name, v string) {
+		fmt.Fprintf(&b, "  // %s\n  // %q: %s,\n\n", docs[name], name, v)
+	}
+
+	key("engine", value(def.Engine))
+	key("headless", value(def.Headless))
+	key("viewport", fmt.Sprintf(`{ "width": %d, "height": %d }`, def.Viewport.Width, def.Viewport.Height))
+	key("timeout", value(def.Timeout))
+	optional("device", `"iPhone 12"`)
+	optional("user_agent", `"Mozilla/5.0 (compatible; phantom-vite)"`)
+	optional("extra_headers", `{ "Accept-Language": "en-US" }`)
+	optional("proxy", `"http://127.0.0.1:3128"`)
+	optional("executable_path", `"/usr/bin/chromium"`)
+	optional("args", `["--disable-gpu"]`)
+	optional("plugins", `[{ "path": "plugins/example.js" }]`)
+	optional("entries", `["scripts/main.js"]`)
+	fmt.Fprintf(&b, "  // %s\n", docs["profiles"])
+	fmt.Fprintf(&b, "  \"profiles\": {\n")
+	fmt.Fprintf(&b, "    \"debug\": { \"headless\": false, \"timeout\": \"2m\" }\n")
+	fmt.Fprintf(&b, "  }\n")
+	fmt.Fprintf(&b, "}\n")
+	return b.Bytes()
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+// proxySchemes are the proxy URL schemes every engine understands
+var proxySchemes = []string{"http", "https", "socks4", "socks5"}
+
+// Validate checks values that decode fine but cannot work, returning Errors
+// with one entry per offending key.
+func (c Config) Validate() error {
+	var errs Errors
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(c.Engine) == "" {
+		fail("engine", "must not be empty")
+	}
+	if c.Viewport.Width <= 0 {
+		fail("viewport.width", "must be greater than 0, got %d", c.Viewport.Width)
+	}
+	if c.Viewport.Height <= 0 {
+		fail("viewport.height", "must be greater than 0, got %d", c.Viewport.Height)
+	}
+	if c.Timeout <= 0 {
+		fail("timeout", "must be greater than 0")
+	}
+
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		switch {
+		case err != nil || u.Host == "":
+			fail("proxy", "must be a URL such as http://host:port, got %q", c.Proxy)
+		case !slices.Contains(proxySchemes, u.Scheme):
+			fail("proxy", "unsupported scheme %q, use one of %s", u.Scheme, strings.Join(proxySchemes, ", "))
+		}
+	}
+	names := make([]string, 0, len(c.ExtraHeaders))
+	for name := range c.ExtraHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " :\r\n") {
+			fail("extra_headers", "invalid header name %q", name)
+		}
+	}
+	for i, arg := range c.Args {
+		if strings.TrimSpace(arg) == "" {
+			fail(fmt.Sprintf("args[%d]", i), "must not be empty")
+		}
+	}
+	if c.Device != "" {
+		if _, ok := engine.LookupDevice(c.Device); !ok {
+			fail("device", "unknown device %q, use one of %s", c.Device, strings.Join(deviceNames(), ", "))
+		}
+	}
+	for _, name := range sortedKeys(c.Profiles) {
+		errs = append(errs, checkProfile(name, c.Profiles[name])...)
+	}
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			fail("profile", "unknown profile %q", c.Profile)
+		}
+	}
+	for i, p := range c.Plugins {
+		if strings.TrimSpace(p.Path) == "" {
+			fail(fmt.Sprintf("plugins[%d].path", i), "must not be empty")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkProfile decodes a profile on its own to report unknown keys, bad
+// values and keys a profile may not set.
+func checkProfile(name string, raw []byte) Errors {
+	prefix := "profiles." + name
+	scratch := Default()
+	set, err := decode(raw, &scratch)
+
+	var errs Errors
+	if err != nil {
+		errs, _ = err.(Errors)
+	}
+	for _, key := range set {
+		if key == "profile" || key == "profiles" {
+			errs = append(errs, &FieldError{Key: key, Message: "cannot be set inside a profile"})
+		}
+	}
+	if errs == nil && scratch.Device != "" {
+		if _, ok := engine.LookupDevice(scratch.Device); !ok {
+			errs = append(errs, &FieldError{Key: "device", Message: fmt.Sprintf("unknown device %q, use one of %s", scratch.Device, strings.Join(deviceNames(), ", "))})
+		}
+	}
+	for _, e := range errs {
+		e.Key = join(prefix, e.Key)
+	}
+	return errs
+}
+
+func deviceNames() []string {
+	names := make([]string, len(engine.Devices))
+	for i, d := range engine.Devices {
+		names[i] = strconv.Quote(d.Name)
+	}
+	return names
+}
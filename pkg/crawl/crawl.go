@@ -0,0 +1,358 @@
+// Package crawl visits a site breadth first with pages of a pool. Every
+// page is rendered by the engine before its links are read from the DOM,
+// so links added by scripts are followed too. URLs are deduped in their
+// normalized form, robots.txt is respected and loads of the same host are
+// spaced out by a delay.
+//
+// The result is the crawl graph: every visited page with its depth, its
+// parent and the normalized links it has.
+package crawl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/pool"
+)
+
+// DefaultUserAgent is the robots.txt user agent of a crawl without one
+const DefaultUserAgent = "phantom-vite"
+
+// linksScript lists the absolute URLs of the links of the rendered page
+const linksScript = `Array.from(document.querySelectorAll("a[href], area[href]"), a => a.href)`
+
+// Config configures a crawl. Zero values select the defaults.
+type Config struct {
+	Pages        *pool.Pool                // required
+	MaxDepth     int                       // links followed away from the start; 0 visits only the start
+	MaxPages     int                       // pages visited at most; 0 for no limit
+	SameOrigin   bool                      // follow only links with the scheme and host of the start
+	Concurrency  int                       // pages loading at the same time; 1 by default
+	Delay        time.Duration             // least time between loads of the same host; robots.txt may raise it
+	IgnoreRobots bool                      // do not fetch or obey robots.txt
+	UserAgent    string                    // matched against robots.txt groups; DefaultUserAgent by default
+	Client       *http.Client              // fetches robots.txt; http.DefaultClient by default
+	Navigation   *engine.NavigationOptions // how pages are loaded
+	Prepare      func(engine.Page) error   // runs before each navigation, e.g. to emulate a device
+	OnPage       func(*Page) error         // runs after each page; an error stops the crawl
+	Log          io.Writer                 // one line per page; none when nil
+}
+
+// Statuses of a Page
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// Page is a visited page, a node of the crawl graph. Links are the
+// normalized URLs the page links to, in the order of the DOM, whether or
+// not they were visited.
+type Page struct {
+	URL     string           `json:"url"`
+	Depth   int              `json:"depth"`
+	Parent  string           `json:"parent,omitempty"` // the page the URL was found on first
+	Status  string           `json:"status"`
+	Error   string           `json:"error,omitempty"`
+	Kind    engine.ErrorKind `json:"error_kind,omitempty"`
+	Title   string           `json:"title,omitempty"`
+	Links   []string         `json:"links,omitempty"`
+	Elapsed time.Duration    `json:"-"`
+}
+
+// Result is the crawl graph. Pages are in the order of their visits:
+// by depth, and in the order their links were found within a depth.
+type Result struct {
+	Start     string   `json:"start"`
+	Pages     []*Page  `json:"pages"`
+	Blocked   []string `json:"blocked,omitempty"`   // found but disallowed by robots.txt
+	Truncated bool     `json:"truncated,omitempty"` // MaxPages left found URLs unvisited
+}
+
+// OK counts the pages that loaded
+func (r *Result) OK() int {
+	n := 0
+	for _, p := range r.Pages {
+		if p.Status == StatusOK {
+			n++
+		}
+	}
+	return n
+}
+
+// Crawler runs one crawl
+type Crawler struct {
+	config Config
+	start  *url.URL
+
+	mu     sync.Mutex // guards the fields below
+	robots map[string]*Robots
+	next   map[string]time.Time // earliest next load of each host
+	done   int
+}
+
+// New returns a crawler starting at start, which must be an http or https
+// URL.
+func New(start string, config Config) (*Crawler, error) {
+	if config.Pages == nil {
+		return nil, errors.New("crawl: a pool of pages is required")
+	}
+	u, err := parseStart(start)
+	if err != nil {
+		return nil, err
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Log == nil {
+		config.Log = io.Discard
+	}
+	return &Crawler{config: config, start: u, robots: map[string]*Robots{}, next: map[string]time.Time{}}, nil
+}
+
+// Run crawls until no unvisited URL is left within MaxDepth and MaxPages.
+// Pages that fail to load are part of the result. Run stops early, with
+// the result so far, when ctx is done or OnPage fails.
+func (c *Crawler) Run(ctx context.Context) (*Result, error) {
+	r := &Result{Start: c.start.String()}
+	if !c.allowed(ctx, r.Start) {
+		return r, fmt.Errorf("the robots.txt of %s disallows %s", origin(c.start), r.Start)
+	}
+	seen := map[string]bool{r.Start: true}
+	level := []*Page{{URL: r.Start}}
+	for depth := 0; len(level) > 0; depth++ {
+		if err := c.visit(ctx, level); err != nil {
+			r.Pages = append(r.Pages, visited(level)...)
+			return r, err
+		}
+		r.Pages = append(r.Pages, level...)
+		if depth == c.config.MaxDepth {
+			break
+		}
+
+		var next []*Page
+		for _, page := range level {
+			for _, link := range page.Links {
+				if seen[link] || !c.follows(link) {
+					continue
+				}
+				seen[link] = true
+				if !c.allowed(ctx, link) {
+					r.Blocked = append(r.Blocked, link)
+					continue
+				}
+				if c.config.MaxPages > 0 && len(r.Pages)+len(next) >= c.config.MaxPages {
+					r.Truncated = true
+					continue
+				}
+				next = append(next, &Page{URL: link, Depth: depth + 1, Parent: page.URL})
+			}
+		}
+		level = next
+	}
+	return r, nil
+}
+
+// visited returns the pages of level that have a status
+func visited(level []*Page) []*Page {
+	var pages []*Page
+	for _, p := range level {
+		if p.Status != "" {
+			pages = append(pages, p)
+		}
+	}
+	return pages
+}
+
+// follows reports whether link is within the crawl's origin limits
+func (c *Crawler) follows(link string) bool {
+	if !c.config.SameOrigin {
+		return true
+	}
+	u, err := url.Parse(link)
+	return err == nil && origin(u) == origin(c.start)
+}
+
+// allowed reports whether robots.txt allows link, fetching it once per
+// origin. A robots.txt that cannot be fetched allows everything.
+func (c *Crawler) allowed(ctx context.Context, link string) bool {
+	if c.config.IgnoreRobots {
+		return true
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return c.robotsOf(ctx, u).Allowed(u.RequestURI())
+}
+
+func (c *Crawler) robotsOf(ctx context.Context, u *url.URL) *Robots {
+	key := origin(u)
+	c.mu.Lock()
+	robots, ok := c.robots[key]
+	c.mu.Unlock()
+	if ok {
+		return robots
+	}
+	fetched, err := fetchRobots(ctx, c.config.Client, key, c.config.UserAgent)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if robots, ok := c.robots[key]; ok {
+		return robots // fetched by another worker meanwhile
+	}
+	if err != nil {
+		fmt.Fprintf(c.config.Log, "⚠️  Ignoring the robots.txt of %s: %v\n", key, err)
+		fetched = &Robots{}
+	}
+	c.robots[key] = fetched
+	return fetched
+}
+
+// visit loads the pages of a level with Concurrency workers
+func (c *Crawler) visit(ctx context.Context, level []*Page) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	jobs := make(chan *Page)
+	var wg sync.WaitGroup
+	for w := 0; w < min(c.config.Concurrency, len(level)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range jobs {
+				c.load(ctx, page)
+				if c.config.OnPage != nil {
+					if err := c.config.OnPage(page); err != nil {
+						cancel(err)
+					}
+				}
+			}
+		}()
+	}
+feed:
+	for _, page := range level {
+		select {
+		case jobs <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// load renders page.URL and reads its title and links
+func (c *Crawler) load(ctx context.Context, page *Page) {
+	start := time.Now()
+	err := func() error {
+		if err := c.wait(ctx, page.URL); err != nil {
+			return err
+		}
+		pg, err := c.config.Pages.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to open page: %w", err)
+		}
+		defer pg.Release()
+		if c.config.Prepare != nil {
+			if err := c.config.Prepare(pg); err != nil {
+				return err
+			}
+		}
+		if err := pg.Navigate(page.URL, c.config.Navigation); err != nil {
+			return fmt.Errorf("navigation failed: %w", err)
+		}
+		if title, err := pg.Title(); err == nil {
+			page.Title = title
+		}
+		page.Links, err = links(pg, page.URL)
+		return err
+	}()
+	page.Elapsed = time.Since(start)
+
+	page.Status = StatusOK
+	if err != nil {
+		page.Status, page.Error, page.Kind = StatusFailed, err.Error(), engine.KindOf(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done++
+	if err != nil {
+		fmt.Fprintf(c.config.Log, "❌ [%d] %s: %v\n", c.done, page.URL, err)
+	} else {
+		fmt.Fprintf(c.config.Log, "🔗 [%d] %s: %d links (%v)\n", c.done, page.URL, len(page.Links), page.Elapsed.Round(time.Millisecond))
+	}
+}
+
+// wait sleeps until the host of link may be loaded again, and books the
+// next slot
+func (c *Crawler) wait(ctx context.Context, link string) error {
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+	delay := c.config.Delay
+	if !c.config.IgnoreRobots {
+		delay = max(delay, c.robotsOf(ctx, u).Delay)
+	}
+	if delay <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	at := time.Now()
+	if next := c.next[u.Host]; next.After(at) {
+		at = next
+	}
+	c.next[u.Host] = at.Add(delay)
+	c.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// links reads the deduped, normalized links of the rendered page, resolving
+// them against its current URL so redirects are followed
+func links(page engine.Page, fallback string) ([]string, error) {
+	result, err := page.ExecuteScript(linksScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read links: %w", err)
+	}
+	hrefs, _ := result.([]interface{})
+	current, err := page.URL()
+	if err != nil || current == "" {
+		current = fallback
+	}
+	base, err := url.Parse(current)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, href := range hrefs {
+		s, _ := href.(string)
+		if link, ok := Normalize(base, s); ok && !seen[link] {
+			seen[link] = true
+			out = append(out, link)
+		}
+	}
+	return out, nil
+}
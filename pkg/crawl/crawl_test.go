@@ -0,0 +1,249 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+	"phantomvite/pkg/pool"
+)
+
+// siteEngine is a fake engine serving site, the links of each path; paths
+// missing from site time out
+func siteEngine(site map[string][]string) *enginetest.Engine {
+	path := func(raw string) string {
+		u, _ := url.Parse(raw)
+		return u.Path
+	}
+	return &enginetest.Engine{
+		Load: func(raw string) error {
+			if _, ok := site[path(raw)]; !ok {
+				return engine.NewEngineError("fake", "navigate", "timed out", context.DeadlineExceeded)
+			}
+			return nil
+		},
+		Script: func(p *enginetest.Page, script string) (interface{}, error) {
+			if script != linksScript {
+				return nil, errors.New("unexpected script " + script)
+			}
+			current, _ := p.URL()
+			var hrefs []interface{}
+			for _, link := range site[path(current)] {
+				hrefs = append(hrefs, link)
+			}
+			return hrefs, nil
+		},
+	}
+}
+
+// newCrawler crawls site from the root of a server that serves robots
+func newCrawler(t *testing.T, site map[string][]string, robots string, config Config) *Crawler {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" || robots == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(robots))
+	}))
+	t.Cleanup(ts.Close)
+	pages, err := pool.New(pool.Config{
+		Launch: func(context.Context) (engine.Engine, error) { return siteEngine(site), nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pages.Close() })
+	config.Pages = pages
+	c, err := New(ts.URL, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+var site = map[string][]string{
+	"/":  {"/a", "/b#top", "mailto:me@example.com", "https://other.example/", "/a?"},
+	"/a": {"/", "/c", "/private/x"},
+	"/b": {"/broken"},
+	"/c": {"/d"},
+	"/d": nil,
+}
+
+func TestCrawl(t *testing.T) {
+	var mu sync.Mutex
+	var hooked []string
+	c := newCrawler(t, site, "User-agent: *\nDisallow: /private\n", Config{
+		MaxDepth:    2,
+		SameOrigin:  true,
+		Concurrency: 2,
+		OnPage: func(p *Page) error {
+			mu.Lock()
+			defer mu.Unlock()
+			hooked = append(hooked, p.URL)
+			return nil
+		},
+	})
+	r, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := r.Start[:len(r.Start)-1]
+	want := []Page{
+		{URL: base + "/", Depth: 0, Status: StatusOK, Title: "Title of " + base + "/",
+			Links: []string{base + "/a", base + "/b", "https://other.example/"}},
+		{URL: base + "/a", Depth: 1, Parent: base + "/", Status: StatusOK, Title: "Title of " + base + "/a",
+			Links: []string{base + "/", base + "/c", base + "/private/x"}},
+		{URL: base + "/b", Depth: 1, Parent: base + "/", Status: StatusOK, Title: "Title of " + base + "/b",
+			Links: []string{base + "/broken"}},
+		{URL: base + "/c", Depth: 2, Parent: base + "/a", Status: StatusOK, Title: "Title of " + base + "/c",
+			Links: []string{base + "/d"}},
+		{URL: base + "/broken", Depth: 2, Parent: base + "/b", Status: StatusFailed, Kind: engine.KindNavigationTimeout},
+	}
+	if len(r.Pages) != len(want) {
+		t.Fatalf("expected %d pages, got %d: %+v", len(want), len(r.Pages), r.Pages)
+	}
+	for i, got := range r.Pages {
+		g := *got
+		g.Error, g.Elapsed = "", 0
+		if !reflect.DeepEqual(g, want[i]) {
+			t.Errorf("page %d: expected %+v, got %+v", i, want[i], g)
+		}
+	}
+	if !reflect.DeepEqual(r.Blocked, []string{base + "/private/x"}) {
+		t.Errorf("expected /private/x to be blocked, got %q", r.Blocked)
+	}
+	if r.Truncated || r.OK() != 4 || len(hooked) != 5 {
+		t.Errorf("expected 4 of 5 pages to load and the hook to see all, got %d, %q, truncated %v", r.OK(), hooked, r.Truncated)
+	}
+}
+
+func TestCrawlLimits(t *testing.T) {
+	c := newCrawler(t, site, "", Config{MaxDepth: 5, MaxPages: 4, IgnoreRobots: true})
+	r, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Pages) != 4 || !r.Truncated {
+		t.Fatalf("expected 4 pages of a truncated crawl, got %d, truncated %v", len(r.Pages), r.Truncated)
+	}
+	if r.Pages[3].URL != "https://other.example/" {
+		t.Errorf("expected links to other origins to be followed, got %s", r.Pages[3].URL)
+	}
+
+	c = newCrawler(t, site, "User-agent: *\nDisallow: /\n", Config{})
+	if _, err := c.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "disallows") {
+		t.Errorf("expected the start to be disallowed, got %v", err)
+	}
+	c = newCrawler(t, site, "User-agent: *\nDisallow: /\n", Config{IgnoreRobots: true})
+	if r, err := c.Run(context.Background()); err != nil || len(r.Pages) != 1 {
+		t.Errorf("expected --ignore-robots to visit the start, got %v", err)
+	}
+}
+
+func TestCrawlStops(t *testing.T) {
+	stop := errors.New("plugin failed")
+	c := newCrawler(t, site, "", Config{MaxDepth: 5, SameOrigin: true, OnPage: func(p *Page) error {
+		if strings.HasSuffix(p.URL, "/a") {
+			return stop
+		}
+		return nil
+	}})
+	r, err := c.Run(context.Background())
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the hook's error, got %v", err)
+	}
+	if n := len(r.Pages); n < 2 || n > 3 {
+		t.Errorf("expected the crawl to stop in its second level, got %d pages", n)
+	}
+}
+
+func TestCrawlDelay(t *testing.T) {
+	c := newCrawler(t, site, "User-agent: *\nCrawl-delay: 0.05\n", Config{MaxDepth: 1, SameOrigin: true, Concurrency: 3})
+	start := time.Now()
+	r, err := c.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// three loads of one host, 50ms apart
+	if len(r.Pages) != 3 || time.Since(start) < 100*time.Millisecond {
+		t.Errorf("expected 3 pages in at least 100ms, got %d in %v", len(r.Pages), time.Since(start))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	base, _ := url.Parse("https://example.com/docs/intro?x=1")
+	for _, tc := range []struct {
+		ref, want string
+	}{
+		{"guide", "https://example.com/docs/guide"},
+		{"../about#team", "https://example.com/about"},
+		{"HTTPS://Example.COM:443", "https://example.com/"},
+		{"http://example.com:8080/a?b=2", "http://example.com:8080/a?b=2"},
+		{"//cdn.example.com/x.js", "https://cdn.example.com/x.js"},
+		{"?page=2", "https://example.com/docs/intro?page=2"},
+		{"#top", "https://example.com/docs/intro?x=1"},
+		{"mailto:me@example.com", ""},
+		{"javascript:void(0)", ""},
+		{"ftp://example.com/file", ""},
+	} {
+		got, ok := Normalize(base, tc.ref)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("%s: expected %q, got %q", tc.ref, tc.want, got)
+		}
+	}
+	if _, err := New("example.com", Config{Pages: &pool.Pool{}}); err == nil {
+		t.Error("expected a start without a scheme to fail")
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	robots := `# comment
+User-agent: googlebot
+Disallow: /
+
+User-agent: Phantom-Vite
+User-agent: other
+Disallow: /private
+Allow: /private/open
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: *
+Disallow: /everything
+`
+	r, err := ParseRobots(strings.NewReader(robots), "phantom-vite/1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]bool{
+		"/":                   true,
+		"/everything":         true,
+		"/private":            false,
+		"/private/x":          false,
+		"/private/open/a":     true,
+		"/files/report.pdf":   false,
+		"/files/report.pdf?x": true,
+	} {
+		if got := r.Allowed(path); got != want {
+			t.Errorf("%s: expected allowed %v, got %v", path, want, got)
+		}
+	}
+	if r.Delay != 2*time.Second {
+		t.Errorf("expected a 2s delay, got %v", r.Delay)
+	}
+
+	r, _ = ParseRobots(strings.NewReader(robots), "curl/8.0")
+	if r.Allowed("/everything") || !r.Allowed("/private") {
+		t.Error("expected the * group for other agents")
+	}
+}
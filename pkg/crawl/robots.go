@@ -0,0 +1,152 @@
+package crawl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRobotsBytes is how much of a robots.txt file is read
+const maxRobotsBytes = 500 << 10
+
+// Robots are the rules of a robots.txt file for one user agent
+type Robots struct {
+	rules []robotsRule
+	Delay time.Duration // the Crawl-delay of the group, if any
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int // of the pattern, to find the longest match
+	pattern *regexp.Regexp
+}
+
+// robotsGroup is a User-agent group of a robots.txt file
+type robotsGroup struct {
+	agents []string
+	rules  []robotsRule
+	delay  time.Duration
+}
+
+// ParseRobots reads the robots.txt rules that apply to userAgent: those of
+// the group naming the longest part of its product token, or of the "*"
+// group when none does. Unknown lines are ignored.
+func ParseRobots(r io.Reader, userAgent string) (*Robots, error) {
+	var groups []*robotsGroup
+	var current *robotsGroup
+	inAgents := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inAgents = false
+			if current != nil && value != "" {
+				current.rules = append(current.rules, robotsRule{allow: key == "allow", length: len(value), pattern: compileRobots(value)})
+			}
+		case "crawl-delay":
+			inAgents = false
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && current != nil && seconds > 0 {
+				current.delay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	product, _, _ := strings.Cut(strings.ToLower(userAgent), "/")
+	var best *robotsGroup
+	bestLen := -1
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			n := -1
+			switch {
+			case agent == "*":
+				n = 0
+			case agent != "" && strings.Contains(product, agent):
+				n = len(agent)
+			}
+			if n > bestLen {
+				best, bestLen = g, n
+			}
+		}
+	}
+	if best == nil {
+		return &Robots{}, nil
+	}
+	return &Robots{rules: best.rules, Delay: best.delay}, nil
+}
+
+// Allowed reports whether the path and query of a URL may be crawled. The
+// longest matching rule wins, and Allow wins a tie.
+func (r *Robots) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > longest || rule.length == longest && rule.allow {
+			allowed, longest = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
+
+// compileRobots turns a robots.txt pattern, a path prefix in which *
+// matches any characters and a final $ anchors the end, into a regexp
+func compileRobots(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	parts := strings.Split(strings.TrimSuffix(pattern, "$"), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// fetchRobots loads the robots.txt of origin. A missing file, or any
+// other client error, allows everything.
+func fetchRobots(ctx context.Context, client *http.Client, origin, userAgent string) (*Robots, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &Robots{}, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("robots.txt: %s", resp.Status)
+	}
+	return ParseRobots(io.LimitReader(resp.Body, maxRobotsBytes), userAgent)
+}
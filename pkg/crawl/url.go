@@ -0,0 +1,58 @@
+package crawl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// defaultPorts are dropped from normalized URLs
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// Normalize resolves ref against base and returns the canonical form used
+// to dedupe URLs: lowercase scheme and host, no default port, no fragment
+// and "/" for an empty path. ok is false for references that are not
+// http or https URLs, such as mailto: and javascript: links.
+func Normalize(base *url.URL, ref string) (normalized string, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if _, ok := defaultPorts[u.Scheme]; !ok || u.Host == "" {
+		return "", false
+	}
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" && port != defaultPorts[u.Scheme] {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment, u.RawFragment = "", ""
+	if u.Path == "" {
+		u.Path, u.RawPath = "/", ""
+	}
+	if u.RawQuery == "" {
+		u.ForceQuery = false
+	}
+	return u.String(), true
+}
+
+// parseStart normalizes the URL a crawl starts at
+func parseStart(raw string) (*url.URL, error) {
+	normalized, ok := Normalize(nil, raw)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an http or https URL", raw)
+	}
+	return url.Parse(normalized)
+}
+
+// origin is the scheme and host of u, e.g. "https://example.com:8443"
+func origin(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,220 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// message is a single Chrome DevTools Protocol frame. Commands carry an ID,
+// responses echo it back, and events carry only a method and params.
+type message struct {
+	ID        int64           `json:"id,omitempty"`
+	SessionID string          `json:"sessionId,omitempty"`
+	Method    string          `json:"method,omitempty"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     *protocolError  `json:"error,omitempty"`
+}
+
+// protocolError is an error returned by the browser for a command
+type protocolError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+func (e *protocolError) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("%s (%d): %s", e.Message, e.Code, e.Data)
+	}
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+var errConnClosed = errors.New("cdp: connection closed")
+
+// subscription receives events of some methods on one session, in the
+// order the browser sent them
+type subscription struct {
+	sessionID string
+	methods   []string
+	events    chan *message
+}
+
+// conn multiplexes commands and events for a browser and all of its
+// flattened target sessions over a single websocket.
+type conn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan *message
+	subs    map[*subscription]struct{}
+	closed  chan struct{}
+	err     error
+}
+
+func dial(ctx context.Context, url string) (*conn, error) {
+	dialer := websocket.Dialer{ReadBufferSize: 1 << 16, WriteBufferSize: 1 << 16}
+	ws, _, err := dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Screenshots and page content easily exceed the default frame limit.
+	ws.SetReadLimit(256 << 20)
+
+	c := &conn{
+		ws:      ws,
+		pending: make(map[int64]chan *message),
+		subs:    make(map[*subscription]struct{}),
+		closed:  make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *conn) readLoop() {
+	for {
+		var msg message
+		if err := c.ws.ReadJSON(&msg); err != nil {
+			c.shutdown(err)
+			return
+		}
+
+		c.mu.Lock()
+		if msg.ID != 0 {
+			if ch, ok := c.pending[msg.ID]; ok {
+				delete(c.pending, msg.ID)
+				ch <- &msg
+			}
+		} else if msg.Method != "" {
+			for sub := range c.subs {
+				if sub.sessionID != msg.SessionID || !slices.Contains(sub.methods, msg.Method) {
+					continue
+				}
+				select {
+				case sub.events <- &msg:
+				default:
+					// Slow subscribers miss events rather than stalling the connection.
+				}
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *conn) shutdown(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return
+	default:
+	}
+	c.err = err
+	close(c.closed)
+	for id, ch := range c.pending {
+		delete(c.pending, id)
+		close(ch)
+	}
+}
+
+// call sends a command and decodes its result into result, which may be nil
+func (c *conn) call(ctx context.Context, sessionID, method string, params, result interface{}) error {
+	var raw json.RawMessage
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		raw = data
+	}
+
+	ch := make(chan *message, 1)
+	c.mu.Lock()
+	select {
+	case <-c.closed:
+		c.mu.Unlock()
+		return errConnClosed
+	default:
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	c.writeMu.Lock()
+	err := c.ws.WriteJSON(&message{ID: id, SessionID: sessionID, Method: method, Params: raw})
+	c.writeMu.Unlock()
+	if err != nil {
+		c.forget(id)
+		return err
+	}
+
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			return errConnClosed
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result != nil && len(msg.Result) > 0 {
+			return json.Unmarshal(msg.Result, result)
+		}
+		return nil
+	case <-ctx.Done():
+		c.forget(id)
+		return ctx.Err()
+	}
+}
+
+func (c *conn) forget(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// subscribe starts buffering events of methods on sessionID. Callers must
+// subscribe before issuing the command that triggers the event.
+func (c *conn) subscribe(sessionID string, methods ...string) *subscription {
+	sub := &subscription{sessionID: sessionID, methods: methods, events: make(chan *message, 16*len(methods))}
+	c.mu.Lock()
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+	return sub
+}
+
+func (c *conn) unsubscribe(sub *subscription) {
+	c.mu.Lock()
+	delete(c.subs, sub)
+	c.mu.Unlock()
+}
+
+// wait blocks until the subscription receives an event or ctx is done
+func (c *conn) wait(ctx context.Context, sub *subscription) (json.RawMessage, error) {
+	select {
+	case msg := <-sub.events:
+		return msg.Params, nil
+	case <-c.closed:
+		return nil, errConnClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *conn) close() error {
+	c.writeMu.Lock()
+	c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+	err := c.ws.Close()
+	c.shutdown(errConnClosed)
+	return err
+}
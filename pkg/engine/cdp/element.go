@@ -0,0 +1,168 @@
+package cdp
+
+import (
+	"encoding/json"
+
+	"phantomvite/pkg/engine"
+)
+
+// Element is a handle to a DOM node held as a Runtime remote object
+type Element struct {
+	page     *Page
+	objectID string
+}
+
+// callFunction invokes declaration with the element bound to `this` and
+// returns its result by value
+func (el *Element) callFunction(operation, declaration string, args ...interface{}) (*remoteObject, error) {
+	return el.callFunctionOn(operation, declaration, true, args...)
+}
+
+func (el *Element) callFunctionOn(operation, declaration string, byValue bool, args ...interface{}) (*remoteObject, error) {
+	callArgs := make([]map[string]interface{}, len(args))
+	for i, arg := range args {
+		callArgs[i] = map[string]interface{}{"value": arg}
+	}
+
+	var res evaluateResult
+	params := map[string]interface{}{
+		"functionDeclaration": declaration,
+		"objectId":            el.objectID,
+		"arguments":           callArgs,
+		"returnByValue":       byValue,
+		"awaitPromise":        true,
+		"userGesture":         true,
+	}
+	if err := el.page.call("Runtime.callFunctionOn", params, &res); err != nil {
+		return nil, el.page.fail(operation, "element call failed", err)
+	}
+	if res.ExceptionDetails != nil {
+		return nil, el.page.fail(operation, "script threw an exception", res.ExceptionDetails)
+	}
+	return &res.Result, nil
+}
+
+// Click scrolls the element into view and dispatches a left mouse click at
+// its center, the way a user would.
+func (el *Element) Click() error {
+	box, err := el.scrollIntoView("click")
+	if err != nil {
+		return err
+	}
+	x, y := box.X+box.Width/2, box.Y+box.Height/2
+
+	for _, kind := range []string{"mouseMoved", "mousePressed", "mouseReleased"} {
+		params := map[string]interface{}{"type": kind, "x": x, "y": y}
+		if kind != "mouseMoved" {
+			params["button"] = "left"
+			params["clickCount"] = 1
+		}
+		if err := el.page.call("Input.dispatchMouseEvent", params, nil); err != nil {
+			return el.page.fail("click", "failed to dispatch mouse event", err)
+		}
+	}
+	return nil
+}
+
+func (el *Element) scrollIntoView(operation string) (*engine.BoundingBox, error) {
+	obj, err := el.callFunction(operation, `function() {
+		this.scrollIntoView({ block: 'center', inline: 'center' });
+		const r = this.getBoundingClientRect();
+		return { x: r.x, y: r.y, width: r.width, height: r.height };
+	}`)
+	if err != nil {
+		return nil, err
+	}
+	var box engine.BoundingBox
+	if err := json.Unmarshal(obj.Value, &box); err != nil {
+		return nil, el.page.fail(operation, "failed to read element position", err)
+	}
+	if box.Width == 0 && box.Height == 0 {
+		return nil, el.page.fail(operation, "element is not visible", nil)
+	}
+	return &box, nil
+}
+
+// Type focuses the element and sends one key event per character
+func (el *Element) Type(text string) error {
+	if _, err := el.callFunction("type", `function() { this.focus(); }`); err != nil {
+		return err
+	}
+	for _, r := range text {
+		for _, kind := range []string{"keyDown", "keyUp"} {
+			params := map[string]interface{}{"type": kind}
+			if kind == "keyDown" {
+				params["text"] = string(r)
+			}
+			if err := el.page.call("Input.dispatchKeyEvent", params, nil); err != nil {
+				return el.page.fail("type", "failed to dispatch key event", err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetAttribute returns the attribute value, or "" when it is not set
+func (el *Element) GetAttribute(name string) (string, error) {
+	obj, err := el.callFunction("get attribute", `function(name) { return this.getAttribute(name); }`, name)
+	if err != nil {
+		return "", err
+	}
+	var value *string
+	if len(obj.Value) > 0 {
+		if err := json.Unmarshal(obj.Value, &value); err != nil {
+			return "", el.page.fail("get attribute", "unexpected attribute value", err)
+		}
+	}
+	if value == nil {
+		return "", nil
+	}
+	return *value, nil
+}
+
+func (el *Element) GetProperty(name string) (interface{}, error) {
+	obj, err := el.callFunction("get property", `function(name) { return this[name]; }`, name)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(obj), nil
+}
+
+func (el *Element) IsVisible() (bool, error) {
+	obj, err := el.callFunction("is visible", `function() {
+		const style = window.getComputedStyle(this);
+		const r = this.getBoundingClientRect();
+		return style.visibility !== 'hidden' && style.display !== 'none' && r.width > 0 && r.height > 0;
+	}`)
+	if err != nil {
+		return false, err
+	}
+	var visible bool
+	json.Unmarshal(obj.Value, &visible)
+	return visible, nil
+}
+
+// QuerySelectorAll returns the descendants of the element matching selector
+func (el *Element) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	obj, err := el.callFunctionOn("query selector all", `function(selector) { return Array.from(this.querySelectorAll(selector)); }`, false, selector)
+	if err != nil {
+		return nil, err
+	}
+	return el.page.elements("query selector all", obj)
+}
+
+// BoundingBox returns the element's position relative to the viewport
+func (el *Element) BoundingBox() (*engine.BoundingBox, error) {
+	obj, err := el.callFunction("bounding box", `function() {
+		const r = this.getBoundingClientRect();
+		return { x: r.x, y: r.y, width: r.width, height: r.height };
+	}`)
+	if err != nil {
+		return nil, err
+	}
+	var box engine.BoundingBox
+	if err := json.Unmarshal(obj.Value, &box); err != nil {
+		return nil, el.page.fail("bounding box", "failed to read element position", err)
+	}
+	return &box, nil
+}
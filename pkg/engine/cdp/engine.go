@@ -0,0 +1,234 @@
+// Package cdp implements engine.Engine by speaking the Chrome DevTools
+// Protocol directly over a websocket, so pages can be driven with nothing
+// more than a Chrome or Chromium binary.
+package cdp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+const engineName = "cdp"
+
+func init() {
+	engine.Register(engine.Driver{
+		Name:        engineName,
+		Description: "Go, native Chrome DevTools Protocol (no Node.js required)",
+		New:         func() engine.Engine { return New() },
+		Probe: func() engine.EngineStatus {
+			path, err := FindBrowser("")
+			if err != nil {
+				return engine.EngineStatus{Error: "Chrome not found. Install Chrome/Chromium or set CHROME_PATH"}
+			}
+			return engine.EngineStatus{Available: true, Path: path}
+		},
+	})
+}
+
+// Engine drives a Chrome instance over the DevTools protocol
+type Engine struct {
+	remoteURL string
+	config    engine.Config
+	browser   *browserProcess
+	conn      *conn
+
+	mu    sync.Mutex
+	pages []*Page
+}
+
+// New returns an engine that launches its own browser on Initialize
+func New() *Engine {
+	return &Engine{}
+}
+
+// NewRemote returns an engine that attaches to an already running browser
+// through its DevTools websocket URL instead of launching one.
+func NewRemote(wsURL string) *Engine {
+	return &Engine{remoteURL: wsURL}
+}
+
+func (e *Engine) Name() string { return engineName }
+
+func (e *Engine) Initialize(config engine.Config) error {
+	if config.Timeout <= 0 {
+		config.Timeout = engine.DefaultConfig().Timeout
+	}
+	e.config = config
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	url := e.remoteURL
+	if url == "" {
+		browser, err := launchBrowser(ctx, config)
+		if err != nil {
+			return engine.NewEngineError(engineName, "initialize", "failed to launch browser", err)
+		}
+		e.browser = browser
+		url = browser.wsURL
+	}
+
+	c, err := dial(ctx, url)
+	if err != nil {
+		if e.browser != nil {
+			e.browser.kill()
+			e.browser = nil
+		}
+		return engine.NewEngineError(engineName, "initialize", "failed to connect to browser", err)
+	}
+	e.conn = c
+	return nil
+}
+
+func (e *Engine) Close() error {
+	if e.conn == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	pages := e.pages
+	e.pages = nil
+	e.mu.Unlock()
+	for _, page := range pages {
+		page.Close()
+	}
+
+	if e.browser != nil {
+		ctx, cancel := e.timeout()
+		e.conn.call(ctx, "", "Browser.close", nil, nil)
+		cancel()
+	}
+	err := e.conn.close()
+	if e.browser != nil {
+		e.browser.kill()
+		e.browser = nil
+	}
+	e.conn = nil
+	return err
+}
+
+func (e *Engine) timeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), e.config.Timeout)
+}
+
+func (e *Engine) Navigate(ctx context.Context, url string) (engine.Page, error) {
+	page, err := e.newPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := page.Navigate(url, nil); err != nil {
+		page.Close()
+		return nil, err
+	}
+	return page, nil
+}
+
+func (e *Engine) NewPage(ctx context.Context) (engine.Page, error) {
+	return e.newPage(ctx)
+}
+
+func (e *Engine) newPage(ctx context.Context) (*Page, error) {
+	if e.conn == nil {
+		return nil, engine.NewEngineError(engineName, "new page", "engine is not initialized", nil)
+	}
+
+	var created struct {
+		TargetID string `json:"targetId"`
+	}
+	if err := e.conn.call(ctx, "", "Target.createTarget", map[string]interface{}{"url": "about:blank"}, &created); err != nil {
+		return nil, engine.NewEngineError(engineName, "new page", "failed to create target", err)
+	}
+
+	var attached struct {
+		SessionID string `json:"sessionId"`
+	}
+	params := map[string]interface{}{"targetId": created.TargetID, "flatten": true}
+	if err := e.conn.call(ctx, "", "Target.attachToTarget", params, &attached); err != nil {
+		return nil, engine.NewEngineError(engineName, "new page", "failed to attach to target", err)
+	}
+
+	page := &Page{
+		engine:    e,
+		targetID:  created.TargetID,
+		sessionID: attached.SessionID,
+		done:      make(chan struct{}),
+	}
+	if err := page.setup(ctx); err != nil {
+		page.Close()
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.pages = append(e.pages, page)
+	e.mu.Unlock()
+	return page, nil
+}
+
+func (e *Engine) removePage(page *Page) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, p := range e.pages {
+		if p == page {
+			e.pages = append(e.pages[:i], e.pages[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Engine) GetPages(ctx context.Context) ([]engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pages := make([]engine.Page, len(e.pages))
+	for i, p := range e.pages {
+		pages[i] = p
+	}
+	return pages, nil
+}
+
+// Screenshot captures the most recently opened page
+func (e *Engine) Screenshot(ctx context.Context, options engine.ScreenshotOptions) error {
+	e.mu.Lock()
+	var page *Page
+	if len(e.pages) > 0 {
+		page = e.pages[len(e.pages)-1]
+	}
+	e.mu.Unlock()
+
+	if page == nil {
+		return engine.NewEngineError(engineName, "screenshot", "no page has been opened", nil)
+	}
+	return page.Screenshot(options)
+}
+
+func (e *Engine) SetUserAgent(userAgent string) error {
+	e.config.UserAgent = userAgent
+	return e.eachPage(func(p *Page) error { return p.setUserAgent(userAgent) })
+}
+
+func (e *Engine) SetExtraHeaders(headers map[string]string) error {
+	e.config.ExtraHeaders = headers
+	return e.eachPage(func(p *Page) error { return p.setExtraHeaders(headers) })
+}
+
+func (e *Engine) SetViewport(viewport engine.ViewportConfig) error {
+	e.config.Viewport = viewport
+	return e.eachPage(func(p *Page) error { return p.SetViewport(viewport) })
+}
+
+func (e *Engine) eachPage(fn func(*Page) error) error {
+	e.mu.Lock()
+	pages := append([]*Page(nil), e.pages...)
+	e.mu.Unlock()
+	for _, p := range pages {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultPolling is the interval used by selector and function waits
+const defaultPolling = 100 * time.Millisecond
@@ -0,0 +1,480 @@
+package cdp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+func startEngine(t *testing.T, f *fakeBrowser) *Engine {
+	t.Helper()
+	cfg := engine.DefaultConfig()
+	cfg.Timeout = 2 * time.Second
+	cfg.Viewport = engine.ViewportConfig{Width: 800, Height: 600}
+
+	e := NewRemote(f.url())
+	if err := e.Initialize(cfg); err != nil {
+		t.Fatalf("failed to initialize engine: %v", err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestNavigateAndTitle(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.evaluate(func(expr string) interface{} {
+		if expr == "document.title" {
+			return map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": "Example Domain"}}
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"type": "undefined"}}
+	})
+	e := startEngine(t, f)
+
+	page, err := e.Navigate(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+
+	title, err := page.Title()
+	if err != nil {
+		t.Fatalf("title failed: %v", err)
+	}
+	if title != "Example Domain" {
+		t.Errorf("expected title 'Example Domain', got %q", title)
+	}
+
+	navs := f.called("Page.navigate")
+	if len(navs) != 1 || navs[0].SessionID != "session-1" {
+		t.Fatalf("expected one navigate on session-1, got %+v", navs)
+	}
+	if !strings.Contains(string(navs[0].Params), "https://example.com") {
+		t.Errorf("navigate params missing URL: %s", navs[0].Params)
+	}
+
+	metrics := f.called("Emulation.setDeviceMetricsOverride")
+	if len(metrics) != 1 || !strings.Contains(string(metrics[0].Params), `"width":800`) {
+		t.Errorf("expected viewport to be applied to the new page, got %+v", metrics)
+	}
+}
+
+func TestNavigateErrorText(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"frameId": "frame-1", "errorText": "net::ERR_NAME_NOT_RESOLVED"}, nil
+	})
+	e := startEngine(t, f)
+
+	_, err := e.Navigate(context.Background(), "https://nope.invalid")
+	var engineErr *engine.EngineError
+	if !errors.As(err, &engineErr) {
+		t.Fatalf("expected *EngineError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ERR_NAME_NOT_RESOLVED") {
+		t.Errorf("expected browser error text in %q", err.Error())
+	}
+	if got := len(f.called("Target.closeTarget")); got != 1 {
+		t.Errorf("expected the failed page to be closed, got %d closeTarget calls", got)
+	}
+}
+
+func TestNavigateTimesOutWithoutLoadEvent(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"frameId": "frame-1", "loaderId": "loader-1"}, nil
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	err = page.Navigate("https://slow.example", &engine.NavigationOptions{Timeout: 50 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWaitForSelectorSharesNavigationTimeout(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+		time.Sleep(400 * time.Millisecond)
+		return map[string]string{"frameId": "frame-1"}, nil
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	start := time.Now()
+	err = page.Navigate("https://example.com", &engine.NavigationOptions{Timeout: 600 * time.Millisecond, WaitForSelector: "#never"})
+	if engine.KindOf(err) != engine.KindNavigationTimeout {
+		t.Fatalf("expected a navigation timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("expected the selector wait to use what was left of 600ms, took %v", elapsed)
+	}
+}
+
+func TestNetworkIdleWaitsForMainFrameAndLoader(t *testing.T) {
+	lifecycle := func(frame, loader, name string) fakeEvent {
+		return fakeEvent{Method: "Page.lifecycleEvent", Params: map[string]string{"frameId": frame, "loaderId": loader, "name": name}}
+	}
+	// An iframe going idle, then the previous document of the main frame
+	early := []fakeEvent{
+		lifecycle("iframe-1", "loader-9", "networkIdle"),
+		lifecycle("target-1", "loader-1", "networkIdle"),
+	}
+	for _, tc := range []struct {
+		name   string
+		events []fakeEvent
+		idle   bool
+	}{
+		{"others only", early, false},
+		{"then the new document", append(early, lifecycle("target-1", "loader-2", "networkIdle")), true},
+	} {
+		f := newFakeBrowser(t)
+		f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+			return map[string]string{"frameId": "target-1", "loaderId": "loader-2"}, tc.events
+		})
+		f.handle("Page.reload", func(json.RawMessage) (interface{}, []fakeEvent) {
+			return struct{}{}, append([]fakeEvent{lifecycle("target-1", "loader-2", "init")}, tc.events...)
+		})
+		e := startEngine(t, f)
+		page, err := e.NewPage(context.Background())
+		if err != nil {
+			t.Fatalf("new page failed: %v", err)
+		}
+
+		opts := &engine.NavigationOptions{WaitUntil: "networkidle0", Timeout: 100 * time.Millisecond}
+		for op, err := range map[string]error{
+			"navigate": page.Navigate("https://example.com", opts),
+			"reload":   page.Reload(opts),
+		} {
+			if tc.idle && err != nil {
+				t.Errorf("%s: %s: expected the new document's idle event to end the wait, got %v", tc.name, op, err)
+			}
+			if !tc.idle && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("%s: %s: expected a timeout, got %v", tc.name, op, err)
+			}
+		}
+	}
+}
+
+func TestNavigateRejectsUnknownWaitUntil(t *testing.T) {
+	f := newFakeBrowser(t)
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+	err = page.Navigate("https://example.com", &engine.NavigationOptions{WaitUntil: "networkidle"})
+	if engine.KindOf(err) != engine.KindConfig {
+		t.Errorf("expected a config error, got %v", err)
+	}
+	if navs := f.called("Page.navigate"); len(navs) != 0 {
+		t.Errorf("expected no navigation, got %d", len(navs))
+	}
+}
+
+func TestExecuteScriptException(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.evaluate(func(string) interface{} {
+		return map[string]interface{}{
+			"result": map[string]interface{}{"type": "object"},
+			"exceptionDetails": map[string]interface{}{
+				"text":      "Uncaught",
+				"exception": map[string]interface{}{"type": "object", "description": "ReferenceError: nope is not defined"},
+			},
+		}
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	_, err = page.ExecuteScript("nope()")
+	if err == nil || !strings.Contains(err.Error(), "ReferenceError") {
+		t.Fatalf("expected ReferenceError, got %v", err)
+	}
+}
+
+func TestClickDispatchesMouseEvents(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.evaluate(func(string) interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"type": "object", "subtype": "node", "objectId": "node-1"}}
+	})
+	f.handle("Runtime.callFunctionOn", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]interface{}{"result": map[string]interface{}{
+			"type":  "object",
+			"value": map[string]float64{"x": 10, "y": 20, "width": 100, "height": 40},
+		}}, nil
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+	if err := page.Click("#submit"); err != nil {
+		t.Fatalf("click failed: %v", err)
+	}
+
+	events := f.called("Input.dispatchMouseEvent")
+	if len(events) != 3 {
+		t.Fatalf("expected 3 mouse events, got %d", len(events))
+	}
+	var pressed struct {
+		Type string  `json:"type"`
+		X    float64 `json:"x"`
+		Y    float64 `json:"y"`
+	}
+	json.Unmarshal(events[1].Params, &pressed)
+	if pressed.Type != "mousePressed" || pressed.X != 60 || pressed.Y != 40 {
+		t.Errorf("expected mousePressed at (60,40), got %+v", pressed)
+	}
+}
+
+func TestQuerySelectorNoMatch(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.evaluate(func(string) interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"type": "object", "subtype": "null"}}
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	el, err := page.QuerySelector(".missing")
+	if err != nil || el != nil {
+		t.Fatalf("expected no element and no error, got %v, %v", el, err)
+	}
+	if err := page.Click(".missing"); err == nil {
+		t.Errorf("expected click on missing element to fail")
+	}
+}
+
+func TestScreenshotWritesFile(t *testing.T) {
+	f := newFakeBrowser(t)
+	png := []byte("\x89PNG fake")
+	f.handle("Page.captureScreenshot", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"data": base64.StdEncoding.EncodeToString(png)}, nil
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "shot.jpg")
+	if err := page.Screenshot(engine.ScreenshotOptions{Path: path, Quality: 70}); err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(png) {
+		t.Fatalf("expected screenshot bytes to be written, got %q, %v", data, err)
+	}
+
+	calls := f.called("Page.captureScreenshot")
+	if len(calls) != 1 || !strings.Contains(string(calls[0].Params), `"format":"jpeg"`) {
+		t.Errorf("expected jpeg format inferred from path, got %+v", calls)
+	}
+}
+
+func TestPDFWritesFile(t *testing.T) {
+	f := newFakeBrowser(t)
+	pdf := []byte("%PDF-1.4 fake")
+	f.handle("Page.printToPDF", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"data": base64.StdEncoding.EncodeToString(pdf)}, nil
+	})
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "page.pdf")
+	opts := engine.PDFOptions{Path: path, Format: "A4", Landscape: true, Margin: &engine.PDFMargin{Top: 1}, FooterTemplate: "<p>f</p>"}
+	if err := page.PDF(opts); err != nil {
+		t.Fatalf("pdf failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(pdf) {
+		t.Fatalf("expected PDF bytes to be written, got %q, %v", data, err)
+	}
+
+	calls := f.called("Page.printToPDF")
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 printToPDF call, got %d", len(calls))
+	}
+	for _, want := range []string{`"paperWidth":8.27`, `"landscape":true`, `"marginTop":1`, `"displayHeaderFooter":true`, `"headerTemplate":"\u003cspan\u003e\u003c/span\u003e"`} {
+		if !strings.Contains(string(calls[0].Params), want) {
+			t.Errorf("expected %s in %s", want, calls[0].Params)
+		}
+	}
+}
+
+func TestCloseClosesTargets(t *testing.T) {
+	f := newFakeBrowser(t)
+	e := startEngine(t, f)
+
+	if _, err := e.NewPage(context.Background()); err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if got := len(f.called("Target.closeTarget")); got != 1 {
+		t.Errorf("expected 1 closeTarget call, got %d", got)
+	}
+}
+
+func TestConcurrentCloseClosesOnce(t *testing.T) {
+	f := newFakeBrowser(t)
+	e := startEngine(t, f)
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			page.Close()
+		}()
+	}
+	e.Close()
+	wg.Wait()
+	if got := len(f.called("Target.closeTarget")); got != 1 {
+		t.Errorf("expected 1 closeTarget call, got %d", got)
+	}
+}
+
+func TestConfigConformance(t *testing.T) {
+	cfg := enginetest.FullConfig()
+
+	args := strings.Join(launchArgs(cfg, "/tmp/profile"), " ")
+	for _, want := range []string{
+		"--window-size=1366,768",
+		"--proxy-server=http://127.0.0.1:3128",
+		"--user-agent=PhantomConformance/1.0",
+		"--phantom-conformance-flag",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("launch args missing %q: %s", want, args)
+		}
+	}
+	if strings.Contains(args, "--headless") {
+		t.Errorf("headless=false must not pass --headless: %s", args)
+	}
+
+	executable := filepath.Join(t.TempDir(), "browser")
+	os.WriteFile(executable, nil, 0755)
+	if path, err := FindBrowser(executable); err != nil || path != executable {
+		t.Errorf("expected configured executable %s, got %q, %v", executable, path, err)
+	}
+
+	f := newFakeBrowser(t)
+	e := NewRemote(f.url())
+	if err := e.Initialize(cfg); err != nil {
+		t.Fatalf("failed to initialize engine: %v", err)
+	}
+	defer e.Close()
+	if _, err := e.NewPage(context.Background()); err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	for method, want := range map[string]string{
+		"Emulation.setDeviceMetricsOverride": `"width":1366`,
+		"Network.setUserAgentOverride":       "PhantomConformance/1.0",
+		"Network.setExtraHTTPHeaders":        "X-Phantom-Conformance",
+	} {
+		calls := f.called(method)
+		if len(calls) != 1 || !strings.Contains(string(calls[0].Params), want) {
+			t.Errorf("expected %s with %s on the new page, got %+v", method, want, calls)
+		}
+	}
+}
+
+func TestEventsReportConsoleAndNetwork(t *testing.T) {
+	f := newFakeBrowser(t)
+	f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"frameId": "frame-1"}, []fakeEvent{
+			{Method: "Network.requestWillBeSent", Params: map[string]interface{}{"requestId": "1", "request": map[string]string{"url": "https://example.com/", "method": "GET"}}},
+			{Method: "Network.responseReceived", Params: map[string]interface{}{"requestId": "1", "response": map[string]interface{}{"url": "https://example.com/", "status": 200}}},
+			{Method: "Network.requestWillBeSent", Params: map[string]interface{}{"requestId": "2", "request": map[string]string{"url": "https://example.com/app.js", "method": "GET"}}},
+			{Method: "Network.loadingFailed", Params: map[string]interface{}{"requestId": "2", "errorText": "net::ERR_BLOCKED_BY_CLIENT"}},
+			{Method: "Runtime.consoleAPICalled", Params: map[string]interface{}{"type": "warning", "timestamp": 1700000000000,
+				"args": []map[string]interface{}{{"type": "string", "value": "slow:"}, {"type": "number", "value": 42}}}},
+			{Method: "Page.loadEventFired", Params: map[string]float64{"timestamp": 1}},
+		}
+	})
+	e := startEngine(t, f)
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	events, err := page.(engine.EventSource).Events(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := page.Navigate("https://example.com/", nil); err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	var got []engine.Event
+	for len(got) < 5 {
+		select {
+		case ev := <-events:
+			ev.Time = time.Time{}
+			got = append(got, ev)
+		case <-time.After(time.Second):
+			t.Fatalf("expected 5 events, got %+v", got)
+		}
+	}
+	want := []engine.Event{
+		{Type: engine.EventRequest, URL: "https://example.com/", Method: "GET"},
+		{Type: engine.EventResponse, URL: "https://example.com/", Method: "GET", Status: 200},
+		{Type: engine.EventRequest, URL: "https://example.com/app.js", Method: "GET"},
+		{Type: engine.EventRequestFailed, URL: "https://example.com/app.js", Method: "GET", Text: "net::ERR_BLOCKED_BY_CLIENT"},
+		{Type: engine.EventConsole, Level: "warning", Text: "slow: 42"},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	page.Close()
+	select {
+	case _, open := <-events:
+		if open {
+			t.Errorf("expected no more events")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the events to end when the page closes")
+	}
+}
@@ -0,0 +1,134 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// Events reports the console messages and network activity of the page,
+// from the Runtime and Network domains enabled in setup
+func (p *Page) Events(ctx context.Context) (<-chan engine.Event, error) {
+	if p.isClosed() {
+		return nil, p.fail("events", "page is closed", nil)
+	}
+	conn := p.engine.conn
+	sub := conn.subscribe(p.sessionID, "Runtime.consoleAPICalled",
+		"Network.requestWillBeSent", "Network.responseReceived", "Network.loadingFailed")
+
+	events := make(chan engine.Event, 16)
+	go func() {
+		defer close(events)
+		defer conn.unsubscribe(sub)
+		// loadingFailed only names the request, so remember what it was
+		requests := make(map[string]engine.Event)
+		for {
+			var msg *message
+			select {
+			case msg = <-sub.events:
+			case <-ctx.Done():
+				return
+			case <-p.done:
+				return
+			case <-conn.closed:
+				return
+			}
+			ev, ok := toEvent(msg.Method, msg.Params, requests)
+			if !ok {
+				continue
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// toEvent converts the params of a DevTools event, keeping track of
+// requests by ID
+func toEvent(method string, params json.RawMessage, requests map[string]engine.Event) (engine.Event, bool) {
+	ev := engine.Event{Time: time.Now()}
+	switch method {
+	case "Runtime.consoleAPICalled":
+		var called struct {
+			Type      string         `json:"type"`
+			Args      []remoteObject `json:"args"`
+			Timestamp float64        `json:"timestamp"` // milliseconds since the epoch
+		}
+		if json.Unmarshal(params, &called) != nil {
+			return ev, false
+		}
+		texts := make([]string, len(called.Args))
+		for i, arg := range called.Args {
+			texts[i] = consoleText(arg)
+		}
+		ev.Type, ev.Level, ev.Text = engine.EventConsole, called.Type, strings.Join(texts, " ")
+		if called.Timestamp > 0 {
+			ev.Time = time.UnixMilli(int64(called.Timestamp))
+		}
+
+	case "Network.requestWillBeSent":
+		var sent struct {
+			RequestID string `json:"requestId"`
+			Request   struct {
+				URL    string `json:"url"`
+				Method string `json:"method"`
+			} `json:"request"`
+		}
+		if json.Unmarshal(params, &sent) != nil {
+			return ev, false
+		}
+		ev.Type, ev.URL, ev.Method = engine.EventRequest, sent.Request.URL, sent.Request.Method
+		requests[sent.RequestID] = ev
+
+	case "Network.responseReceived":
+		var received struct {
+			RequestID string `json:"requestId"`
+			Response  struct {
+				URL    string `json:"url"`
+				Status int    `json:"status"`
+			} `json:"response"`
+		}
+		if json.Unmarshal(params, &received) != nil {
+			return ev, false
+		}
+		ev.Type, ev.URL, ev.Status = engine.EventResponse, received.Response.URL, received.Response.Status
+		ev.Method = requests[received.RequestID].Method
+		delete(requests, received.RequestID)
+
+	case "Network.loadingFailed":
+		var failed struct {
+			RequestID string `json:"requestId"`
+			ErrorText string `json:"errorText"`
+		}
+		if json.Unmarshal(params, &failed) != nil {
+			return ev, false
+		}
+		request := requests[failed.RequestID]
+		ev.Type, ev.URL, ev.Method, ev.Text = engine.EventRequestFailed, request.URL, request.Method, failed.ErrorText
+		delete(requests, failed.RequestID)
+	}
+	return ev, true
+}
+
+// consoleText formats an argument of a console call the way DevTools
+// prints it: strings as they are, other values as JSON or their description
+func consoleText(arg remoteObject) string {
+	var s string
+	if arg.Type == "string" && json.Unmarshal(arg.Value, &s) == nil {
+		return s
+	}
+	if len(arg.Value) > 0 {
+		return string(arg.Value)
+	}
+	if arg.Description != "" {
+		return arg.Description
+	}
+	return arg.Type
+}
@@ -0,0 +1,126 @@
+package cdp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeEvent is an event the fake browser emits after answering a command
+type fakeEvent struct {
+	Method string
+	Params interface{}
+}
+
+// fakeHandler scripts the response to one CDP method
+type fakeHandler func(params json.RawMessage) (result interface{}, events []fakeEvent)
+
+// fakeBrowser is an in-process DevTools websocket server that answers
+// commands from scripted handlers and records every call it receives.
+type fakeBrowser struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	handlers map[string]fakeHandler
+	calls    []message
+}
+
+func newFakeBrowser(t *testing.T) *fakeBrowser {
+	f := &fakeBrowser{t: t, handlers: make(map[string]fakeHandler)}
+
+	f.handle("Target.createTarget", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"targetId": "target-1"}, nil
+	})
+	f.handle("Target.attachToTarget", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"sessionId": "session-1"}, nil
+	})
+	f.handle("Page.navigate", func(json.RawMessage) (interface{}, []fakeEvent) {
+		return map[string]string{"frameId": "frame-1", "loaderId": "loader-1"},
+			[]fakeEvent{{Method: "Page.loadEventFired", Params: map[string]float64{"timestamp": 1}}}
+	})
+
+	upgrader := websocket.Upgrader{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("fake browser upgrade failed: %v", err)
+			return
+		}
+		defer ws.Close()
+		f.serve(ws)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeBrowser) url() string {
+	return "ws" + strings.TrimPrefix(f.server.URL, "http") + "/devtools/browser/fake"
+}
+
+func (f *fakeBrowser) handle(method string, h fakeHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = h
+}
+
+// evaluate scripts Runtime.evaluate to return value for every expression
+func (f *fakeBrowser) evaluate(fn func(expression string) interface{}) {
+	f.handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, []fakeEvent) {
+		var p struct {
+			Expression string `json:"expression"`
+		}
+		json.Unmarshal(params, &p)
+		return fn(p.Expression), nil
+	})
+}
+
+func (f *fakeBrowser) serve(ws *websocket.Conn) {
+	for {
+		var msg message
+		if err := ws.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.calls = append(f.calls, msg)
+		handler := f.handlers[msg.Method]
+		f.mu.Unlock()
+
+		var result interface{} = struct{}{}
+		var events []fakeEvent
+		if handler != nil {
+			result, events = handler(msg.Params)
+		}
+
+		reply := map[string]interface{}{"id": msg.ID, "sessionId": msg.SessionID}
+		if err, ok := result.(*protocolError); ok {
+			reply["error"] = err
+		} else {
+			reply["result"] = result
+		}
+		if err := ws.WriteJSON(reply); err != nil {
+			return
+		}
+		for _, ev := range events {
+			ws.WriteJSON(map[string]interface{}{"method": ev.Method, "params": ev.Params, "sessionId": msg.SessionID})
+		}
+	}
+}
+
+// called returns the recorded calls of method
+func (f *fakeBrowser) called(method string) []message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []message
+	for _, call := range f.calls {
+		if call.Method == method {
+			matched = append(matched, call)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,167 @@
+package cdp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+// candidateBinaries are looked up on PATH when no executable is configured
+var candidateBinaries = []string{
+	"google-chrome",
+	"google-chrome-stable",
+	"chromium",
+	"chromium-browser",
+	"chrome",
+	"msedge",
+}
+
+// wellKnownPaths are install locations that are usually not on PATH
+func wellKnownPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+		}
+	case "windows":
+		return []string{
+			`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
+			`C:\Program Files (x86)\Microsoft\Edge\Application\msedge.exe`,
+		}
+	}
+	return nil
+}
+
+// FindBrowser locates a Chrome or Chromium executable. An explicit path wins,
+// then the CHROME_PATH environment variable, then PATH and well-known
+// install locations.
+func FindBrowser(explicit string) (string, error) {
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", fmt.Errorf("browser executable not found: %s", explicit)
+		}
+		return explicit, nil
+	}
+	if env := os.Getenv("CHROME_PATH"); env != "" {
+		if _, err := os.Stat(env); err == nil {
+			return env, nil
+		}
+	}
+	for _, name := range candidateBinaries {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	for _, path := range wellKnownPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Chrome or Chromium executable found")
+}
+
+// browserProcess is a Chrome instance started with remote debugging enabled
+type browserProcess struct {
+	cmd         *exec.Cmd
+	userDataDir string
+	wsURL       string
+}
+
+func launchArgs(config engine.Config, userDataDir string) []string {
+	args := []string{
+		"--remote-debugging-port=0",
+		"--user-data-dir=" + userDataDir,
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--disable-background-networking",
+		"--disable-popup-blocking",
+		"--disable-dev-shm-usage",
+	}
+	if config.Headless {
+		args = append(args, "--headless=new", "--hide-scrollbars", "--mute-audio")
+	}
+	if config.Viewport.Width > 0 && config.Viewport.Height > 0 {
+		args = append(args, fmt.Sprintf("--window-size=%d,%d", config.Viewport.Width, config.Viewport.Height))
+	}
+	if config.Proxy != "" {
+		args = append(args, "--proxy-server="+config.Proxy)
+	}
+	if config.UserAgent != "" {
+		// Pages also get an override in setup; the flag covers requests made
+		// before a page is attached, such as service workers.
+		args = append(args, "--user-agent="+config.UserAgent)
+	}
+	args = append(args, config.Args...)
+	return append(args, "about:blank")
+}
+
+// launchBrowser starts Chrome and waits for it to announce its DevTools
+// websocket endpoint on stderr.
+func launchBrowser(ctx context.Context, config engine.Config) (*browserProcess, error) {
+	path, err := FindBrowser(config.ExecutablePath)
+	if err != nil {
+		return nil, err
+	}
+
+	userDataDir, err := os.MkdirTemp("", "phantom-cdp-")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(path, launchArgs(config, userDataDir)...)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		os.RemoveAll(userDataDir)
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(userDataDir)
+		return nil, err
+	}
+
+	proc := &browserProcess{cmd: cmd, userDataDir: userDataDir}
+	found := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if i := strings.Index(line, "ws://"); i >= 0 && strings.Contains(line, "DevTools listening on") {
+				found <- strings.TrimSpace(line[i:])
+				break
+			}
+		}
+		close(found)
+		// Keep draining so the browser never blocks on a full pipe.
+		io.Copy(io.Discard, stderr)
+	}()
+
+	select {
+	case url, ok := <-found:
+		if !ok {
+			proc.kill()
+			return nil, fmt.Errorf("browser exited before exposing a DevTools endpoint")
+		}
+		proc.wsURL = url
+		return proc, nil
+	case <-ctx.Done():
+		proc.kill()
+		return nil, fmt.Errorf("timed out waiting for the browser to start: %w", ctx.Err())
+	}
+}
+
+func (p *browserProcess) kill() {
+	if p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+		p.cmd.Wait()
+	}
+	os.RemoveAll(p.userDataDir)
+}
@@ -0,0 +1,809 @@
+package cdp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// Page is a browser tab attached through a flattened DevTools session
+type Page struct {
+	engine    *Engine
+	targetID  string
+	sessionID string
+	closeOnce sync.Once
+	done      chan struct{} // closed by Close
+}
+
+// isClosed reports whether Close has been called
+func (p *Page) isClosed() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Page) call(method string, params, result interface{}) error {
+	ctx, cancel := p.engine.timeout()
+	defer cancel()
+	return p.engine.conn.call(ctx, p.sessionID, method, params, result)
+}
+
+func (p *Page) fail(operation, message string, cause error) error {
+	return engine.NewEngineError(engineName, operation, message, cause)
+}
+
+// setup enables the protocol domains the page relies on and applies the
+// engine configuration to the new tab.
+func (p *Page) setup(ctx context.Context) error {
+	for _, method := range []string{"Page.enable", "Runtime.enable", "Network.enable"} {
+		if err := p.engine.conn.call(ctx, p.sessionID, method, nil, nil); err != nil {
+			return p.fail("new page", "failed to enable "+strings.TrimSuffix(method, ".enable"), err)
+		}
+	}
+	if err := p.engine.conn.call(ctx, p.sessionID, "Page.setLifecycleEventsEnabled", map[string]interface{}{"enabled": true}, nil); err != nil {
+		return p.fail("new page", "failed to enable lifecycle events", err)
+	}
+
+	config := p.engine.config
+	if config.Viewport.Width > 0 && config.Viewport.Height > 0 {
+		if err := p.SetViewport(config.Viewport); err != nil {
+			return err
+		}
+	}
+	if config.UserAgent != "" {
+		if err := p.setUserAgent(config.UserAgent); err != nil {
+			return err
+		}
+	}
+	if len(config.ExtraHeaders) > 0 {
+		if err := p.setExtraHeaders(config.ExtraHeaders); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Page) setUserAgent(userAgent string) error {
+	if err := p.call("Network.setUserAgentOverride", map[string]interface{}{"userAgent": userAgent}, nil); err != nil {
+		return p.fail("set user agent", "failed to override user agent", err)
+	}
+	return nil
+}
+
+func (p *Page) setExtraHeaders(headers map[string]string) error {
+	if err := p.call("Network.setExtraHTTPHeaders", map[string]interface{}{"headers": headers}, nil); err != nil {
+		return p.fail("set extra headers", "failed to set extra HTTP headers", err)
+	}
+	return nil
+}
+
+// remoteObject mirrors Runtime.RemoteObject
+type remoteObject struct {
+	Type        string          `json:"type"`
+	Subtype     string          `json:"subtype,omitempty"`
+	ObjectID    string          `json:"objectId,omitempty"`
+	Value       json.RawMessage `json:"value,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// exceptionDetails mirrors Runtime.ExceptionDetails
+type exceptionDetails struct {
+	Text      string        `json:"text"`
+	Exception *remoteObject `json:"exception,omitempty"`
+}
+
+func (d *exceptionDetails) Error() string {
+	if d.Exception != nil && d.Exception.Description != "" {
+		return d.Exception.Description
+	}
+	return d.Text
+}
+
+type evaluateResult struct {
+	Result           remoteObject      `json:"result"`
+	ExceptionDetails *exceptionDetails `json:"exceptionDetails,omitempty"`
+}
+
+// evaluate runs expression in the page's main world
+func (p *Page) evaluate(operation, expression string, byValue, await bool) (*remoteObject, error) {
+	var res evaluateResult
+	params := map[string]interface{}{
+		"expression":    expression,
+		"returnByValue": byValue,
+		"awaitPromise":  await,
+		"userGesture":   true,
+	}
+	if err := p.call("Runtime.evaluate", params, &res); err != nil {
+		return nil, p.fail(operation, "evaluation failed", err)
+	}
+	if res.ExceptionDetails != nil {
+		return nil, p.fail(operation, "script threw an exception", res.ExceptionDetails)
+	}
+	return &res.Result, nil
+}
+
+// evaluateValue runs expression and decodes its JSON value into out
+func (p *Page) evaluateValue(operation, expression string, out interface{}) error {
+	obj, err := p.evaluate(operation, expression, true, true)
+	if err != nil {
+		return err
+	}
+	if len(obj.Value) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(obj.Value, out); err != nil {
+		return p.fail(operation, "unexpected result type", err)
+	}
+	return nil
+}
+
+func decodeValue(obj *remoteObject) interface{} {
+	if obj == nil || len(obj.Value) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(obj.Value, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+func (p *Page) Title() (string, error) {
+	var title string
+	err := p.evaluateValue("title", "document.title", &title)
+	return title, err
+}
+
+func (p *Page) URL() (string, error) {
+	var url string
+	err := p.evaluateValue("url", "location.href", &url)
+	return url, err
+}
+
+func (p *Page) Content() (string, error) {
+	var html string
+	err := p.evaluateValue("content", `(() => {
+		let html = '';
+		if (document.doctype) html = new XMLSerializer().serializeToString(document.doctype);
+		if (document.documentElement) html += document.documentElement.outerHTML;
+		return html;
+	})()`, &html)
+	return html, err
+}
+
+// lifecycleWaiter waits for the page event that corresponds to a WaitUntil
+// value. It subscribes before the triggering command is sent.
+//
+// Page.lifecycleEvent also fires for iframes and, late, for the previous
+// document, so the network idle events count only for the main frame and
+// the loader of the new document: the one Page.navigate returns, or else
+// the one of the main frame's next init event.
+type lifecycleWaiter struct {
+	page     *Page
+	sub      *subscription
+	event    string
+	frameID  string // the main frame; Chrome gives it the target's ID
+	loaderID string
+}
+
+func (p *Page) expectLifecycle(waitUntil string) *lifecycleWaiter {
+	w := &lifecycleWaiter{page: p, frameID: p.targetID}
+	switch waitUntil {
+	case "domcontentloaded":
+		w.sub = p.engine.conn.subscribe(p.sessionID, "Page.domContentEventFired")
+	case "networkidle0":
+		w.sub = p.engine.conn.subscribe(p.sessionID, "Page.lifecycleEvent")
+		w.event = "networkIdle"
+	case "networkidle2":
+		w.sub = p.engine.conn.subscribe(p.sessionID, "Page.lifecycleEvent")
+		w.event = "networkAlmostIdle"
+	default:
+		w.sub = p.engine.conn.subscribe(p.sessionID, "Page.loadEventFired")
+	}
+	return w
+}
+
+func (w *lifecycleWaiter) wait(ctx context.Context) error {
+	defer w.page.engine.conn.unsubscribe(w.sub)
+	for {
+		params, err := w.page.engine.conn.wait(ctx, w.sub)
+		if err != nil {
+			return err
+		}
+		if w.event == "" {
+			return nil
+		}
+		var ev struct {
+			FrameID  string `json:"frameId"`
+			LoaderID string `json:"loaderId"`
+			Name     string `json:"name"`
+		}
+		if json.Unmarshal(params, &ev) != nil || ev.FrameID != w.frameID {
+			continue
+		}
+		if w.loaderID == "" && ev.Name == "init" {
+			w.loaderID = ev.LoaderID
+		}
+		if ev.Name == w.event && w.loaderID != "" && ev.LoaderID == w.loaderID {
+			return nil
+		}
+	}
+}
+
+func (w *lifecycleWaiter) cancel() {
+	w.page.engine.conn.unsubscribe(w.sub)
+}
+
+func (p *Page) navigationContext(options *engine.NavigationOptions) (context.Context, context.CancelFunc) {
+	timeout := p.engine.config.Timeout
+	if options != nil && options.Timeout > 0 {
+		timeout = options.Timeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func (p *Page) Navigate(url string, options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if options == nil {
+		options = &engine.NavigationOptions{}
+	}
+	ctx, cancel := p.navigationContext(options)
+	defer cancel()
+
+	waiter := p.expectLifecycle(options.WaitUntil)
+	params := map[string]interface{}{"url": url}
+	if options.Referer != "" {
+		params["referrer"] = options.Referer
+	}
+
+	var res struct {
+		FrameID   string `json:"frameId"`
+		LoaderID  string `json:"loaderId,omitempty"`
+		ErrorText string `json:"errorText,omitempty"`
+	}
+	if err := p.engine.conn.call(ctx, p.sessionID, "Page.navigate", params, &res); err != nil {
+		waiter.cancel()
+		return p.fail("navigate", "failed to navigate to "+url, err)
+	}
+	if res.ErrorText != "" {
+		waiter.cancel()
+		return p.fail("navigate", "failed to navigate to "+url, fmt.Errorf("%s", res.ErrorText))
+	}
+	// Same-document navigations (fragment changes) have no loader and fire no load event.
+	if res.LoaderID == "" {
+		waiter.cancel()
+	} else {
+		if res.FrameID != "" {
+			waiter.frameID = res.FrameID
+		}
+		waiter.loaderID = res.LoaderID
+		if err := waiter.wait(ctx); err != nil {
+			return p.fail("navigate", "timed out waiting for "+waitUntilName(options.WaitUntil), err)
+		}
+	}
+
+	return p.waitAfterNavigation(ctx, options)
+}
+
+func waitUntilName(waitUntil string) string {
+	if waitUntil == "" {
+		return "load"
+	}
+	return waitUntil
+}
+
+// waitAfterNavigation waits for options.WaitForSelector in the time left
+// of the navigation's ctx
+func (p *Page) waitAfterNavigation(ctx context.Context, options *engine.NavigationOptions) error {
+	if options.WaitForSelector == "" {
+		return nil
+	}
+	_, err := p.WaitForSelector(options.WaitForSelector, &engine.WaitOptions{Timeout: engine.Remaining(ctx)})
+	return err
+}
+
+func (p *Page) Reload(options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if options == nil {
+		options = &engine.NavigationOptions{}
+	}
+	ctx, cancel := p.navigationContext(options)
+	defer cancel()
+
+	waiter := p.expectLifecycle(options.WaitUntil)
+	if err := p.engine.conn.call(ctx, p.sessionID, "Page.reload", nil, nil); err != nil {
+		waiter.cancel()
+		return p.fail("reload", "failed to reload page", err)
+	}
+	if err := waiter.wait(ctx); err != nil {
+		return p.fail("reload", "timed out waiting for "+waitUntilName(options.WaitUntil), err)
+	}
+	return p.waitAfterNavigation(ctx, options)
+}
+
+func (p *Page) GoBack() error    { return p.navigateHistory("go back", -1) }
+func (p *Page) GoForward() error { return p.navigateHistory("go forward", 1) }
+
+func (p *Page) navigateHistory(operation string, delta int) error {
+	var history struct {
+		CurrentIndex int `json:"currentIndex"`
+		Entries      []struct {
+			ID int `json:"id"`
+		} `json:"entries"`
+	}
+	if err := p.call("Page.getNavigationHistory", nil, &history); err != nil {
+		return p.fail(operation, "failed to read navigation history", err)
+	}
+	index := history.CurrentIndex + delta
+	if index < 0 || index >= len(history.Entries) {
+		return p.fail(operation, "no history entry to navigate to", nil)
+	}
+
+	ctx, cancel := p.navigationContext(nil)
+	defer cancel()
+	waiter := p.expectLifecycle("")
+	params := map[string]interface{}{"entryId": history.Entries[index].ID}
+	if err := p.engine.conn.call(ctx, p.sessionID, "Page.navigateToHistoryEntry", params, nil); err != nil {
+		waiter.cancel()
+		return p.fail(operation, "failed to navigate history", err)
+	}
+	if err := waiter.wait(ctx); err != nil {
+		return p.fail(operation, "timed out waiting for load", err)
+	}
+	return nil
+}
+
+// QuerySelector returns the first matching element, or nil when none matches
+func (p *Page) QuerySelector(selector string) (engine.ElementHandle, error) {
+	expr := fmt.Sprintf("document.querySelector(%s)", jsString(selector))
+	obj, err := p.evaluate("query selector", expr, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Subtype == "null" || obj.ObjectID == "" {
+		return nil, nil
+	}
+	return &Element{page: p, objectID: obj.ObjectID}, nil
+}
+
+func (p *Page) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	expr := fmt.Sprintf("Array.from(document.querySelectorAll(%s))", jsString(selector))
+	obj, err := p.evaluate("query selector all", expr, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.elements("query selector all", obj)
+}
+
+// elements returns handles to the nodes of the array obj, releasing it
+func (p *Page) elements(operation string, obj *remoteObject) ([]engine.ElementHandle, error) {
+	defer p.call("Runtime.releaseObject", map[string]interface{}{"objectId": obj.ObjectID}, nil)
+
+	var props struct {
+		Result []struct {
+			Name  string        `json:"name"`
+			Value *remoteObject `json:"value,omitempty"`
+		} `json:"result"`
+	}
+	params := map[string]interface{}{"objectId": obj.ObjectID, "ownProperties": true}
+	if err := p.call("Runtime.getProperties", params, &props); err != nil {
+		return nil, p.fail(operation, "failed to read matches", err)
+	}
+
+	var elements []engine.ElementHandle
+	for _, prop := range props.Result {
+		if prop.Value == nil || prop.Value.Subtype != "node" || prop.Value.ObjectID == "" {
+			continue
+		}
+		elements = append(elements, &Element{page: p, objectID: prop.Value.ObjectID})
+	}
+	return elements, nil
+}
+
+func (p *Page) WaitForSelector(selector string, options *engine.WaitOptions) (engine.ElementHandle, error) {
+	timeout, polling := p.waitSettings(options)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		el, err := p.QuerySelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case options != nil && options.Hidden:
+			if el == nil {
+				return nil, nil
+			}
+			if visible, err := el.IsVisible(); err == nil && !visible {
+				return el, nil
+			}
+		case el != nil && options != nil && options.Visible:
+			if visible, err := el.IsVisible(); err == nil && visible {
+				return el, nil
+			}
+		case el != nil:
+			return el, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, p.fail("wait for selector", fmt.Sprintf("timed out after %v waiting for %q", timeout, selector), context.DeadlineExceeded)
+		}
+		time.Sleep(polling)
+	}
+}
+
+func (p *Page) waitSettings(options *engine.WaitOptions) (time.Duration, time.Duration) {
+	timeout := p.engine.config.Timeout
+	polling := defaultPolling
+	if options != nil {
+		if options.Timeout > 0 {
+			timeout = options.Timeout
+		}
+		if options.Polling > 0 {
+			polling = options.Polling
+		}
+	}
+	return timeout, polling
+}
+
+func (p *Page) ExecuteScript(script string) (interface{}, error) {
+	obj, err := p.evaluate("execute script", script, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(obj), nil
+}
+
+// ExecuteScriptAsync evaluates script and waits for the promise it returns
+func (p *Page) ExecuteScriptAsync(script string) (interface{}, error) {
+	obj, err := p.evaluate("execute script", script, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(obj), nil
+}
+
+func (p *Page) mustQuery(operation, selector string) (*Element, error) {
+	el, err := p.QuerySelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if el == nil {
+		return nil, p.fail(operation, "no element matches selector "+selector, nil)
+	}
+	return el.(*Element), nil
+}
+
+func (p *Page) Click(selector string) error {
+	el, err := p.mustQuery("click", selector)
+	if err != nil {
+		return err
+	}
+	return el.Click()
+}
+
+func (p *Page) Type(selector string, text string) error {
+	el, err := p.mustQuery("type", selector)
+	if err != nil {
+		return err
+	}
+	return el.Type(text)
+}
+
+func (p *Page) Fill(selector string, text string) error {
+	el, err := p.mustQuery("fill", selector)
+	if err != nil {
+		return err
+	}
+	_, err = el.callFunction("fill", `function(value) {
+		this.focus();
+		this.value = value;
+		this.dispatchEvent(new Event('input', { bubbles: true }));
+		this.dispatchEvent(new Event('change', { bubbles: true }));
+	}`, text)
+	return err
+}
+
+func (p *Page) Select(selector string, values ...string) error {
+	el, err := p.mustQuery("select", selector)
+	if err != nil {
+		return err
+	}
+	_, err = el.callFunction("select", `function(values) {
+		if (this.nodeName.toLowerCase() !== 'select') throw new Error('element is not a <select>');
+		for (const option of this.options) option.selected = values.includes(option.value);
+		this.dispatchEvent(new Event('input', { bubbles: true }));
+		this.dispatchEvent(new Event('change', { bubbles: true }));
+	}`, values)
+	return err
+}
+
+func (p *Page) Screenshot(options engine.ScreenshotOptions) error {
+	format := options.ResolvedFormat()
+	params := map[string]interface{}{"format": format}
+	if format != "png" && options.Quality > 0 {
+		params["quality"] = options.Quality
+	}
+
+	clip := options.Clip
+	if options.FullPage && clip == nil {
+		var metrics struct {
+			ContentSize struct {
+				Width  float64 `json:"width"`
+				Height float64 `json:"height"`
+			} `json:"cssContentSize"`
+		}
+		if err := p.call("Page.getLayoutMetrics", nil, &metrics); err != nil {
+			return p.fail("screenshot", "failed to measure page", err)
+		}
+		clip = &engine.ClipOptions{Width: metrics.ContentSize.Width, Height: metrics.ContentSize.Height}
+		params["captureBeyondViewport"] = true
+	}
+	if clip != nil {
+		params["clip"] = map[string]interface{}{
+			"x": clip.X, "y": clip.Y, "width": clip.Width, "height": clip.Height, "scale": 1,
+		}
+	}
+
+	if options.OmitBackground {
+		transparent := map[string]interface{}{"color": map[string]int{"r": 0, "g": 0, "b": 0, "a": 0}}
+		if err := p.call("Emulation.setDefaultBackgroundColorOverride", transparent, nil); err != nil {
+			return p.fail("screenshot", "failed to clear background", err)
+		}
+		defer p.call("Emulation.setDefaultBackgroundColorOverride", nil, nil)
+	}
+
+	var res struct {
+		Data string `json:"data"`
+	}
+	if err := p.call("Page.captureScreenshot", params, &res); err != nil {
+		return p.fail("screenshot", "failed to capture screenshot", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(res.Data)
+	if err != nil {
+		return p.fail("screenshot", "invalid screenshot data", err)
+	}
+	if err := os.WriteFile(options.Path, data, 0644); err != nil {
+		return p.fail("screenshot", "failed to write "+options.Path, err)
+	}
+	return nil
+}
+
+func (p *Page) PDF(options engine.PDFOptions) error {
+	paper, err := options.Paper()
+	if err != nil {
+		return p.fail("pdf", "invalid paper size", err)
+	}
+	display, header, footer := options.HeaderFooter()
+	params := map[string]interface{}{
+		"paperWidth":          paper.Width,
+		"paperHeight":         paper.Height,
+		"landscape":           options.Landscape,
+		"printBackground":     options.PrintBackground,
+		"pageRanges":          options.PageRanges,
+		"displayHeaderFooter": display,
+		"headerTemplate":      header,
+		"footerTemplate":      footer,
+	}
+	if options.Scale > 0 {
+		params["scale"] = options.Scale
+	}
+	if m := options.Margin; m != nil {
+		params["marginTop"], params["marginRight"] = m.Top, m.Right
+		params["marginBottom"], params["marginLeft"] = m.Bottom, m.Left
+	}
+
+	var res struct {
+		Data string `json:"data"`
+	}
+	if err := p.call("Page.printToPDF", params, &res); err != nil {
+		return p.fail("pdf", "failed to print page", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(res.Data)
+	if err != nil {
+		return p.fail("pdf", "invalid PDF data", err)
+	}
+	if err := os.WriteFile(options.Path, data, 0644); err != nil {
+		return p.fail("pdf", "failed to write "+options.Path, err)
+	}
+	return nil
+}
+
+func (p *Page) WaitForNavigation(options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if options == nil {
+		options = &engine.NavigationOptions{}
+	}
+	ctx, cancel := p.navigationContext(options)
+	defer cancel()
+
+	if err := p.expectLifecycle(options.WaitUntil).wait(ctx); err != nil {
+		return p.fail("wait for navigation", "timed out waiting for "+waitUntilName(options.WaitUntil), err)
+	}
+	return p.waitAfterNavigation(ctx, options)
+}
+
+func (p *Page) WaitForTimeout(timeout time.Duration) error {
+	time.Sleep(timeout)
+	return nil
+}
+
+func (p *Page) WaitForFunction(pageFunction string, options *engine.WaitOptions) error {
+	timeout, polling := p.waitSettings(options)
+	deadline := time.Now().Add(timeout)
+	expr := fmt.Sprintf("(async () => !!(await (%s)))()", pageFunction)
+
+	for {
+		var ok bool
+		if err := p.evaluateValue("wait for function", expr, &ok); err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return p.fail("wait for function", fmt.Sprintf("timed out after %v", timeout), context.DeadlineExceeded)
+		}
+		time.Sleep(polling)
+	}
+}
+
+// cdpCookie mirrors Network.Cookie and Network.CookieParam
+type cdpCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	URL      string  `json:"url,omitempty"`
+	Domain   string  `json:"domain,omitempty"`
+	Path     string  `json:"path,omitempty"`
+	Expires  float64 `json:"expires,omitempty"`
+	HTTPOnly bool    `json:"httpOnly,omitempty"`
+	Secure   bool    `json:"secure,omitempty"`
+	SameSite string  `json:"sameSite,omitempty"`
+	Priority string  `json:"priority,omitempty"`
+}
+
+func (p *Page) GetCookies() ([]engine.Cookie, error) {
+	var res struct {
+		Cookies []cdpCookie `json:"cookies"`
+	}
+	if err := p.call("Network.getCookies", nil, &res); err != nil {
+		return nil, p.fail("get cookies", "failed to read cookies", err)
+	}
+
+	cookies := make([]engine.Cookie, 0, len(res.Cookies))
+	for _, c := range res.Cookies {
+		expires := int64(0)
+		if c.Expires > 0 {
+			expires = int64(c.Expires)
+		}
+		cookies = append(cookies, engine.Cookie{
+			Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path, Expires: expires,
+			HTTPOnly: c.HTTPOnly, Secure: c.Secure, SameSite: c.SameSite, Priority: c.Priority,
+		})
+	}
+	return cookies, nil
+}
+
+func (p *Page) SetCookies(cookies []engine.Cookie) error {
+	url, err := p.URL()
+	if err != nil {
+		return err
+	}
+
+	params := make([]cdpCookie, 0, len(cookies))
+	for _, c := range cookies {
+		cookie := cdpCookie{
+			Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path, Expires: float64(c.Expires),
+			HTTPOnly: c.HTTPOnly, Secure: c.Secure, SameSite: c.SameSite, Priority: c.Priority,
+		}
+		// Cookies without a domain are scoped to the current page.
+		if cookie.Domain == "" && strings.HasPrefix(url, "http") {
+			cookie.URL = url
+		}
+		params = append(params, cookie)
+	}
+	if err := p.call("Network.setCookies", map[string]interface{}{"cookies": params}, nil); err != nil {
+		return p.fail("set cookies", "failed to set cookies", err)
+	}
+	return nil
+}
+
+func (p *Page) ClearCookies() error {
+	if err := p.call("Network.clearBrowserCookies", nil, nil); err != nil {
+		return p.fail("clear cookies", "failed to clear cookies", err)
+	}
+	return nil
+}
+
+func (p *Page) SetViewport(viewport engine.ViewportConfig) error {
+	params := map[string]interface{}{
+		"width":             viewport.Width,
+		"height":            viewport.Height,
+		"deviceScaleFactor": 1,
+		"mobile":            false,
+	}
+	if err := p.call("Emulation.setDeviceMetricsOverride", params, nil); err != nil {
+		return p.fail("set viewport", "failed to set viewport", err)
+	}
+	return nil
+}
+
+func (p *Page) GetMetrics() (map[string]interface{}, error) {
+	if err := p.call("Performance.enable", nil, nil); err != nil {
+		return nil, p.fail("get metrics", "failed to enable performance domain", err)
+	}
+	var res struct {
+		Metrics []struct {
+			Name  string  `json:"name"`
+			Value float64 `json:"value"`
+		} `json:"metrics"`
+	}
+	if err := p.call("Performance.getMetrics", nil, &res); err != nil {
+		return nil, p.fail("get metrics", "failed to read metrics", err)
+	}
+
+	metrics := make(map[string]interface{}, len(res.Metrics))
+	for _, m := range res.Metrics {
+		metrics[m.Name] = m.Value
+	}
+	return metrics, nil
+}
+
+func (p *Page) EmulateDevice(device engine.Device) error {
+	params := map[string]interface{}{
+		"width":             device.Viewport.Width,
+		"height":            device.Viewport.Height,
+		"deviceScaleFactor": device.DeviceScaleFactor,
+		"mobile":            device.IsMobile,
+	}
+	if err := p.call("Emulation.setDeviceMetricsOverride", params, nil); err != nil {
+		return p.fail("emulate device", "failed to set device metrics", err)
+	}
+	if err := p.call("Emulation.setTouchEmulationEnabled", map[string]interface{}{"enabled": device.HasTouch}, nil); err != nil {
+		return p.fail("emulate device", "failed to set touch emulation", err)
+	}
+	if device.UserAgent != "" {
+		return p.setUserAgent(device.UserAgent)
+	}
+	return nil
+}
+
+// Close closes the tab. It is safe to call concurrently, as the engine
+// and a page's user may both close it; calls after the first do nothing.
+func (p *Page) Close() error {
+	first := false
+	p.closeOnce.Do(func() {
+		first = true
+		close(p.done)
+	})
+	if !first {
+		return nil
+	}
+	p.engine.removePage(p)
+	if err := p.call("Target.closeTarget", map[string]interface{}{"targetId": p.targetID}, nil); err != nil {
+		return p.fail("close", "failed to close page", err)
+	}
+	return nil
+}
+
+// jsString quotes s as a JavaScript string literal
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
@@ -0,0 +1,30 @@
+// Package enginetest provides helpers shared by the engine backend tests,
+// and a fake engine for the tests of the packages that drive one.
+package enginetest
+
+import (
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// FullConfig returns a Config in which every field is set and differs from
+// DefaultConfig, so a backend test can check that each one reaches the
+// browser instead of silently falling back to a default.
+func FullConfig() engine.Config {
+	return engine.Config{
+		Engine:   "conformance",
+		Headless: false,
+		Viewport: engine.ViewportConfig{Width: 1366, Height: 768},
+		Timeout:  7 * time.Second,
+
+		UserAgent:    "PhantomConformance/1.0",
+		ExtraHeaders: map[string]string{"X-Phantom-Conformance": "yes"},
+		Proxy:        "http://127.0.0.1:3128",
+
+		ExecutablePath: "/opt/phantom/browser",
+		Args:           []string{"--phantom-conformance-flag"},
+
+		Plugins: []engine.PluginConfig{{Path: "plugins/conformance.js", Name: "conformance", Enabled: true}},
+	}
+}
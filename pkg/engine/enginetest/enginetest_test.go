@@ -0,0 +1,22 @@
+package enginetest
+
+import (
+	"reflect"
+	"testing"
+
+	"phantomvite/pkg/engine"
+)
+
+// TestFullConfigCoversEveryField fails when a field is added to
+// engine.Config without giving it a non-default value in FullConfig.
+func TestFullConfigCoversEveryField(t *testing.T) {
+	full := reflect.ValueOf(FullConfig())
+	defaults := reflect.ValueOf(engine.DefaultConfig())
+
+	for i := 0; i < full.NumField(); i++ {
+		name := full.Type().Field(i).Name
+		if reflect.DeepEqual(full.Field(i).Interface(), defaults.Field(i).Interface()) {
+			t.Errorf("FullConfig().%s must differ from DefaultConfig().%s", name, name)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package enginetest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// Engine is an in-memory engine.Engine for the tests of the packages that
+// drive pages. Its funcs configure what its pages load, and the embedded
+// interfaces panic on anything the fakes do not implement. The zero value
+// is ready to use.
+type Engine struct {
+	engine.Engine
+
+	// Load is the error of navigating to url, or nil. By default URLs
+	// containing "slow" time out waiting for the WaitUntil event.
+	Load func(url string) error
+	// Document is the root element of the page at url, which the
+	// selectors of the page search. Pages without one match nothing.
+	Document func(url string) *Element
+	// Script answers ExecuteScript; by default it returns the script
+	Script func(p *Page, script string) (interface{}, error)
+
+	mu      sync.Mutex
+	pages   []*Page // open
+	opened  int
+	closed  bool
+	broken  error
+	cookies []engine.Cookie // shared by the pages, as a browser's are
+}
+
+func (e *Engine) Name() string                   { return "fake" }
+func (e *Engine) Initialize(engine.Config) error { return nil }
+
+func (e *Engine) NewPage(context.Context) (engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.broken != nil {
+		return nil, e.broken
+	}
+	page := &Page{engine: e}
+	e.pages = append(e.pages, page)
+	e.opened++
+	return page, nil
+}
+
+func (e *Engine) GetPages(context.Context) ([]engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.broken != nil {
+		return nil, e.broken
+	}
+	pages := make([]engine.Page, len(e.pages))
+	for i, page := range e.pages {
+		pages[i] = page
+	}
+	return pages, nil
+}
+
+// Close closes the engine and leaves its pages open
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	return nil
+}
+
+// Closed reports whether Close was called
+func (e *Engine) Closed() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.closed
+}
+
+// Break makes NewPage and GetPages fail with err, as if the browser died
+func (e *Engine) Break(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.broken = err
+}
+
+// Opened is the number of pages NewPage opened
+func (e *Engine) Opened() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.opened
+}
+
+// Open is the number of pages still open
+func (e *Engine) Open() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.pages)
+}
+
+// Page is a page of an Engine. It records the calls that change it, in
+// the form "click SELECTOR", for Calls.
+type Page struct {
+	engine.Page
+	engine *Engine
+
+	mu     sync.Mutex
+	url    string
+	nav    *engine.NavigationOptions
+	calls  []string
+	closed bool
+}
+
+func (p *Page) record(format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, fmt.Sprintf(format, args...))
+}
+
+// Calls are the calls recorded so far
+func (p *Page) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.calls...)
+}
+
+// Navigation is the options of the last navigation that loaded
+func (p *Page) Navigation() *engine.NavigationOptions {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nav
+}
+
+// Closed reports whether Close was called
+func (p *Page) Closed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+func (p *Page) Navigate(url string, options *engine.NavigationOptions) error {
+	var timeout time.Duration
+	if options != nil {
+		timeout = options.Timeout
+	}
+	p.record("navigate %s %v", url, timeout)
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if err := p.load(url, options); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.url, p.nav = url, options
+	return nil
+}
+
+func (p *Page) load(url string, options *engine.NavigationOptions) error {
+	if p.engine.Load != nil {
+		return p.engine.Load(url)
+	}
+	if !strings.Contains(url, "slow") {
+		return nil
+	}
+	event := "load"
+	if options != nil && options.WaitUntil != "" {
+		event = options.WaitUntil
+	}
+	return engine.NewEngineError("fake", "navigate", "timed out waiting for "+event, context.DeadlineExceeded)
+}
+
+// URL is the URL last loaded, or about:blank
+func (p *Page) URL() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.url == "" {
+		return "about:blank", nil
+	}
+	return p.url, nil
+}
+
+// Title is "Title of" and the URL
+func (p *Page) Title() (string, error) {
+	url, _ := p.URL()
+	return "Title of " + url, nil
+}
+
+func (p *Page) Content() (string, error) { return "<html></html>", nil }
+
+func (p *Page) ExecuteScript(script string) (interface{}, error) {
+	if p.engine.Script != nil {
+		return p.engine.Script(p, script)
+	}
+	return script, nil
+}
+
+// document is the root element of the page, or nil
+func (p *Page) document() *Element {
+	if p.engine.Document == nil {
+		return nil
+	}
+	url, _ := p.URL()
+	return p.engine.Document(url)
+}
+
+func (p *Page) QuerySelector(selector string) (engine.ElementHandle, error) {
+	if doc := p.document(); doc != nil {
+		return doc.QuerySelector(selector)
+	}
+	return nil, nil
+}
+
+func (p *Page) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	if doc := p.document(); doc != nil {
+		return doc.QuerySelectorAll(selector)
+	}
+	return nil, nil
+}
+
+// WaitForSelector finds an element at once, matching or not
+func (p *Page) WaitForSelector(selector string, options *engine.WaitOptions) (engine.ElementHandle, error) {
+	var timeout time.Duration
+	if options != nil {
+		timeout = options.Timeout
+	}
+	p.record("wait %s %v", selector, timeout)
+	return &Element{}, nil
+}
+
+func (p *Page) Click(selector string) error {
+	p.record("click %s", selector)
+	return nil
+}
+
+func (p *Page) Type(selector, text string) error {
+	p.record("type %s %q", selector, text)
+	return nil
+}
+
+func (p *Page) WaitForTimeout(d time.Duration) error {
+	p.record("sleep %v", d)
+	return nil
+}
+
+// Screenshot writes the format of the screenshot to its path
+func (p *Page) Screenshot(options engine.ScreenshotOptions) error {
+	p.record("screenshot %s %s %v", options.Path, options.Format, options.FullPage)
+	return os.WriteFile(options.Path, []byte(options.ResolvedFormat()), 0644)
+}
+
+// PDF writes "%PDF" and the paper format to the path of the PDF
+func (p *Page) PDF(options engine.PDFOptions) error {
+	p.record("pdf %s", options.Path)
+	return os.WriteFile(options.Path, []byte("%PDF "+options.Format), 0644)
+}
+
+func (p *Page) SetViewport(v engine.ViewportConfig) error {
+	p.record("viewport %dx%d", v.Width, v.Height)
+	return nil
+}
+
+func (p *Page) GetCookies() ([]engine.Cookie, error) {
+	p.engine.mu.Lock()
+	defer p.engine.mu.Unlock()
+	return append([]engine.Cookie(nil), p.engine.cookies...), nil
+}
+
+func (p *Page) SetCookies(cookies []engine.Cookie) error {
+	p.engine.mu.Lock()
+	defer p.engine.mu.Unlock()
+	p.engine.cookies = append(p.engine.cookies, cookies...)
+	return nil
+}
+
+func (p *Page) ClearCookies() error {
+	p.engine.mu.Lock()
+	defer p.engine.mu.Unlock()
+	p.engine.cookies = nil
+	return nil
+}
+
+func (p *Page) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	e := p.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, page := range e.pages {
+		if page == p {
+			e.pages = append(e.pages[:i], e.pages[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Element is an element of a Document: its text, its attributes and the
+// elements its selectors match
+type Element struct {
+	engine.ElementHandle
+	Text       string
+	Attrs      map[string]string
+	Properties map[string]interface{} // besides innerText and textContent, which are Text
+	Matches    map[string][]*Element
+}
+
+func (e *Element) GetAttribute(name string) (string, error) { return e.Attrs[name], nil }
+
+func (e *Element) GetProperty(name string) (interface{}, error) {
+	switch name {
+	case "innerText", "textContent":
+		return e.Text, nil
+	}
+	return e.Properties[name], nil
+}
+
+func (e *Element) QuerySelector(selector string) (engine.ElementHandle, error) {
+	if matches := e.Matches[selector]; len(matches) > 0 {
+		return matches[0], nil
+	}
+	return nil, nil
+}
+
+func (e *Element) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	matches := e.Matches[selector]
+	handles := make([]engine.ElementHandle, len(matches))
+	for i, m := range matches {
+		handles[i] = m
+	}
+	return handles, nil
+}
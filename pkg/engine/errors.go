@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// ErrorKind classifies a failure so callers, such as the CLI choosing an
+// exit code, can react to what went wrong rather than to the message
+type ErrorKind string
+
+const (
+	KindConfig            ErrorKind = "config"             // the configuration or options are invalid
+	KindUnavailable       ErrorKind = "engine_unavailable" // the engine is unknown, not installed or failed to start
+	KindNavigationTimeout ErrorKind = "navigation_timeout" // a page, or something waited for on it, did not load in time
+	KindScript            ErrorKind = "script"             // a script failed, in the page or on its own
+	KindPlugin            ErrorKind = "plugin"             // a plugin failed to load or its hook failed
+)
+
+// launchOperations fail when the engine cannot be used at all
+var launchOperations = map[string]bool{"lookup": true, "launch": true, "initialize": true}
+
+// ErrorKind returns e.Kind when it is set. Otherwise the kind follows from
+// the operation and the cause: failures to look up, launch or initialize
+// an engine mean it is unavailable, a failed "execute script" is a script
+// failure, and any other operation that ran out of time is a navigation
+// timeout.
+func (e *EngineError) ErrorKind() ErrorKind {
+	switch {
+	case e.Kind != "":
+		return e.Kind
+	case launchOperations[e.Operation]:
+		return KindUnavailable
+	case e.Operation == "execute script":
+		return KindScript
+	case isTimeout(e.Cause):
+		return KindNavigationTimeout
+	}
+	return ""
+}
+
+// isTimeout reports whether err is a deadline or an error that says it is
+// a timeout, like net.Error does
+func isTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) ||
+		errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// KindOf returns the kind of the outermost error in err's chain that has
+// one, or "" when none does. Errors outside this package take part by
+// implementing ErrorKind() ErrorKind.
+func KindOf(err error) ErrorKind {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if k, ok := err.(interface{ ErrorKind() ErrorKind }); ok && k.ErrorKind() != "" {
+			return k.ErrorKind()
+		}
+	}
+	return ""
+}
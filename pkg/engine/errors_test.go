@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "TimeoutError: Navigation timeout of 30000 ms exceeded" }
+func (timeoutError) Timeout() bool { return true }
+
+type pluginError struct{}
+
+func (pluginError) Error() string        { return "plugin failed" }
+func (pluginError) ErrorKind() ErrorKind { return KindPlugin }
+
+func TestKindOf(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		kind ErrorKind
+	}{
+		{errors.New("boom"), ""},
+		{nil, ""},
+		{NewEngineError("cdp", "launch", "engine is not available", nil), KindUnavailable},
+		{NewEngineError("cdp", "initialize", "failed to launch browser", context.DeadlineExceeded), KindUnavailable},
+		{NewEngineError("cdp", "navigate", "timed out waiting for load", context.DeadlineExceeded), KindNavigationTimeout},
+		{NewEngineError("puppeteer", "wait for selector", "waiting failed", timeoutError{}), KindNavigationTimeout},
+		{NewEngineError("cdp", "navigate", "failed to navigate", errors.New("net::ERR_NAME_NOT_RESOLVED")), ""},
+		{NewEngineError("cdp", "execute script", "script threw an exception", nil), KindScript},
+		{&EngineError{Operation: "navigate", Kind: KindConfig, Cause: context.DeadlineExceeded}, KindConfig},
+		{fmt.Errorf("navigation failed: %w", NewEngineError("cdp", "navigate", "timed out", context.DeadlineExceeded)), KindNavigationTimeout},
+		{fmt.Errorf("hook: %w", pluginError{}), KindPlugin},
+	} {
+		if got := KindOf(tc.err); got != tc.kind {
+			t.Errorf("%v: expected kind %q, got %q", tc.err, tc.kind, got)
+		}
+	}
+}
+
+func TestEngineErrorWithoutEngine(t *testing.T) {
+	err := &EngineError{Operation: "run script", Message: "script not found: a.js"}
+	if err.Error() != "run script: script not found: a.js" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
@@ -2,6 +2,10 @@ package engine
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -59,6 +63,33 @@ type ScreenshotOptions struct {
 	OmitBackground bool `json:"omit_background,omitempty"` // transparent background
 }
 
+// screenshotFormats maps the file extensions of screenshots to their formats
+var screenshotFormats = map[string]string{".png": "png", ".jpg": "jpeg", ".jpeg": "jpeg", ".webp": "webp"}
+
+// ScreenshotFormat is the format of a screenshot saved to path, from its
+// extension: png, jpeg or webp. It reports false for any other extension.
+func ScreenshotFormat(path string) (string, bool) {
+	format, ok := screenshotFormats[strings.ToLower(filepath.Ext(path))]
+	return format, ok
+}
+
+// ResolvedFormat is the format to capture in: Format, or the one of the
+// extension of Path when Format is empty, and png when neither names one
+func (o ScreenshotOptions) ResolvedFormat() string {
+	format := strings.ToLower(o.Format)
+	if format == "" {
+		format, _ = ScreenshotFormat(o.Path)
+	}
+	switch format {
+	case "jpg", "jpeg":
+		return "jpeg"
+	case "webp":
+		return "webp"
+	default:
+		return "png"
+	}
+}
+
 // ClipOptions represents a rectangular area to clip from the screenshot
 type ClipOptions struct {
 	X      float64 `json:"x"`
@@ -67,6 +98,94 @@ type ClipOptions struct {
 	Height float64 `json:"height"`
 }
 
+// PDFOptions represents options for rendering a page to PDF. Lengths are
+// in inches.
+type PDFOptions struct {
+	Path string `json:"path"` // file path to save the PDF
+
+	// Paper size: a named Format, or Width and Height. Letter when neither
+	// is set; see Paper.
+	Format    string     `json:"format,omitempty"` // Letter, Legal, Tabloid, Ledger, A0 to A6
+	Width     float64    `json:"width,omitempty"`
+	Height    float64    `json:"height,omitempty"`
+	Landscape bool       `json:"landscape,omitempty"`
+	Margin    *PDFMargin `json:"margin,omitempty"` // the browser's default margins when nil
+
+	// Content settings
+	Scale           float64 `json:"scale,omitempty"` // 0.1 to 2; 1 when zero
+	PrintBackground bool    `json:"print_background,omitempty"`
+	PageRanges      string  `json:"page_ranges,omitempty"` // e.g. "1-5, 8"; every page when empty
+
+	// HTML templates for the header and footer of each page. Elements with
+	// the classes date, title, url, pageNumber and totalPages get the
+	// corresponding values. Neither is printed when both are empty.
+	HeaderTemplate string `json:"header_template,omitempty"`
+	FooterTemplate string `json:"footer_template,omitempty"`
+}
+
+// PDFMargin represents the page margins of a PDF, in inches
+type PDFMargin struct {
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+}
+
+// PaperSize is a paper width and height in inches, portrait
+type PaperSize struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// PaperSizes are the names accepted by PDFOptions.Format, lowercased
+var PaperSizes = map[string]PaperSize{
+	"letter":  {8.5, 11},
+	"legal":   {8.5, 14},
+	"tabloid": {11, 17},
+	"ledger":  {17, 11},
+	"a0":      {33.1, 46.8},
+	"a1":      {23.4, 33.1},
+	"a2":      {16.54, 23.4},
+	"a3":      {11.7, 16.54},
+	"a4":      {8.27, 11.7},
+	"a5":      {5.83, 8.27},
+	"a6":      {4.13, 5.83},
+}
+
+// Paper returns the paper size the options select, before Landscape is
+// applied: Width and Height when both are set, else the named Format, else
+// Letter.
+func (o PDFOptions) Paper() (PaperSize, error) {
+	switch {
+	case o.Width > 0 && o.Height > 0:
+		return PaperSize{o.Width, o.Height}, nil
+	case o.Format == "":
+		return PaperSizes["letter"], nil
+	}
+	size, ok := PaperSizes[strings.ToLower(o.Format)]
+	if !ok {
+		return PaperSize{}, fmt.Errorf("unknown paper format %q", o.Format)
+	}
+	return size, nil
+}
+
+// HeaderFooter reports whether a header or footer is printed, and returns
+// both templates with an empty one replaced by a blank element, since
+// browsers print a default header or footer for an empty template
+func (o PDFOptions) HeaderFooter() (display bool, header, footer string) {
+	if o.HeaderTemplate == "" && o.FooterTemplate == "" {
+		return false, "", ""
+	}
+	header, footer = o.HeaderTemplate, o.FooterTemplate
+	if header == "" {
+		header = "<span></span>"
+	}
+	if footer == "" {
+		footer = "<span></span>"
+	}
+	return true, header, footer
+}
+
 // WaitOptions represents options for waiting operations
 type WaitOptions struct {
 	Timeout    time.Duration `json:"timeout,omitempty"`     // maximum wait time
@@ -84,6 +203,37 @@ type NavigationOptions struct {
 	WaitForSelector string        `json:"wait_for_selector,omitempty"` // wait for specific selector after navigation
 }
 
+// WaitUntilEvents are the values of NavigationOptions.WaitUntil; empty
+// means "load"
+var WaitUntilEvents = []string{"load", "domcontentloaded", "networkidle0", "networkidle2"}
+
+// Validate checks WaitUntil and Timeout; nil options are valid. Engines
+// call it before navigating, so a mistake fails with KindConfig instead
+// of waiting for the wrong event.
+func (o *NavigationOptions) Validate() error {
+	switch {
+	case o == nil:
+		return nil
+	case o.WaitUntil != "" && !slices.Contains(WaitUntilEvents, o.WaitUntil):
+		return &EngineError{Operation: "navigate", Kind: KindConfig,
+			Message: fmt.Sprintf("wait_until must be one of %s, got %q", strings.Join(WaitUntilEvents, ", "), o.WaitUntil)}
+	case o.Timeout < 0:
+		return &EngineError{Operation: "navigate", Kind: KindConfig, Message: fmt.Sprintf("timeout must not be negative, got %v", o.Timeout)}
+	}
+	return nil
+}
+
+// Remaining returns the time left before ctx's deadline, so a wait after
+// navigation shares the navigation's timeout. It is at least a nanosecond,
+// as a zero timeout means the default to engines; zero without a deadline.
+func Remaining(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return max(time.Until(deadline), time.Nanosecond)
+}
+
 // ElementHandle represents a handle to a DOM element
 type ElementHandle interface {
 	Click() error
@@ -94,6 +244,12 @@ type ElementHandle interface {
 	BoundingBox() (*BoundingBox, error)
 }
 
+// ElementQuerier is implemented by elements that can look up their own
+// descendants; check for it with a type assertion
+type ElementQuerier interface {
+	QuerySelectorAll(selector string) ([]ElementHandle, error)
+}
+
 // BoundingBox represents the bounding box of an element
 type BoundingBox struct {
 	X      float64 `json:"x"`
@@ -102,6 +258,34 @@ type BoundingBox struct {
 	Height float64 `json:"height"`
 }
 
+// EventType is what an Event reports
+type EventType string
+
+const (
+	EventConsole       EventType = "console"        // the page logged a message
+	EventRequest       EventType = "request"        // the page sent a request
+	EventResponse      EventType = "response"       // a request was answered
+	EventRequestFailed EventType = "request_failed" // a request got no answer
+)
+
+// Event is a console message or network activity of a page
+type Event struct {
+	Type   EventType `json:"type"`
+	Time   time.Time `json:"time"`
+	Level  string    `json:"level,omitempty"`  // console: log, info, warning, error or debug
+	Text   string    `json:"text,omitempty"`   // console: the message; request_failed: the reason
+	URL    string    `json:"url,omitempty"`    // network events
+	Method string    `json:"method,omitempty"` // network events
+	Status int       `json:"status,omitempty"` // response: the HTTP status
+}
+
+// EventSource is implemented by pages that can report their events. The
+// channel closes when ctx is done or the page closes; events that arrive
+// faster than they are received may be dropped.
+type EventSource interface {
+	Events(ctx context.Context) (<-chan Event, error)
+}
+
 // Engine interface defines the contract for browser automation engines
 type Engine interface {
 	// Basic engine operations
@@ -110,9 +294,9 @@ type Engine interface {
 	Close() error
 	
 	// Page operations
-	Navigate(ctx context.Context, url string) (*Page, error)
-	NewPage(ctx context.Context) (*Page, error)
-	GetPages(ctx context.Context) ([]*Page, error)
+	Navigate(ctx context.Context, url string) (Page, error)
+	NewPage(ctx context.Context) (Page, error)
+	GetPages(ctx context.Context) ([]Page, error)
 	
 	// Screenshot operations
 	Screenshot(ctx context.Context, options ScreenshotOptions) error
@@ -153,6 +337,9 @@ type Page interface {
 	
 	// Screenshot operations
 	Screenshot(options ScreenshotOptions) error
+
+	// PDF rendering; most browsers can only print to PDF when headless
+	PDF(options PDFOptions) error
 	
 	// Waiting operations
 	WaitForNavigation(options *NavigationOptions) error
@@ -198,17 +385,22 @@ type Device struct {
 
 // EngineError represents an error that occurred during engine operations
 type EngineError struct {
-	Engine    string `json:"engine"`
-	Operation string `json:"operation"`
-	Message   string `json:"message"`
-	Cause     error  `json:"cause,omitempty"`
+	Engine    string    `json:"engine"`
+	Operation string    `json:"operation"`
+	Message   string    `json:"message"`
+	Cause     error     `json:"cause,omitempty"`
+	Kind      ErrorKind `json:"kind,omitempty"` // derived from Operation and Cause when empty; see ErrorKind
 }
 
 func (e *EngineError) Error() string {
+	msg := e.Operation + ": " + e.Message
+	if e.Engine != "" {
+		msg = e.Engine + " " + msg
+	}
 	if e.Cause != nil {
-		return e.Engine + " " + e.Operation + ": " + e.Message + " (caused by: " + e.Cause.Error() + ")"
+		return msg + " (caused by: " + e.Cause.Error() + ")"
 	}
-	return e.Engine + " " + e.Operation + ": " + e.Message
+	return msg
 }
 
 func (e *EngineError) Unwrap() error {
@@ -273,6 +465,28 @@ var (
 	}
 )
 
+// Devices lists the predefined devices that can be looked up by name
+var Devices = []Device{DeviceIPhone12, DeviceIPhoneSE, DevicePixel5, DeviceIPadPro, DeviceDesktop}
+
+// LookupDevice finds a predefined device by name. Case, spaces, dashes and
+// underscores are ignored, and a "Device" prefix is allowed, so "iPhone 12",
+// "iphone-12" and "DeviceIPhone12" all name the same device.
+func LookupDevice(name string) (Device, bool) {
+	want := normalizeDeviceName(name)
+	for _, d := range Devices {
+		if normalizeDeviceName(d.Name) == want {
+			return d, true
+		}
+	}
+	return Device{}, false
+}
+
+func normalizeDeviceName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name)
+	return strings.TrimPrefix(name, "device")
+}
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() Config {
 	return Config{
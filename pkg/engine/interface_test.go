@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -32,3 +33,95 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Expected default plugins to be empty, got %d plugins", len(cfg.Plugins))
 	}
 }
+
+func TestLookupDevice(t *testing.T) {
+	for _, name := range []string{"iPhone 12", "iphone-12", "DeviceIPhone12", "IPHONE_12"} {
+		device, ok := LookupDevice(name)
+		if !ok || device.Name != DeviceIPhone12.Name {
+			t.Errorf("expected %q to find iPhone 12, got %+v, %t", name, device, ok)
+		}
+	}
+	if _, ok := LookupDevice("Nokia 3310"); ok {
+		t.Errorf("expected unknown device to not be found")
+	}
+}
+
+func TestScreenshotOptionsResolvedFormat(t *testing.T) {
+	for _, tc := range []struct {
+		opts ScreenshotOptions
+		want string
+	}{
+		{ScreenshotOptions{}, "png"},
+		{ScreenshotOptions{Path: "shot.JPG"}, "jpeg"},
+		{ScreenshotOptions{Path: "shot.webp"}, "webp"},
+		{ScreenshotOptions{Path: "shot.gif"}, "png"},
+		{ScreenshotOptions{Path: "shot.png", Format: "jpg"}, "jpeg"},
+	} {
+		if got := tc.opts.ResolvedFormat(); got != tc.want {
+			t.Errorf("%+v: expected %s, got %s", tc.opts, tc.want, got)
+		}
+	}
+	if _, ok := ScreenshotFormat("shot.gif"); ok {
+		t.Errorf("expected .gif to have no screenshot format")
+	}
+}
+
+func TestPDFOptionsPaper(t *testing.T) {
+	for _, tc := range []struct {
+		opts PDFOptions
+		want PaperSize
+	}{
+		{PDFOptions{}, PaperSize{8.5, 11}},
+		{PDFOptions{Format: "A4"}, PaperSize{8.27, 11.7}},
+		{PDFOptions{Format: "A4", Width: 5, Height: 7}, PaperSize{5, 7}},
+		{PDFOptions{Format: "legal", Width: 5}, PaperSize{8.5, 14}},
+	} {
+		got, err := tc.opts.Paper()
+		if err != nil || got != tc.want {
+			t.Errorf("%+v: expected %v, got %v, %v", tc.opts, tc.want, got, err)
+		}
+	}
+	if _, err := (PDFOptions{Format: "B5"}).Paper(); err == nil {
+		t.Errorf("expected an unknown format to fail")
+	}
+}
+
+func TestPDFOptionsHeaderFooter(t *testing.T) {
+	if display, _, _ := (PDFOptions{}).HeaderFooter(); display {
+		t.Errorf("expected no header or footer without templates")
+	}
+	display, header, footer := PDFOptions{FooterTemplate: `<span class="pageNumber"></span>`}.HeaderFooter()
+	if !display || header != "<span></span>" || footer != `<span class="pageNumber"></span>` {
+		t.Errorf("expected a blank header and the footer, got %v %q %q", display, header, footer)
+	}
+}
+
+func TestNavigationOptionsValidate(t *testing.T) {
+	var none *NavigationOptions
+	for _, opts := range []*NavigationOptions{none, {}, {WaitUntil: "networkidle2", Timeout: time.Second}} {
+		if err := opts.Validate(); err != nil {
+			t.Errorf("%+v: %v", opts, err)
+		}
+	}
+	for _, opts := range []*NavigationOptions{{WaitUntil: "idle"}, {Timeout: -time.Second}} {
+		if err := opts.Validate(); KindOf(err) != KindConfig {
+			t.Errorf("%+v: expected a config error, got %v", opts, err)
+		}
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	if got := Remaining(context.Background()); got != 0 {
+		t.Errorf("expected 0 without a deadline, got %v", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if got := Remaining(ctx); got <= 59*time.Second || got > time.Minute {
+		t.Errorf("expected about a minute, got %v", got)
+	}
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if got := Remaining(ctx); got != time.Nanosecond {
+		t.Errorf("expected a nanosecond after the deadline, got %v", got)
+	}
+}
@@ -0,0 +1,184 @@
+package nodebridge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// RPCError is an error object returned by the sidecar. Data carries the
+// JavaScript error name (e.g. TimeoutError) and stack when available.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    *struct {
+		Name  string `json:"name"`
+		Stack string `json:"stack"`
+	} `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if e.Data != nil && e.Data.Name != "" {
+		return e.Data.Name + ": " + e.Message
+	}
+	return e.Message
+}
+
+// Name returns the JavaScript error class name, or "" if unknown
+func (e *RPCError) Name() string {
+	if e.Data == nil {
+		return ""
+	}
+	return e.Data.Name
+}
+
+// Timeout reports whether the error is a Puppeteer or Playwright
+// TimeoutError
+func (e *RPCError) Timeout() bool {
+	return e.Name() == "TimeoutError"
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RPCError       `json:"error,omitempty"`
+}
+
+var errBridgeClosed = errors.New("nodebridge: sidecar closed")
+
+// client is a JSON-RPC 2.0 client over newline-delimited JSON streams
+type client struct {
+	w       io.WriteCloser
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan *rpcResponse
+	done    chan struct{}
+	err     error
+}
+
+func newClient(r io.Reader, w io.WriteCloser) *client {
+	c := &client{
+		w:       w,
+		pending: make(map[int64]chan *rpcResponse),
+		done:    make(chan struct{}),
+	}
+	go c.readLoop(r)
+	return c
+}
+
+func (c *client) readLoop(r io.Reader) {
+	reader := bufio.NewReaderSize(r, 1<<16)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var resp rpcResponse
+			if jsonErr := json.Unmarshal(line, &resp); jsonErr == nil && resp.ID != nil {
+				c.mu.Lock()
+				ch, ok := c.pending[*resp.ID]
+				delete(c.pending, *resp.ID)
+				c.mu.Unlock()
+				if ok {
+					ch <- &resp
+				}
+			}
+		}
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+	}
+}
+
+func (c *client) shutdown(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	if err == io.EOF {
+		err = errBridgeClosed
+	}
+	c.err = err
+	close(c.done)
+	for id, ch := range c.pending {
+		delete(c.pending, id)
+		close(ch)
+	}
+}
+
+// call sends method with params and decodes the result into result, which
+// may be nil. It gives up when ctx is done, leaving the sidecar to finish.
+func (c *client) call(ctx context.Context, method string, params, result interface{}) error {
+	ch := make(chan *rpcResponse, 1)
+
+	c.mu.Lock()
+	select {
+	case <-c.done:
+		c.mu.Unlock()
+		return errBridgeClosed
+	default:
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	data, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		c.forget(id)
+		return err
+	}
+
+	c.writeMu.Lock()
+	_, err = c.w.Write(append(data, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.forget(id)
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return errBridgeClosed
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			return json.Unmarshal(resp.Result, result)
+		}
+		return nil
+	case <-ctx.Done():
+		c.forget(id)
+		return ctx.Err()
+	}
+}
+
+func (c *client) forget(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+func (c *client) close() error {
+	err := c.w.Close()
+	c.shutdown(errBridgeClosed)
+	return err
+}
@@ -0,0 +1,81 @@
+package nodebridge
+
+import (
+	"context"
+
+	"phantomvite/pkg/engine"
+)
+
+// Element is an element handle held by the sidecar and addressed by its ID
+type Element struct {
+	page *Page
+	id   string
+}
+
+func (el *Element) call(operation, method string, params map[string]interface{}, result interface{}) error {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["elementId"] = el.id
+	return el.page.engine.call(context.Background(), operation, "element."+method, 0, params, result)
+}
+
+func (el *Element) Click() error { return el.call("click", "click", nil, nil) }
+
+func (el *Element) Type(text string) error {
+	return el.call("type", "type", map[string]interface{}{"text": text}, nil)
+}
+
+// GetAttribute returns the attribute value, or "" when it is not set
+func (el *Element) GetAttribute(name string) (string, error) {
+	var res struct {
+		Value *string `json:"value"`
+	}
+	if err := el.call("get attribute", "getAttribute", map[string]interface{}{"name": name}, &res); err != nil {
+		return "", err
+	}
+	if res.Value == nil {
+		return "", nil
+	}
+	return *res.Value, nil
+}
+
+func (el *Element) GetProperty(name string) (interface{}, error) {
+	var res struct {
+		Value interface{} `json:"value"`
+	}
+	err := el.call("get property", "getProperty", map[string]interface{}{"name": name}, &res)
+	return res.Value, err
+}
+
+func (el *Element) IsVisible() (bool, error) {
+	var res struct {
+		Value bool `json:"value"`
+	}
+	err := el.call("is visible", "isVisible", nil, &res)
+	return res.Value, err
+}
+
+// QuerySelectorAll returns the descendants of the element matching selector
+func (el *Element) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	var res struct {
+		ElementIDs []string `json:"elementIds"`
+	}
+	if err := el.call("query selector all", "querySelectorAll", map[string]interface{}{"selector": selector}, &res); err != nil {
+		return nil, err
+	}
+	elements := make([]engine.ElementHandle, len(res.ElementIDs))
+	for i, id := range res.ElementIDs {
+		elements[i] = &Element{page: el.page, id: id}
+	}
+	return elements, nil
+}
+
+// BoundingBox returns the element's box, or nil when it is not rendered
+func (el *Element) BoundingBox() (*engine.BoundingBox, error) {
+	var res struct {
+		Box *engine.BoundingBox `json:"box"`
+	}
+	err := el.call("bounding box", "boundingBox", nil, &res)
+	return res.Box, err
+}
@@ -0,0 +1,298 @@
+// Package nodebridge implements engine.Engine on top of a long-lived Node.js
+// sidecar (runtime/bridge.mjs) that drives Puppeteer or Playwright. The Go
+// side speaks JSON-RPC 2.0 to it over stdio, one message per line.
+package nodebridge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// bridgeScript is the sidecar entry point inside the runtime directory
+const bridgeScript = "bridge.mjs"
+
+// callGrace is added to sidecar-side timeouts so the sidecar reports its own
+// timeout error before the Go side gives up on the request.
+const callGrace = 5 * time.Second
+
+func init() {
+	for _, d := range []struct{ name, description string }{
+		{"puppeteer", "Node.js, full Chrome control via DevTools protocol"},
+		{"playwright", "Node.js, cross-browser automation (Chrome, Firefox, Safari)"},
+	} {
+		name := d.name
+		engine.Register(engine.Driver{
+			Name:        name,
+			Description: d.description,
+			New:         func() engine.Engine { return New(name) },
+			Probe:       func() engine.EngineStatus { return probe(name) },
+		})
+	}
+}
+
+var (
+	runtimeMu  sync.Mutex
+	runtimeDir = "runtime"
+)
+
+// SetRuntimeDir sets the directory RuntimeDir returns when
+// PHANTOM_RUNTIME_DIR is unset, such as the runtime of the project a
+// command runs in
+func SetRuntimeDir(dir string) {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	runtimeDir = dir
+}
+
+// RuntimeDir returns the directory holding bridge.mjs and its node_modules:
+// PHANTOM_RUNTIME_DIR when it is set, or else the directory given to
+// SetRuntimeDir. It defaults to ./runtime.
+func RuntimeDir() string {
+	if dir := os.Getenv("PHANTOM_RUNTIME_DIR"); dir != "" {
+		return dir
+	}
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+	return runtimeDir
+}
+
+func probe(driver string) engine.EngineStatus {
+	if _, err := exec.LookPath("node"); err != nil {
+		return engine.EngineStatus{Error: "Node.js not found. Install Node.js 20+"}
+	}
+	dir := filepath.Join(RuntimeDir(), "node_modules", driver)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return engine.EngineStatus{Error: "Not installed. Run: cd runtime && npm install " + driver}
+	}
+	return engine.EngineStatus{Available: true, Path: dir}
+}
+
+// Engine controls one browser through the Node sidecar
+type Engine struct {
+	driver string
+	config engine.Config
+
+	// transport overrides the spawned sidecar, mainly for tests
+	reader io.Reader
+	writer io.WriteCloser
+
+	cmd    *exec.Cmd
+	client *client
+
+	mu    sync.Mutex
+	pages []*Page
+}
+
+// New returns an engine that spawns the sidecar for the given driver
+// ("puppeteer" or "playwright") on Initialize.
+func New(driver string) *Engine {
+	return &Engine{driver: driver}
+}
+
+// NewWithTransport returns an engine that talks to an already running
+// sidecar through r and w instead of spawning one.
+func NewWithTransport(driver string, r io.Reader, w io.WriteCloser) *Engine {
+	return &Engine{driver: driver, reader: r, writer: w}
+}
+
+func (e *Engine) Name() string { return e.driver }
+
+func (e *Engine) Initialize(config engine.Config) error {
+	if config.Timeout <= 0 {
+		config.Timeout = engine.DefaultConfig().Timeout
+	}
+	e.config = config
+
+	if e.reader == nil {
+		if err := e.spawn(); err != nil {
+			return engine.NewEngineError(e.driver, "initialize", "failed to start Node sidecar", err)
+		}
+	}
+	e.client = newClient(e.reader, e.writer)
+
+	params := map[string]interface{}{"driver": e.driver, "config": config}
+	if err := e.call(context.Background(), "initialize", "launch", config.Timeout, params, nil); err != nil {
+		e.Close()
+		return err
+	}
+	return nil
+}
+
+func (e *Engine) spawn() error {
+	dir := RuntimeDir()
+	cmd := exec.Command("node", bridgeScript)
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	e.cmd = cmd
+	e.reader = stdout
+	e.writer = stdin
+	return nil
+}
+
+// call performs one RPC and maps failures to *engine.EngineError. timeout is
+// how long the operation itself may take; zero means the configured default.
+func (e *Engine) call(ctx context.Context, operation, method string, timeout time.Duration, params, result interface{}) error {
+	if e.client == nil {
+		return engine.NewEngineError(e.driver, operation, "engine is not initialized", nil)
+	}
+	if timeout <= 0 {
+		timeout = e.config.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout+callGrace)
+	defer cancel()
+
+	err := e.client.call(ctx, method, params, result)
+	if err == nil {
+		return nil
+	}
+
+	var rpcErr *RPCError
+	switch {
+	case errors.As(err, &rpcErr):
+		return engine.NewEngineError(e.driver, operation, rpcErr.Message, rpcErr)
+	case errors.Is(err, context.DeadlineExceeded):
+		return engine.NewEngineError(e.driver, operation, "timed out waiting for the Node sidecar", err)
+	default:
+		return engine.NewEngineError(e.driver, operation, "Node sidecar request failed", err)
+	}
+}
+
+func (e *Engine) Close() error {
+	if e.client == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	e.pages = nil
+	e.mu.Unlock()
+
+	closeErr := e.call(context.Background(), "close", "close", 0, nil, nil)
+	e.client.close()
+	e.client = nil
+
+	if e.cmd != nil {
+		done := make(chan error, 1)
+		go func() { done <- e.cmd.Wait() }()
+		select {
+		case <-done:
+		case <-time.After(callGrace):
+			e.cmd.Process.Kill()
+			<-done
+		}
+		e.cmd = nil
+	}
+	return closeErr
+}
+
+func (e *Engine) Navigate(ctx context.Context, url string) (engine.Page, error) {
+	page, err := e.newPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := page.Navigate(url, nil); err != nil {
+		page.Close()
+		return nil, err
+	}
+	return page, nil
+}
+
+func (e *Engine) NewPage(ctx context.Context) (engine.Page, error) {
+	return e.newPage(ctx)
+}
+
+func (e *Engine) newPage(ctx context.Context) (*Page, error) {
+	var res struct {
+		PageID string `json:"pageId"`
+	}
+	if err := e.call(ctx, "new page", "newPage", 0, nil, &res); err != nil {
+		return nil, err
+	}
+
+	page := &Page{engine: e, id: res.PageID}
+	e.mu.Lock()
+	e.pages = append(e.pages, page)
+	e.mu.Unlock()
+	return page, nil
+}
+
+func (e *Engine) removePage(page *Page) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, p := range e.pages {
+		if p == page {
+			e.pages = append(e.pages[:i], e.pages[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Engine) GetPages(ctx context.Context) ([]engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pages := make([]engine.Page, len(e.pages))
+	for i, p := range e.pages {
+		pages[i] = p
+	}
+	return pages, nil
+}
+
+// Screenshot captures the most recently opened page
+func (e *Engine) Screenshot(ctx context.Context, options engine.ScreenshotOptions) error {
+	e.mu.Lock()
+	var page *Page
+	if len(e.pages) > 0 {
+		page = e.pages[len(e.pages)-1]
+	}
+	e.mu.Unlock()
+
+	if page == nil {
+		return engine.NewEngineError(e.driver, "screenshot", "no page has been opened", nil)
+	}
+	return page.Screenshot(options)
+}
+
+func (e *Engine) SetUserAgent(userAgent string) error {
+	e.config.UserAgent = userAgent
+	params := map[string]interface{}{"userAgent": userAgent}
+	return e.call(context.Background(), "set user agent", "setUserAgent", 0, params, nil)
+}
+
+func (e *Engine) SetExtraHeaders(headers map[string]string) error {
+	e.config.ExtraHeaders = headers
+	params := map[string]interface{}{"headers": headers}
+	return e.call(context.Background(), "set extra headers", "setExtraHeaders", 0, params, nil)
+}
+
+func (e *Engine) SetViewport(viewport engine.ViewportConfig) error {
+	e.config.Viewport = viewport
+	e.mu.Lock()
+	pages := append([]*Page(nil), e.pages...)
+	e.mu.Unlock()
+	for _, p := range pages {
+		if err := p.SetViewport(viewport); err != nil {
+			return err
+		}
+	}
+	return nil
+}
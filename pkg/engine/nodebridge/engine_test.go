@@ -0,0 +1,398 @@
+package nodebridge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// fakeSidecar answers JSON-RPC requests in-process from scripted handlers
+type fakeSidecar struct {
+	mu       sync.Mutex
+	handlers map[string]func(params json.RawMessage) (interface{}, *RPCError)
+	requests []rpcRequestLog
+}
+
+type rpcRequestLog struct {
+	Method string
+	Params json.RawMessage
+}
+
+func newFakeSidecar() *fakeSidecar {
+	f := &fakeSidecar{handlers: make(map[string]func(json.RawMessage) (interface{}, *RPCError))}
+	f.handle("newPage", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]string{"pageId": "p1"}, nil
+	})
+	return f
+}
+
+func (f *fakeSidecar) handle(method string, h func(json.RawMessage) (interface{}, *RPCError)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = h
+}
+
+func (f *fakeSidecar) requested(method string) []rpcRequestLog {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []rpcRequestLog
+	for _, r := range f.requests {
+		if r.Method == method {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// start wires the fake to a new engine and serves until the engine closes stdin
+func (f *fakeSidecar) start(t *testing.T, driver string) *Engine {
+	t.Helper()
+	cfg := engine.DefaultConfig()
+	cfg.Timeout = time.Second
+	return f.startWithConfig(t, driver, cfg)
+}
+
+func (f *fakeSidecar) startWithConfig(t *testing.T, driver string, cfg engine.Config) *Engine {
+	t.Helper()
+	toSidecar, fromEngine := io.Pipe()
+	toEngine, fromSidecar := io.Pipe()
+
+	go func() {
+		defer fromSidecar.Close()
+		scanner := bufio.NewScanner(toSidecar)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		for scanner.Scan() {
+			var req struct {
+				ID     int64           `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+				t.Errorf("fake sidecar got invalid JSON: %v", err)
+				return
+			}
+
+			f.mu.Lock()
+			f.requests = append(f.requests, rpcRequestLog{Method: req.Method, Params: req.Params})
+			handler := f.handlers[req.Method]
+			f.mu.Unlock()
+
+			if handler == nil {
+				// Unscripted methods hang so timeout handling can be exercised.
+				if strings.HasPrefix(req.Method, "hang.") {
+					continue
+				}
+				handler = func(json.RawMessage) (interface{}, *RPCError) { return map[string]string{}, nil }
+			}
+			result, rpcErr := handler(req.Params)
+			resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			if rpcErr != nil {
+				resp["error"] = rpcErr
+			} else {
+				resp["result"] = result
+			}
+			data, _ := json.Marshal(resp)
+			fromSidecar.Write(append(data, '\n'))
+		}
+	}()
+
+	e := NewWithTransport(driver, toEngine, fromEngine)
+	if err := e.Initialize(cfg); err != nil {
+		t.Fatalf("failed to initialize engine: %v", err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestInitializeSendsLaunch(t *testing.T) {
+	f := newFakeSidecar()
+	f.start(t, "playwright")
+
+	launches := f.requested("launch")
+	if len(launches) != 1 {
+		t.Fatalf("expected one launch request, got %d", len(launches))
+	}
+	var params struct {
+		Driver string `json:"driver"`
+		Config struct {
+			Headless bool `json:"headless"`
+		} `json:"config"`
+	}
+	json.Unmarshal(launches[0].Params, &params)
+	if params.Driver != "playwright" || !params.Config.Headless {
+		t.Errorf("unexpected launch params: %s", launches[0].Params)
+	}
+}
+
+func TestNavigateAndTitle(t *testing.T) {
+	f := newFakeSidecar()
+	f.handle("page.title", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]string{"value": "Example Domain"}, nil
+	})
+	e := f.start(t, "puppeteer")
+
+	page, err := e.Navigate(context.Background(), "https://example.com/?q='quoted'")
+	if err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	title, err := page.Title()
+	if err != nil || title != "Example Domain" {
+		t.Fatalf("expected title 'Example Domain', got %q, %v", title, err)
+	}
+
+	navs := f.requested("page.navigate")
+	if len(navs) != 1 {
+		t.Fatalf("expected one navigate request, got %d", len(navs))
+	}
+	var params struct {
+		PageID  string `json:"pageId"`
+		URL     string `json:"url"`
+		Options struct {
+			Timeout time.Duration `json:"timeout"`
+		} `json:"options"`
+	}
+	json.Unmarshal(navs[0].Params, &params)
+	if params.PageID != "p1" || params.URL != "https://example.com/?q='quoted'" {
+		t.Errorf("URL must reach the sidecar verbatim, got %+v", params)
+	}
+	if params.Options.Timeout != time.Second {
+		t.Errorf("expected configured timeout to be sent, got %v", params.Options.Timeout)
+	}
+}
+
+func TestNavigateFailureClosesPage(t *testing.T) {
+	f := newFakeSidecar()
+	f.handle("page.navigate", func(json.RawMessage) (interface{}, *RPCError) {
+		return nil, &RPCError{Code: -32000, Message: "net::ERR_NAME_NOT_RESOLVED at https://nope.invalid"}
+	})
+	e := f.start(t, "puppeteer")
+
+	if _, err := e.Navigate(context.Background(), "https://nope.invalid"); err == nil {
+		t.Fatal("expected navigate to fail")
+	}
+	if got := len(f.requested("page.close")); got != 1 {
+		t.Errorf("expected the failed page to be closed, got %d close requests", got)
+	}
+}
+
+func TestConcurrentCloseClosesOnce(t *testing.T) {
+	f := newFakeSidecar()
+	e := f.start(t, "puppeteer")
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			page.Close()
+		}()
+	}
+	wg.Wait()
+	if got := len(f.requested("page.close")); got != 1 {
+		t.Errorf("expected one close request, got %d", got)
+	}
+}
+
+func TestScreenshotResolvesFormat(t *testing.T) {
+	f := newFakeSidecar()
+	e := f.start(t, "playwright")
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	opts := engine.ScreenshotOptions{Path: "shot.JPG", Quality: 60, FullPage: true, Clip: &engine.ClipOptions{Width: 10, Height: 20}, OmitBackground: true}
+	if err := page.Screenshot(opts); err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+	var params struct {
+		Options map[string]interface{} `json:"options"`
+	}
+	json.Unmarshal(f.requested("page.screenshot")[0].Params, &params)
+	for key, want := range map[string]interface{}{"format": "jpeg", "quality": 60.0, "full_page": true, "omit_background": true} {
+		if params.Options[key] != want {
+			t.Errorf("expected %s=%v, got %v", key, want, params.Options[key])
+		}
+	}
+	if path, _ := params.Options["path"].(string); !filepath.IsAbs(path) {
+		t.Errorf("expected an absolute path, got %q", path)
+	}
+	if clip, _ := params.Options["clip"].(map[string]interface{}); clip["height"] != 20.0 {
+		t.Errorf("expected the clip to be sent, got %v", params.Options["clip"])
+	}
+
+	if err := page.Screenshot(engine.ScreenshotOptions{Path: "shot.webp"}); err == nil || !strings.Contains(err.Error(), "WebP") {
+		t.Errorf("expected Playwright to refuse WebP, got %v", err)
+	}
+}
+
+func TestPDFResolvesPaper(t *testing.T) {
+	f := newFakeSidecar()
+	e := f.start(t, "puppeteer")
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	if err := page.PDF(engine.PDFOptions{Path: "page.pdf", Format: "Legal", HeaderTemplate: "<p>h</p>"}); err != nil {
+		t.Fatalf("pdf failed: %v", err)
+	}
+	var params struct {
+		Options map[string]interface{} `json:"options"`
+	}
+	json.Unmarshal(f.requested("page.pdf")[0].Params, &params)
+	for key, want := range map[string]interface{}{"width": 8.5, "height": 14.0, "header_template": "<p>h</p>", "footer_template": "<span></span>"} {
+		if params.Options[key] != want {
+			t.Errorf("expected %s=%v, got %v", key, want, params.Options[key])
+		}
+	}
+	if _, ok := params.Options["format"]; ok {
+		t.Errorf("expected the format to be resolved, got %v", params.Options["format"])
+	}
+	if path, _ := params.Options["path"].(string); !filepath.IsAbs(path) {
+		t.Errorf("expected an absolute path, got %q", path)
+	}
+}
+
+func TestRPCErrorMapsToEngineError(t *testing.T) {
+	f := newFakeSidecar()
+	f.handle("page.click", func(json.RawMessage) (interface{}, *RPCError) {
+		err := &RPCError{Code: -32000, Message: "No element found for selector: #nope"}
+		err.Data = &struct {
+			Name  string `json:"name"`
+			Stack string `json:"stack"`
+		}{Name: "Error"}
+		return nil, err
+	})
+	e := f.start(t, "puppeteer")
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	err = page.Click("#nope")
+	var engineErr *engine.EngineError
+	if !errors.As(err, &engineErr) {
+		t.Fatalf("expected *EngineError, got %v", err)
+	}
+	if engineErr.Engine != "puppeteer" || engineErr.Operation != "click" {
+		t.Errorf("unexpected error attribution: %+v", engineErr)
+	}
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32000 {
+		t.Errorf("expected RPC error to be preserved as the cause, got %v", err)
+	}
+}
+
+func TestQuerySelectorNull(t *testing.T) {
+	f := newFakeSidecar()
+	f.handle("page.querySelector", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]interface{}{"elementId": nil}, nil
+	})
+	f.handle("page.querySelectorAll", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]interface{}{"elementIds": []string{"e1", "e2"}}, nil
+	})
+	f.handle("element.getAttribute", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]interface{}{"value": "/next"}, nil
+	})
+	e := f.start(t, "puppeteer")
+
+	page, _ := e.NewPage(context.Background())
+	el, err := page.QuerySelector(".missing")
+	if err != nil || el != nil {
+		t.Fatalf("expected nil element, got %v, %v", el, err)
+	}
+
+	all, err := page.QuerySelectorAll("a")
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected 2 elements, got %d, %v", len(all), err)
+	}
+	href, err := all[1].GetAttribute("href")
+	if err != nil || href != "/next" {
+		t.Fatalf("expected href '/next', got %q, %v", href, err)
+	}
+	if got := f.requested("element.getAttribute"); !strings.Contains(string(got[0].Params), `"elementId":"e2"`) {
+		t.Errorf("expected attribute request for e2, got %s", got[0].Params)
+	}
+
+	f.handle("element.querySelectorAll", func(json.RawMessage) (interface{}, *RPCError) {
+		return map[string]interface{}{"elementIds": []string{"e3"}}, nil
+	})
+	inner, err := all[0].(engine.ElementQuerier).QuerySelectorAll("span")
+	if err != nil || len(inner) != 1 {
+		t.Fatalf("expected 1 descendant, got %d, %v", len(inner), err)
+	}
+	if got := f.requested("element.querySelectorAll"); !strings.Contains(string(got[0].Params), `"elementId":"e1"`) {
+		t.Errorf("expected a query from e1, got %s", got[0].Params)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	f := newFakeSidecar()
+	e := f.start(t, "puppeteer")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := e.call(ctx, "hang", "hang.forever", 0, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("call did not honor its timeout")
+	}
+}
+
+func TestConfigConformance(t *testing.T) {
+	f := newFakeSidecar()
+	want := enginetest.FullConfig()
+	f.startWithConfig(t, "puppeteer", want)
+
+	launches := f.requested("launch")
+	if len(launches) != 1 {
+		t.Fatalf("expected one launch request, got %d", len(launches))
+	}
+	var params struct {
+		Config engine.Config `json:"config"`
+	}
+	if err := json.Unmarshal(launches[0].Params, &params); err != nil {
+		t.Fatalf("invalid launch params: %v", err)
+	}
+	// The sidecar applies each field itself, so every one must arrive intact.
+	if !reflect.DeepEqual(params.Config, want) {
+		t.Errorf("launch config mismatch:\n got  %+v\n want %+v", params.Config, want)
+	}
+}
+
+func TestRuntimeDir(t *testing.T) {
+	t.Setenv("PHANTOM_RUNTIME_DIR", "")
+	t.Cleanup(func() { SetRuntimeDir("runtime") })
+	if got := RuntimeDir(); got != "runtime" {
+		t.Errorf("expected ./runtime by default, got %s", got)
+	}
+	SetRuntimeDir("/srv/project/runtime")
+	if got := RuntimeDir(); got != "/srv/project/runtime" {
+		t.Errorf("expected the directory set, got %s", got)
+	}
+	t.Setenv("PHANTOM_RUNTIME_DIR", "/opt/runtime")
+	if got := RuntimeDir(); got != "/opt/runtime" {
+		t.Errorf("expected PHANTOM_RUNTIME_DIR to win, got %s", got)
+	}
+}
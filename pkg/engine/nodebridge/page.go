@@ -0,0 +1,253 @@
+package nodebridge
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// Page is a browser page owned by the sidecar and addressed by its ID
+type Page struct {
+	engine    *Engine
+	id        string
+	closeOnce sync.Once
+}
+
+// call invokes a page.* method with the page ID merged into params
+func (p *Page) call(operation, method string, timeout time.Duration, params map[string]interface{}, result interface{}) error {
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["pageId"] = p.id
+	return p.engine.call(context.Background(), operation, "page."+method, timeout, params, result)
+}
+
+func (p *Page) value(operation, method string) (string, error) {
+	var res struct {
+		Value string `json:"value"`
+	}
+	err := p.call(operation, method, 0, nil, &res)
+	return res.Value, err
+}
+
+func (p *Page) Title() (string, error)   { return p.value("title", "title") }
+func (p *Page) URL() (string, error)     { return p.value("url", "url") }
+func (p *Page) Content() (string, error) { return p.value("content", "content") }
+
+// withTimeout fills in the configured default so the sidecar applies it
+func (p *Page) withTimeout(options *engine.NavigationOptions) *engine.NavigationOptions {
+	opts := engine.NavigationOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = p.engine.config.Timeout
+	}
+	return &opts
+}
+
+func (p *Page) Navigate(url string, options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	options = p.withTimeout(options)
+	params := map[string]interface{}{"url": url, "options": options}
+	return p.call("navigate", "navigate", options.Timeout, params, nil)
+}
+
+func (p *Page) Reload(options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	options = p.withTimeout(options)
+	return p.call("reload", "reload", options.Timeout, map[string]interface{}{"options": options}, nil)
+}
+
+func (p *Page) GoBack() error    { return p.call("go back", "goBack", 0, nil, nil) }
+func (p *Page) GoForward() error { return p.call("go forward", "goForward", 0, nil, nil) }
+
+func (p *Page) element(res elementResult) engine.ElementHandle {
+	if res.ElementID == nil {
+		return nil
+	}
+	return &Element{page: p, id: *res.ElementID}
+}
+
+type elementResult struct {
+	ElementID *string `json:"elementId"`
+}
+
+// QuerySelector returns the first matching element, or nil when none matches
+func (p *Page) QuerySelector(selector string) (engine.ElementHandle, error) {
+	var res elementResult
+	if err := p.call("query selector", "querySelector", 0, map[string]interface{}{"selector": selector}, &res); err != nil {
+		return nil, err
+	}
+	return p.element(res), nil
+}
+
+func (p *Page) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	var res struct {
+		ElementIDs []string `json:"elementIds"`
+	}
+	if err := p.call("query selector all", "querySelectorAll", 0, map[string]interface{}{"selector": selector}, &res); err != nil {
+		return nil, err
+	}
+	elements := make([]engine.ElementHandle, len(res.ElementIDs))
+	for i, id := range res.ElementIDs {
+		elements[i] = &Element{page: p, id: id}
+	}
+	return elements, nil
+}
+
+func (p *Page) waitOptions(options *engine.WaitOptions) *engine.WaitOptions {
+	opts := engine.WaitOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = p.engine.config.Timeout
+	}
+	return &opts
+}
+
+func (p *Page) WaitForSelector(selector string, options *engine.WaitOptions) (engine.ElementHandle, error) {
+	options = p.waitOptions(options)
+	var res elementResult
+	params := map[string]interface{}{"selector": selector, "options": options}
+	if err := p.call("wait for selector", "waitForSelector", options.Timeout, params, &res); err != nil {
+		return nil, err
+	}
+	return p.element(res), nil
+}
+
+// ExecuteScript evaluates script as an expression. Puppeteer and Playwright
+// both await a promise it returns, so it is the same as ExecuteScriptAsync.
+func (p *Page) ExecuteScript(script string) (interface{}, error) {
+	var res struct {
+		Value interface{} `json:"value"`
+	}
+	err := p.call("execute script", "evaluate", 0, map[string]interface{}{"script": script}, &res)
+	return res.Value, err
+}
+
+// ExecuteScriptAsync evaluates script and waits for the promise it returns
+func (p *Page) ExecuteScriptAsync(script string) (interface{}, error) {
+	return p.ExecuteScript(script)
+}
+
+func (p *Page) Click(selector string) error {
+	return p.call("click", "click", 0, map[string]interface{}{"selector": selector}, nil)
+}
+
+func (p *Page) Type(selector string, text string) error {
+	return p.call("type", "type", 0, map[string]interface{}{"selector": selector, "text": text}, nil)
+}
+
+func (p *Page) Fill(selector string, text string) error {
+	return p.call("fill", "fill", 0, map[string]interface{}{"selector": selector, "text": text}, nil)
+}
+
+func (p *Page) Select(selector string, values ...string) error {
+	if values == nil {
+		values = []string{}
+	}
+	return p.call("select", "select", 0, map[string]interface{}{"selector": selector, "values": values}, nil)
+}
+
+// Screenshot asks the sidecar to write the capture straight to options.Path,
+// with the format resolved so it applies the quality to .jpg and .webp
+// files too
+func (p *Page) Screenshot(options engine.ScreenshotOptions) error {
+	path, err := filepath.Abs(options.Path)
+	if err != nil {
+		return engine.NewEngineError(p.engine.driver, "screenshot", "invalid screenshot path", err)
+	}
+	options.Path = path
+	options.Format = options.ResolvedFormat()
+	if options.Format == "webp" && p.engine.driver == "playwright" {
+		return engine.NewEngineError(p.engine.driver, "screenshot", "Playwright cannot produce WebP screenshots", nil)
+	}
+	return p.call("screenshot", "screenshot", 0, map[string]interface{}{"options": options}, nil)
+}
+
+// PDF sends the options with the paper size resolved and the header and
+// footer templates filled in, so Puppeteer and Playwright print alike
+func (p *Page) PDF(options engine.PDFOptions) error {
+	path, err := filepath.Abs(options.Path)
+	if err != nil {
+		return engine.NewEngineError(p.engine.driver, "pdf", "invalid PDF path", err)
+	}
+	options.Path = path
+	paper, err := options.Paper()
+	if err != nil {
+		return engine.NewEngineError(p.engine.driver, "pdf", "invalid paper size", err)
+	}
+	options.Format, options.Width, options.Height = "", paper.Width, paper.Height
+	_, options.HeaderTemplate, options.FooterTemplate = options.HeaderFooter()
+	return p.call("pdf", "pdf", 0, map[string]interface{}{"options": options}, nil)
+}
+
+func (p *Page) WaitForNavigation(options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	options = p.withTimeout(options)
+	return p.call("wait for navigation", "waitForNavigation", options.Timeout, map[string]interface{}{"options": options}, nil)
+}
+
+func (p *Page) WaitForTimeout(timeout time.Duration) error {
+	return p.call("wait for timeout", "waitForTimeout", timeout, map[string]interface{}{"timeout": timeout}, nil)
+}
+
+func (p *Page) WaitForFunction(pageFunction string, options *engine.WaitOptions) error {
+	options = p.waitOptions(options)
+	params := map[string]interface{}{"script": pageFunction, "options": options}
+	return p.call("wait for function", "waitForFunction", options.Timeout, params, nil)
+}
+
+func (p *Page) GetCookies() ([]engine.Cookie, error) {
+	var res struct {
+		Cookies []engine.Cookie `json:"cookies"`
+	}
+	err := p.call("get cookies", "cookies", 0, nil, &res)
+	return res.Cookies, err
+}
+
+func (p *Page) SetCookies(cookies []engine.Cookie) error {
+	return p.call("set cookies", "setCookies", 0, map[string]interface{}{"cookies": cookies}, nil)
+}
+
+func (p *Page) ClearCookies() error {
+	return p.call("clear cookies", "clearCookies", 0, nil, nil)
+}
+
+func (p *Page) SetViewport(viewport engine.ViewportConfig) error {
+	return p.call("set viewport", "setViewport", 0, map[string]interface{}{"viewport": viewport}, nil)
+}
+
+func (p *Page) GetMetrics() (map[string]interface{}, error) {
+	var res struct {
+		Metrics map[string]interface{} `json:"metrics"`
+	}
+	err := p.call("get metrics", "metrics", 0, nil, &res)
+	return res.Metrics, err
+}
+
+func (p *Page) EmulateDevice(device engine.Device) error {
+	return p.call("emulate device", "emulate", 0, map[string]interface{}{"device": device}, nil)
+}
+
+// Close closes the page. It is safe to call concurrently, as the engine
+// and a page's user may both close it; calls after the first do nothing.
+func (p *Page) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		p.engine.removePage(p)
+		err = p.call("close", "close", 0, nil, nil)
+	})
+	return err
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory creates a new, uninitialized engine instance
+type Factory func() Engine
+
+// Probe reports whether a driver can run on the current machine
+type Probe func() EngineStatus
+
+// EngineStatus describes the availability of a registered driver
+type EngineStatus struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Path      string `json:"path,omitempty"`  // where the driver's runtime was found
+	Error     string `json:"error,omitempty"` // why the driver is unavailable, with a fix hint
+}
+
+// Driver describes an engine implementation that can be looked up by name
+type Driver struct {
+	Name        string  // unique name used with --engine and in config files
+	Description string  // one-line summary shown by `phantom-vite engines`
+	New         Factory // nil for integrations that cannot drive a page
+	Probe       Probe   // capability check used by `doctor` and before launch
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+// Register makes a driver available by name. It panics if the name is empty,
+// the probe is missing, or a driver with the same name is already registered,
+// so it is meant to be called from package init functions.
+func Register(driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if driver.Name == "" {
+		panic("engine: Register called with an empty driver name")
+	}
+	if driver.Probe == nil {
+		panic("engine: Register called without a probe for driver " + driver.Name)
+	}
+	if _, dup := drivers[driver.Name]; dup {
+		panic("engine: Register called twice for driver " + driver.Name)
+	}
+	drivers[driver.Name] = driver
+}
+
+// Get returns the driver registered under name
+func Get(name string) (Driver, error) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	driver, ok := drivers[name]
+	if !ok {
+		return Driver{}, NewEngineError(name, "lookup", "unknown engine", nil)
+	}
+	return driver, nil
+}
+
+// List returns all registered drivers sorted by name
+func List() []Driver {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	list := make([]Driver, 0, len(drivers))
+	for _, driver := range drivers {
+		list = append(list, driver)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Status runs the driver's probe and fills in the driver name
+func (d Driver) Status() EngineStatus {
+	status := d.Probe()
+	status.Name = d.Name
+	return status
+}
+
+// Launch probes the driver, creates an engine and initializes it with config
+func (d Driver) Launch(config Config) (Engine, error) {
+	if d.New == nil {
+		return nil, NewEngineError(d.Name, "launch", "driver does not support page automation", nil)
+	}
+	if status := d.Status(); !status.Available {
+		return nil, NewEngineError(d.Name, "launch", fmt.Sprintf("engine is not available: %s", status.Error), nil)
+	}
+
+	eng := d.New()
+	if err := eng.Initialize(config); err != nil {
+		return nil, err
+	}
+	return eng, nil
+}
+
+// Open looks up a driver by name and launches it with config
+func Open(name string, config Config) (Engine, error) {
+	driver, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return driver.Launch(config)
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func withCleanRegistry(t *testing.T) {
+	driversMu.Lock()
+	saved := drivers
+	drivers = make(map[string]Driver)
+	driversMu.Unlock()
+
+	t.Cleanup(func() {
+		driversMu.Lock()
+		drivers = saved
+		driversMu.Unlock()
+	})
+}
+
+func availableProbe() EngineStatus {
+	return EngineStatus{Available: true, Path: "test"}
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	withCleanRegistry(t)
+
+	Register(Driver{Name: "fake", Description: "fake driver", Probe: availableProbe})
+
+	driver, err := Get("fake")
+	if err != nil {
+		t.Fatalf("expected driver to be registered, got error: %v", err)
+	}
+	if driver.Description != "fake driver" {
+		t.Errorf("expected description 'fake driver', got %q", driver.Description)
+	}
+
+	_, err = Get("missing")
+	var engineErr *EngineError
+	if !errors.As(err, &engineErr) {
+		t.Fatalf("expected *EngineError for unknown driver, got %v", err)
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	withCleanRegistry(t)
+
+	Register(Driver{Name: "fake", Probe: availableProbe})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected duplicate registration to panic")
+		}
+	}()
+	Register(Driver{Name: "fake", Probe: availableProbe})
+}
+
+func TestListIsSorted(t *testing.T) {
+	withCleanRegistry(t)
+
+	Register(Driver{Name: "selenium", Probe: availableProbe})
+	Register(Driver{Name: "cdp", Probe: availableProbe})
+	Register(Driver{Name: "puppeteer", Probe: availableProbe})
+
+	list := List()
+	if len(list) != 3 {
+		t.Fatalf("expected 3 drivers, got %d", len(list))
+	}
+	for i, want := range []string{"cdp", "puppeteer", "selenium"} {
+		if list[i].Name != want {
+			t.Errorf("expected driver %d to be %s, got %s", i, want, list[i].Name)
+		}
+	}
+}
+
+func TestLaunchUnavailableDriver(t *testing.T) {
+	withCleanRegistry(t)
+
+	created := false
+	Register(Driver{
+		Name: "broken",
+		New:  func() Engine { created = true; return nil },
+		Probe: func() EngineStatus {
+			return EngineStatus{Available: false, Error: "not installed"}
+		},
+	})
+
+	if _, err := Open("broken", DefaultConfig()); err == nil {
+		t.Fatal("expected launch of unavailable driver to fail")
+	}
+	if created {
+		t.Errorf("factory should not be called when the probe fails")
+	}
+}
+
+func TestStatusFillsName(t *testing.T) {
+	driver := Driver{Name: "fake", Probe: availableProbe}
+	if status := driver.Status(); status.Name != "fake" {
+		t.Errorf("expected status name 'fake', got %q", status.Name)
+	}
+}
@@ -0,0 +1,101 @@
+package webdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// elementKey is the W3C web element identifier key
+const elementKey = "element-6066-11e4-a52e-4f735466cecf"
+
+// Error is an error reported by the remote end, e.g. "no such element"
+type Error struct {
+	Status     int    `json:"-"`
+	Code       string `json:"error"`
+	Message    string `json:"message"`
+	Stacktrace string `json:"stacktrace,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Code + ": " + e.Message
+	}
+	return e.Code
+}
+
+// Timeout reports whether the remote end gave up waiting, e.g. for a page
+// to load
+func (e *Error) Timeout() bool {
+	return e.Code == "timeout"
+}
+
+// client sends W3C WebDriver commands to a remote end
+type client struct {
+	baseURL string
+	http    *http.Client
+}
+
+func newClient(baseURL string) *client {
+	return &client{baseURL: strings.TrimSuffix(baseURL, "/"), http: &http.Client{}}
+}
+
+// do sends a command and decodes the "value" member of the response into
+// result, which may be nil.
+func (c *client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else if method == http.MethodPost {
+		// The spec requires a JSON object body on every POST.
+		reader = strings.NewReader("{}")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("invalid response from %s %s (HTTP %d): %w", method, path, resp.StatusCode, err)
+	}
+
+	if resp.StatusCode >= 400 {
+		wdErr := &Error{Status: resp.StatusCode}
+		if err := json.Unmarshal(envelope.Value, wdErr); err != nil || wdErr.Code == "" {
+			wdErr.Code = "unknown error"
+			wdErr.Message = strings.TrimSpace(string(data))
+		}
+		return wdErr
+	}
+
+	if result != nil && len(envelope.Value) > 0 {
+		return json.Unmarshal(envelope.Value, result)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package webdriver
+
+import (
+	"net/http"
+
+	"phantomvite/pkg/engine"
+)
+
+// Element is a W3C web element reference
+type Element struct {
+	page *Page
+	id   string
+}
+
+func (el *Element) call(operation, method, path string, body, result interface{}) error {
+	return el.page.call(operation, method, "/element/"+escapePath(el.id)+path, body, result)
+}
+
+func (el *Element) Click() error {
+	return el.call("click", http.MethodPost, "/click", nil, nil)
+}
+
+func (el *Element) Type(text string) error {
+	return el.call("type", http.MethodPost, "/value", map[string]string{"text": text}, nil)
+}
+
+// GetAttribute returns the attribute value, or "" when it is not set
+func (el *Element) GetAttribute(name string) (string, error) {
+	var value *string
+	if err := el.call("get attribute", http.MethodGet, "/attribute/"+escapePath(name), nil, &value); err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", nil
+	}
+	return *value, nil
+}
+
+func (el *Element) GetProperty(name string) (interface{}, error) {
+	var value interface{}
+	err := el.call("get property", http.MethodGet, "/property/"+escapePath(name), nil, &value)
+	return value, err
+}
+
+func (el *Element) IsVisible() (bool, error) {
+	var displayed bool
+	err := el.call("is visible", http.MethodGet, "/displayed", nil, &displayed)
+	return displayed, err
+}
+
+// QuerySelectorAll returns the descendants of the element matching selector
+func (el *Element) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	var refs []elementRef
+	if err := el.call("query selector all", http.MethodPost, "/elements", map[string]string{"using": "css selector", "value": selector}, &refs); err != nil {
+		return nil, err
+	}
+	elements := make([]engine.ElementHandle, len(refs))
+	for i, ref := range refs {
+		elements[i] = el.page.element(ref)
+	}
+	return elements, nil
+}
+
+// BoundingBox returns the element rectangle relative to the document
+func (el *Element) BoundingBox() (*engine.BoundingBox, error) {
+	var box engine.BoundingBox
+	if err := el.call("bounding box", http.MethodGet, "/rect", nil, &box); err != nil {
+		return nil, err
+	}
+	return &box, nil
+}
@@ -0,0 +1,448 @@
+// Package webdriver implements engine.Engine as a W3C WebDriver client, so
+// any chromedriver, geckodriver or Selenium Grid endpoint can drive pages
+// without a Python or Java runtime.
+package webdriver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+const engineName = "selenium"
+
+// URLEnv names the environment variable pointing at a running remote end
+const URLEnv = "PHANTOM_WEBDRIVER_URL"
+
+// driverBinaries are the local remote ends that can be spawned, in order of
+// preference, with the browser each one drives.
+var driverBinaries = []struct{ binary, browser string }{
+	{"chromedriver", "chrome"},
+	{"geckodriver", "firefox"},
+	{"msedgedriver", "MicrosoftEdge"},
+}
+
+func init() {
+	engine.Register(engine.Driver{
+		Name:        engineName,
+		Description: "Go, W3C WebDriver client (chromedriver, geckodriver, Selenium Grid)",
+		New:         func() engine.Engine { return New() },
+		Probe: func() engine.EngineStatus {
+			if url := os.Getenv(URLEnv); url != "" {
+				return engine.EngineStatus{Available: true, Path: url}
+			}
+			if path, _, err := findDriver(); err == nil {
+				return engine.EngineStatus{Available: true, Path: path}
+			}
+			return engine.EngineStatus{Error: "No WebDriver found. Install chromedriver or set " + URLEnv}
+		},
+	})
+}
+
+func findDriver() (path, browser string, err error) {
+	for _, d := range driverBinaries {
+		if path, err := exec.LookPath(d.binary); err == nil {
+			return path, d.browser, nil
+		}
+	}
+	return "", "", fmt.Errorf("no WebDriver executable found on PATH")
+}
+
+// Engine drives one WebDriver session. Each Page is a window handle within
+// that session; the engine switches windows as pages are used.
+type Engine struct {
+	remoteURL string
+	browser   string
+	config    engine.Config
+
+	driverCmd *exec.Cmd
+	client    *client
+	sessionID string
+
+	mu      sync.Mutex
+	pages   []*Page
+	current string // window handle the session is focused on
+}
+
+// New returns an engine that uses PHANTOM_WEBDRIVER_URL when set and
+// otherwise spawns a local chromedriver or geckodriver on Initialize.
+func New() *Engine {
+	return &Engine{remoteURL: os.Getenv(URLEnv)}
+}
+
+// NewRemote returns an engine that talks to the remote end at url
+func NewRemote(url string) *Engine {
+	return &Engine{remoteURL: url}
+}
+
+func (e *Engine) Name() string { return engineName }
+
+func (e *Engine) Initialize(config engine.Config) error {
+	if config.Timeout <= 0 {
+		config.Timeout = engine.DefaultConfig().Timeout
+	}
+	e.config = config
+
+	ctx, cancel := e.timeout()
+	defer cancel()
+
+	url := e.remoteURL
+	if url == "" {
+		spawned, err := e.spawnDriver(ctx)
+		if err != nil {
+			return engine.NewEngineError(engineName, "initialize", "failed to start WebDriver", err)
+		}
+		url = spawned
+	}
+	e.client = newClient(url)
+
+	var session struct {
+		SessionID    string                 `json:"sessionId"`
+		Capabilities map[string]interface{} `json:"capabilities"`
+	}
+	if err := e.client.do(ctx, http.MethodPost, "/session", e.newSessionRequest(), &session); err != nil {
+		e.stopDriver()
+		return engine.NewEngineError(engineName, "initialize", "failed to create session", err)
+	}
+	e.sessionID = session.SessionID
+	if name, ok := session.Capabilities["browserName"].(string); ok && name != "" {
+		e.browser = name
+	}
+	if len(config.ExtraHeaders) > 0 && e.cdpVendor() == "" {
+		e.Close()
+		return engine.NewEngineError(engineName, "initialize", "extra HTTP headers need a Chromium-based browser over WebDriver", nil)
+	}
+
+	timeouts := map[string]int64{
+		"pageLoad": config.Timeout.Milliseconds(),
+		"script":   config.Timeout.Milliseconds(),
+	}
+	if err := e.do(ctx, http.MethodPost, "/timeouts", timeouts, nil); err != nil {
+		e.Close()
+		return engine.NewEngineError(engineName, "initialize", "failed to set timeouts", err)
+	}
+
+	var handle string
+	if err := e.do(ctx, http.MethodGet, "/window", nil, &handle); err != nil {
+		e.Close()
+		return engine.NewEngineError(engineName, "initialize", "failed to read window handle", err)
+	}
+	e.current = handle
+	// The session's initial window becomes the first page handed out.
+	e.pages = append(e.pages, &Page{engine: e, handle: handle, unused: true})
+	return nil
+}
+
+// newSessionRequest builds the capabilities for the configured browser
+func (e *Engine) newSessionRequest() map[string]interface{} {
+	browser := e.browser
+	if browser == "" {
+		browser = "chrome"
+	}
+
+	var args []string
+	if e.config.Headless {
+		args = append(args, "--headless")
+	}
+	if e.config.Viewport.Width > 0 && e.config.Viewport.Height > 0 {
+		args = append(args, fmt.Sprintf("--window-size=%d,%d", e.config.Viewport.Width, e.config.Viewport.Height))
+	}
+
+	options := map[string]interface{}{}
+	if e.config.ExecutablePath != "" {
+		options["binary"] = e.config.ExecutablePath
+	}
+
+	caps := map[string]interface{}{"browserName": browser}
+	switch browser {
+	case "firefox":
+		if e.config.UserAgent != "" {
+			options["prefs"] = map[string]interface{}{"general.useragent.override": e.config.UserAgent}
+		}
+		options["args"] = append(args, e.config.Args...)
+		caps["moz:firefoxOptions"] = options
+	default:
+		if e.config.UserAgent != "" {
+			args = append(args, "--user-agent="+e.config.UserAgent)
+		}
+		if browser != "MicrosoftEdge" {
+			args = append(args, "--no-sandbox", "--disable-dev-shm-usage")
+		}
+		options["args"] = append(args, e.config.Args...)
+		if browser == "MicrosoftEdge" {
+			caps["ms:edgeOptions"] = options
+		} else {
+			caps["goog:chromeOptions"] = options
+		}
+	}
+	if e.config.Proxy != "" {
+		caps["proxy"] = proxyCapability(e.config.Proxy)
+	}
+	return map[string]interface{}{"capabilities": map[string]interface{}{"alwaysMatch": caps}}
+}
+
+// proxyCapability converts a proxy URL into the W3C manual proxy capability,
+// which takes host:port without a scheme.
+func proxyCapability(proxy string) map[string]interface{} {
+	host, scheme := proxy, "http"
+	if u, err := url.Parse(proxy); err == nil && u.Host != "" {
+		host, scheme = u.Host, u.Scheme
+	}
+	switch scheme {
+	case "socks4", "socks5":
+		return map[string]interface{}{"proxyType": "manual", "socksProxy": host, "socksVersion": int(scheme[5] - '0')}
+	default:
+		return map[string]interface{}{"proxyType": "manual", "httpProxy": host, "sslProxy": host}
+	}
+}
+
+// cdpVendor is the vendor prefix of the Chromium DevTools passthrough
+// command, or "" when the session's browser has none.
+func (e *Engine) cdpVendor() string {
+	switch strings.ToLower(e.browser) {
+	case "chrome", "chromium":
+		return "goog"
+	case "msedge", "microsoftedge":
+		return "ms"
+	}
+	return ""
+}
+
+// spawnDriver starts a local WebDriver executable on a free port and waits
+// until it reports ready.
+func (e *Engine) spawnDriver(ctx context.Context) (string, error) {
+	path, browser, err := findDriver()
+	if err != nil {
+		return "", err
+	}
+	e.browser = browser
+
+	port, err := freePort()
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(path, "--port="+strconv.Itoa(port))
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	e.driverCmd = cmd
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	c := newClient(url)
+	for {
+		var status struct {
+			Ready bool `json:"ready"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/status", nil, &status); err == nil && status.Ready {
+			return url, nil
+		}
+		select {
+		case <-ctx.Done():
+			e.stopDriver()
+			return "", fmt.Errorf("WebDriver did not become ready: %w", ctx.Err())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+func (e *Engine) stopDriver() {
+	if e.driverCmd != nil && e.driverCmd.Process != nil {
+		e.driverCmd.Process.Kill()
+		e.driverCmd.Wait()
+	}
+	e.driverCmd = nil
+}
+
+func (e *Engine) timeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), e.config.Timeout)
+}
+
+// do sends a command scoped to the current session
+func (e *Engine) do(ctx context.Context, method, path string, body, result interface{}) error {
+	if e.client == nil || e.sessionID == "" {
+		return fmt.Errorf("no WebDriver session")
+	}
+	return e.client.do(ctx, method, "/session/"+e.sessionID+path, body, result)
+}
+
+func (e *Engine) Close() error {
+	var err error
+	if e.sessionID != "" {
+		ctx, cancel := e.timeout()
+		err = e.client.do(ctx, http.MethodDelete, "/session/"+e.sessionID, nil, nil)
+		cancel()
+		e.sessionID = ""
+	}
+	e.mu.Lock()
+	e.pages = nil
+	e.mu.Unlock()
+	e.stopDriver()
+	if err != nil {
+		return engine.NewEngineError(engineName, "close", "failed to delete session", err)
+	}
+	return nil
+}
+
+func (e *Engine) Navigate(ctx context.Context, url string) (engine.Page, error) {
+	page, err := e.newPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := page.Navigate(url, nil); err != nil {
+		page.Close()
+		return nil, err
+	}
+	return page, nil
+}
+
+func (e *Engine) NewPage(ctx context.Context) (engine.Page, error) {
+	return e.newPage(ctx)
+}
+
+// newPage hands out the session's initial window first, then opens tabs
+func (e *Engine) newPage(ctx context.Context) (*Page, error) {
+	page, err := e.claimPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.config.ExtraHeaders) > 0 {
+		if err := page.setExtraHeaders(e.config.ExtraHeaders); err != nil {
+			page.Close()
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (e *Engine) claimPage(ctx context.Context) (*Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client == nil {
+		return nil, engine.NewEngineError(engineName, "new page", "engine is not initialized", nil)
+	}
+	for _, p := range e.pages {
+		if p.unused {
+			p.unused = false
+			return p, nil
+		}
+	}
+
+	var window struct {
+		Handle string `json:"handle"`
+	}
+	if err := e.do(ctx, http.MethodPost, "/window/new", map[string]string{"type": "tab"}, &window); err != nil {
+		return nil, engine.NewEngineError(engineName, "new page", "failed to open window", err)
+	}
+	page := &Page{engine: e, handle: window.Handle}
+	e.pages = append(e.pages, page)
+	return page, nil
+}
+
+// isLastPage reports whether page holds the session's only window
+func (e *Engine) isLastPage(page *Page) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.pages) == 1 && e.pages[0] == page
+}
+
+// keepWindow replaces a closed page with an unused one on the same window,
+// for newPage to hand out again
+func (e *Engine) keepWindow(page *Page) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, p := range e.pages {
+		if p == page {
+			e.pages[i] = &Page{engine: e, handle: page.handle, unused: true}
+			return
+		}
+	}
+}
+
+func (e *Engine) removePage(page *Page) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, p := range e.pages {
+		if p == page {
+			e.pages = append(e.pages[:i], e.pages[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Engine) GetPages(ctx context.Context) ([]engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var pages []engine.Page
+	for _, p := range e.pages {
+		if !p.unused {
+			pages = append(pages, p)
+		}
+	}
+	return pages, nil
+}
+
+// Screenshot captures the most recently opened page
+func (e *Engine) Screenshot(ctx context.Context, options engine.ScreenshotOptions) error {
+	pages, _ := e.GetPages(ctx)
+	if len(pages) == 0 {
+		return engine.NewEngineError(engineName, "screenshot", "no page has been opened", nil)
+	}
+	return pages[len(pages)-1].Screenshot(options)
+}
+
+// SetUserAgent overrides the user agent of every open page. WebDriver has no
+// command for it, so this needs a Chromium-based browser.
+func (e *Engine) SetUserAgent(userAgent string) error {
+	e.config.UserAgent = userAgent
+	pages, _ := e.GetPages(context.Background())
+	for _, p := range pages {
+		if err := p.(*Page).setUserAgent(userAgent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetExtraHeaders sets headers on every open page and on pages opened later.
+// Like SetUserAgent it relies on the Chromium DevTools passthrough.
+func (e *Engine) SetExtraHeaders(headers map[string]string) error {
+	e.config.ExtraHeaders = headers
+	pages, _ := e.GetPages(context.Background())
+	for _, p := range pages {
+		if err := p.(*Page).setExtraHeaders(headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Engine) SetViewport(viewport engine.ViewportConfig) error {
+	e.config.Viewport = viewport
+	pages, _ := e.GetPages(context.Background())
+	for _, p := range pages {
+		if err := p.SetViewport(viewport); err != nil {
+			return err
+		}
+	}
+	return nil
+}
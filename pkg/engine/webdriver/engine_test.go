@@ -0,0 +1,448 @@
+package webdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// fakeRemoteEnd is a minimal W3C WebDriver server answering from routes
+// keyed by "METHOD /path" relative to the session.
+type fakeRemoteEnd struct {
+	mu       sync.Mutex
+	routes   map[string]func(body string) (int, interface{})
+	requests []recordedRequest
+	server   *httptest.Server
+}
+
+func newFakeRemoteEnd(t *testing.T) *fakeRemoteEnd {
+	f := &fakeRemoteEnd{routes: make(map[string]func(string) (int, interface{}))}
+	f.route("POST /session", func(string) (int, interface{}) {
+		return 200, map[string]interface{}{"sessionId": "s1", "capabilities": map[string]string{"browserName": "chrome"}}
+	})
+	f.route("GET /window", func(string) (int, interface{}) { return 200, "w1" })
+
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/session/s1")
+		key := r.Method + " " + path
+
+		f.mu.Lock()
+		f.requests = append(f.requests, recordedRequest{Method: r.Method, Path: path, Body: string(data)})
+		handler := f.routes[key]
+		f.mu.Unlock()
+
+		status, value := 200, interface{}(nil)
+		if handler != nil {
+			status, value = handler(string(data))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{"value": value})
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeRemoteEnd) route(key string, h func(body string) (int, interface{})) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.routes[key] = h
+}
+
+func (f *fakeRemoteEnd) requested(method, path string) []recordedRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []recordedRequest
+	for _, r := range f.requests {
+		if r.Method == method && r.Path == path {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+func startEngine(t *testing.T, f *fakeRemoteEnd) *Engine {
+	t.Helper()
+	cfg := engine.DefaultConfig()
+	cfg.Timeout = 2 * time.Second
+	e := NewRemote(f.server.URL)
+	if err := e.Initialize(cfg); err != nil {
+		t.Fatalf("failed to initialize engine: %v", err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestSessionCapabilities(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	startEngine(t, f)
+
+	sessions := f.requested("POST", "/session")
+	if len(sessions) != 1 {
+		t.Fatalf("expected one new session request, got %d", len(sessions))
+	}
+	body := sessions[0].Body
+	if !strings.Contains(body, `"browserName":"chrome"`) || !strings.Contains(body, "--headless") {
+		t.Errorf("expected headless chrome capabilities, got %s", body)
+	}
+	if !strings.Contains(body, "--window-size=1920,1080") {
+		t.Errorf("expected viewport window size in capabilities, got %s", body)
+	}
+	if got := f.requested("POST", "/timeouts"); len(got) != 1 || !strings.Contains(got[0].Body, `"pageLoad":2000`) {
+		t.Errorf("expected page load timeout to be configured, got %+v", got)
+	}
+}
+
+func TestNavigateAndTitle(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("GET /title", func(string) (int, interface{}) { return 200, "Example Domain" })
+	e := startEngine(t, f)
+
+	page, err := e.Navigate(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	title, err := page.Title()
+	if err != nil || title != "Example Domain" {
+		t.Fatalf("expected title 'Example Domain', got %q, %v", title, err)
+	}
+
+	navs := f.requested("POST", "/url")
+	if len(navs) != 1 || !strings.Contains(navs[0].Body, "https://example.com") {
+		t.Errorf("expected navigation to example.com, got %+v", navs)
+	}
+	if got := f.requested("POST", "/window/new"); len(got) != 0 {
+		t.Errorf("first page should reuse the initial window, got %d new windows", len(got))
+	}
+}
+
+func TestQuerySelectorAndClick(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /element", func(body string) (int, interface{}) {
+		if strings.Contains(body, "#missing") {
+			return 404, map[string]string{"error": "no such element", "message": "Unable to locate element"}
+		}
+		return 200, map[string]string{elementKey: "el-1"}
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	el, err := page.QuerySelector("#missing")
+	if err != nil || el != nil {
+		t.Fatalf("expected no element and no error, got %v, %v", el, err)
+	}
+
+	if err := page.Click("#submit"); err != nil {
+		t.Fatalf("click failed: %v", err)
+	}
+	if got := f.requested("POST", "/element/el-1/click"); len(got) != 1 {
+		t.Errorf("expected click on el-1, got %d requests", len(got))
+	}
+
+	f.route("POST /element/el-1/elements", func(string) (int, interface{}) {
+		return 200, []map[string]string{{elementKey: "el-2"}, {elementKey: "el-3"}}
+	})
+	el, _ = page.QuerySelector("#list")
+	items, err := el.(engine.ElementQuerier).QuerySelectorAll("li")
+	if err != nil || len(items) != 2 {
+		t.Fatalf("expected 2 descendants, got %d, %v", len(items), err)
+	}
+	if got := f.requested("POST", "/element/el-1/elements"); len(got) != 1 || !strings.Contains(got[0].Body, `"value":"li"`) {
+		t.Errorf("expected a css selector query from el-1, got %+v", got)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /execute/sync", func(string) (int, interface{}) {
+		return 500, map[string]string{"error": "javascript error", "message": "nope is not defined"}
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	_, err := page.ExecuteScript("nope()")
+	var engineErr *engine.EngineError
+	if !errors.As(err, &engineErr) || engineErr.Engine != "selenium" {
+		t.Fatalf("expected selenium *EngineError, got %v", err)
+	}
+	var wdErr *Error
+	if !errors.As(err, &wdErr) || wdErr.Code != "javascript error" {
+		t.Errorf("expected WebDriver error code to be preserved, got %v", err)
+	}
+}
+
+func TestCookies(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("GET /cookie", func(string) (int, interface{}) {
+		return 200, []map[string]interface{}{{"name": "sid", "value": "abc", "domain": "example.com", "expiry": 1700000000}}
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	cookies, err := page.GetCookies()
+	if err != nil || len(cookies) != 1 || cookies[0].Expires != 1700000000 {
+		t.Fatalf("unexpected cookies %+v, %v", cookies, err)
+	}
+
+	if err := page.SetCookies([]engine.Cookie{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}); err != nil {
+		t.Fatalf("set cookies failed: %v", err)
+	}
+	if got := f.requested("POST", "/cookie"); len(got) != 2 {
+		t.Errorf("expected one request per cookie, got %d", len(got))
+	}
+}
+
+func TestScreenshotClipAndJPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 30; y++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	f := newFakeRemoteEnd(t)
+	f.route("GET /screenshot", func(string) (int, interface{}) {
+		return 200, base64.StdEncoding.EncodeToString(buf.Bytes())
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	path := filepath.Join(t.TempDir(), "clip.png")
+	err := page.Screenshot(engine.ScreenshotOptions{Path: path, Clip: &engine.ClipOptions{X: 5, Y: 5, Width: 10, Height: 8}})
+	if err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	clipped, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("screenshot is not a PNG: %v", err)
+	}
+	if b := clipped.Bounds(); b.Dx() != 10 || b.Dy() != 8 {
+		t.Errorf("expected 10x8 clip, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	jpegPath := filepath.Join(t.TempDir(), "shot.jpg")
+	if err := page.Screenshot(engine.ScreenshotOptions{Path: jpegPath, Quality: 50}); err != nil {
+		t.Fatalf("jpeg screenshot failed: %v", err)
+	}
+	data, _ = os.ReadFile(jpegPath)
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		t.Errorf("expected JPEG output")
+	}
+}
+
+func TestScreenshotOmitBackground(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	f := newFakeRemoteEnd(t)
+	f.route("GET /screenshot", func(string) (int, interface{}) {
+		return 200, base64.StdEncoding.EncodeToString(buf.Bytes())
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	path := filepath.Join(t.TempDir(), "shot.png")
+	if err := page.Screenshot(engine.ScreenshotOptions{Path: path, OmitBackground: true}); err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+	overrides := 0
+	for _, r := range f.requested("POST", "/goog/cdp/execute") {
+		if strings.Contains(r.Body, "Emulation.setDefaultBackgroundColorOverride") {
+			overrides++
+		}
+	}
+	if overrides != 2 {
+		t.Errorf("expected the background to be cleared and restored, got %d overrides", overrides)
+	}
+}
+
+func TestPDFPrintsInCentimeters(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /print", func(string) (int, interface{}) {
+		return 200, base64.StdEncoding.EncodeToString([]byte("%PDF-1.4 fake"))
+	})
+	e := startEngine(t, f)
+	page, _ := e.NewPage(context.Background())
+
+	path := filepath.Join(t.TempDir(), "page.pdf")
+	opts := engine.PDFOptions{Path: path, Landscape: true, Margin: &engine.PDFMargin{Top: 1}, PageRanges: "1-2, 5"}
+	if err := page.PDF(opts); err != nil {
+		t.Fatalf("pdf failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "%PDF-1.4 fake" {
+		t.Errorf("expected PDF bytes to be written, got %q", data)
+	}
+	prints := f.requested("POST", "/print")
+	if len(prints) != 1 {
+		t.Fatalf("expected 1 print request, got %d", len(prints))
+	}
+	for _, want := range []string{`"orientation":"landscape"`, `"width":21.59`, `"top":2.54`, `"pageRanges":["1-2","5"]`} {
+		if !strings.Contains(prints[0].Body, want) {
+			t.Errorf("expected %s in %s", want, prints[0].Body)
+		}
+	}
+
+	if err := page.PDF(engine.PDFOptions{Path: path, HeaderTemplate: "<p>h</p>"}); err == nil {
+		t.Errorf("expected header templates to be refused")
+	}
+}
+
+func TestSecondPageOpensWindowAndSwitches(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /window/new", func(string) (int, interface{}) {
+		return 200, map[string]string{"handle": "w2", "type": "tab"}
+	})
+	e := startEngine(t, f)
+
+	first, _ := e.NewPage(context.Background())
+	second, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatalf("second page failed: %v", err)
+	}
+
+	second.Title()
+	first.Title()
+
+	switches := f.requested("POST", "/window")
+	if len(switches) != 2 || !strings.Contains(switches[0].Body, "w2") || !strings.Contains(switches[1].Body, "w1") {
+		t.Errorf("expected switches to w2 then w1, got %+v", switches)
+	}
+}
+
+func TestConfigConformance(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	e := NewRemote(f.server.URL)
+	if err := e.Initialize(enginetest.FullConfig()); err != nil {
+		t.Fatalf("failed to initialize engine: %v", err)
+	}
+	defer e.Close()
+	if _, err := e.NewPage(context.Background()); err != nil {
+		t.Fatalf("new page failed: %v", err)
+	}
+
+	session := f.requested("POST", "/session")[0].Body
+	for _, want := range []string{
+		`"binary":"/opt/phantom/browser"`,
+		"--window-size=1366,768",
+		"--user-agent=PhantomConformance/1.0",
+		"--phantom-conformance-flag",
+		`"httpProxy":"127.0.0.1:3128"`,
+	} {
+		if !strings.Contains(session, want) {
+			t.Errorf("session capabilities missing %s: %s", want, session)
+		}
+	}
+	if strings.Contains(session, "--headless") {
+		t.Errorf("headless=false must not pass --headless: %s", session)
+	}
+	if got := f.requested("POST", "/timeouts"); len(got) != 1 || !strings.Contains(got[0].Body, `"pageLoad":7000`) {
+		t.Errorf("expected configured timeout, got %+v", got)
+	}
+
+	var headers bool
+	for _, r := range f.requested("POST", "/goog/cdp/execute") {
+		headers = headers || strings.Contains(r.Body, "X-Phantom-Conformance")
+	}
+	if !headers {
+		t.Errorf("expected extra headers to be sent to the new page")
+	}
+}
+
+func TestExtraHeadersNeedChromium(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /session", func(string) (int, interface{}) {
+		return 200, map[string]interface{}{"sessionId": "s1", "capabilities": map[string]string{"browserName": "firefox"}}
+	})
+	cfg := engine.DefaultConfig()
+	cfg.ExtraHeaders = map[string]string{"X-Test": "1"}
+
+	err := NewRemote(f.server.URL).Initialize(cfg)
+	if err == nil || !strings.Contains(err.Error(), "Chromium") {
+		t.Fatalf("expected extra headers to be rejected for firefox, got %v", err)
+	}
+}
+
+func TestClosingLastPageKeepsSession(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	f.route("POST /window/new", func(string) (int, interface{}) {
+		return 200, map[string]string{"handle": "w2", "type": "tab"}
+	})
+	e := startEngine(t, f)
+
+	first, _ := e.NewPage(context.Background())
+	second, _ := e.NewPage(context.Background())
+	if err := second.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if closes := f.requested("DELETE", "/window"); len(closes) != 1 {
+		t.Errorf("expected only the second window to be closed, got %+v", closes)
+	}
+	blanked := f.requested("POST", "/url")
+	if len(blanked) != 1 || !strings.Contains(blanked[0].Body, "about:blank") {
+		t.Errorf("expected the last window to be left on about:blank, got %+v", blanked)
+	}
+
+	again, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.(*Page).handle != "w1" || len(f.requested("POST", "/window/new")) != 1 {
+		t.Errorf("expected the kept window to be handed out again, got %s", again.(*Page).handle)
+	}
+	if pages, _ := e.GetPages(context.Background()); len(pages) != 1 {
+		t.Errorf("expected one page, got %d", len(pages))
+	}
+	if err := first.Close(); err != nil || len(f.requested("POST", "/url")) != 1 {
+		t.Errorf("expected closing a closed page to do nothing, got %v", err)
+	}
+}
+
+func TestNewPageReleasesWindowOnHeaderFailure(t *testing.T) {
+	f := newFakeRemoteEnd(t)
+	e := startEngine(t, f)
+	e.config.ExtraHeaders = map[string]string{"X-Test": "1"}
+	f.route("POST /goog/cdp/execute", func(string) (int, interface{}) {
+		return 500, map[string]string{"error": "unknown error", "message": "devtools unavailable"}
+	})
+
+	if _, err := e.NewPage(context.Background()); err == nil {
+		t.Fatal("expected the new page to fail")
+	}
+	if pages, _ := e.GetPages(context.Background()); len(pages) != 0 {
+		t.Errorf("expected the failed page to be released, got %d pages", len(pages))
+	}
+	f.route("POST /goog/cdp/execute", func(string) (int, interface{}) { return 200, nil })
+	page, err := e.NewPage(context.Background())
+	if err != nil || page.(*Page).handle != "w1" {
+		t.Errorf("expected the initial window to be handed out again, got %v", err)
+	}
+}
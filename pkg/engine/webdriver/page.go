@@ -0,0 +1,599 @@
+package webdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// defaultPolling is the interval used by selector and function waits
+const defaultPolling = 100 * time.Millisecond
+
+// Page is one window of the WebDriver session
+type Page struct {
+	engine *Engine
+	handle string
+	unused bool
+	closed bool
+}
+
+func (p *Page) fail(operation, message string, cause error) error {
+	return engine.NewEngineError(engineName, operation, message, cause)
+}
+
+// do focuses this page's window and sends a session command. Commands from
+// different pages are serialized because window focus is session state.
+func (p *Page) do(ctx context.Context, method, path string, body, result interface{}) error {
+	e := p.engine
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != p.handle {
+		if err := e.do(ctx, http.MethodPost, "/window", map[string]string{"handle": p.handle}, nil); err != nil {
+			return err
+		}
+		e.current = p.handle
+	}
+	return e.do(ctx, method, path, body, result)
+}
+
+func (p *Page) call(operation, method, path string, body, result interface{}) error {
+	ctx, cancel := p.engine.timeout()
+	defer cancel()
+	if err := p.do(ctx, method, path, body, result); err != nil {
+		return p.fail(operation, "command failed", err)
+	}
+	return nil
+}
+
+// devtools runs a Chromium DevTools command in this page through the
+// driver's vendor passthrough endpoint.
+func (p *Page) devtools(operation, command string, params interface{}) error {
+	vendor := p.engine.cdpVendor()
+	if vendor == "" {
+		return p.fail(operation, p.engine.browser+" does not support this over WebDriver", nil)
+	}
+	body := map[string]interface{}{"cmd": command, "params": params}
+	return p.call(operation, http.MethodPost, "/"+vendor+"/cdp/execute", body, nil)
+}
+
+func (p *Page) setUserAgent(userAgent string) error {
+	return p.devtools("set user agent", "Network.setUserAgentOverride", map[string]string{"userAgent": userAgent})
+}
+
+func (p *Page) setExtraHeaders(headers map[string]string) error {
+	if err := p.devtools("set extra headers", "Network.enable", map[string]interface{}{}); err != nil {
+		return err
+	}
+	return p.devtools("set extra headers", "Network.setExtraHTTPHeaders", map[string]interface{}{"headers": headers})
+}
+
+func (p *Page) Title() (string, error) {
+	var title string
+	err := p.call("title", http.MethodGet, "/title", nil, &title)
+	return title, err
+}
+
+func (p *Page) URL() (string, error) {
+	var u string
+	err := p.call("url", http.MethodGet, "/url", nil, &u)
+	return u, err
+}
+
+func (p *Page) Content() (string, error) {
+	var source string
+	err := p.call("content", http.MethodGet, "/source", nil, &source)
+	return source, err
+}
+
+func (p *Page) navigationContext(options *engine.NavigationOptions) (context.Context, context.CancelFunc) {
+	timeout := p.engine.config.Timeout
+	if options != nil && options.Timeout > 0 {
+		timeout = options.Timeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// navigate runs a navigation command. WebDriver returns once the document
+// has loaded, which covers "load" and "domcontentloaded"; network idleness
+// cannot be observed over the protocol.
+func (p *Page) navigate(operation, path string, body interface{}, options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := p.navigationContext(options)
+	defer cancel()
+	if err := p.do(ctx, http.MethodPost, path, body, nil); err != nil {
+		return p.fail(operation, "navigation failed", err)
+	}
+	if options != nil && options.WaitForSelector != "" {
+		_, err := p.WaitForSelector(options.WaitForSelector, &engine.WaitOptions{Timeout: engine.Remaining(ctx)})
+		return err
+	}
+	return nil
+}
+
+func (p *Page) Navigate(url string, options *engine.NavigationOptions) error {
+	return p.navigate("navigate", "/url", map[string]string{"url": url}, options)
+}
+
+func (p *Page) Reload(options *engine.NavigationOptions) error {
+	return p.navigate("reload", "/refresh", nil, options)
+}
+
+func (p *Page) GoBack() error    { return p.navigate("go back", "/back", nil, nil) }
+func (p *Page) GoForward() error { return p.navigate("go forward", "/forward", nil, nil) }
+
+type elementRef map[string]string
+
+func (p *Page) element(ref elementRef) *Element {
+	return &Element{page: p, id: ref[elementKey]}
+}
+
+func isNoSuchElement(err error) bool {
+	var wdErr *Error
+	return errors.As(err, &wdErr) && wdErr.Code == "no such element"
+}
+
+// QuerySelector returns the first matching element, or nil when none matches
+func (p *Page) QuerySelector(selector string) (engine.ElementHandle, error) {
+	ctx, cancel := p.engine.timeout()
+	defer cancel()
+
+	var ref elementRef
+	err := p.do(ctx, http.MethodPost, "/element", map[string]string{"using": "css selector", "value": selector}, &ref)
+	if isNoSuchElement(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, p.fail("query selector", "failed to find element", err)
+	}
+	return p.element(ref), nil
+}
+
+func (p *Page) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	var refs []elementRef
+	if err := p.call("query selector all", http.MethodPost, "/elements", map[string]string{"using": "css selector", "value": selector}, &refs); err != nil {
+		return nil, err
+	}
+	elements := make([]engine.ElementHandle, len(refs))
+	for i, ref := range refs {
+		elements[i] = p.element(ref)
+	}
+	return elements, nil
+}
+
+func (p *Page) waitSettings(options *engine.WaitOptions) (time.Duration, time.Duration) {
+	timeout := p.engine.config.Timeout
+	polling := defaultPolling
+	if options != nil {
+		if options.Timeout > 0 {
+			timeout = options.Timeout
+		}
+		if options.Polling > 0 {
+			polling = options.Polling
+		}
+	}
+	return timeout, polling
+}
+
+func (p *Page) WaitForSelector(selector string, options *engine.WaitOptions) (engine.ElementHandle, error) {
+	timeout, polling := p.waitSettings(options)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		el, err := p.QuerySelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case options != nil && options.Hidden:
+			if el == nil {
+				return nil, nil
+			}
+			if visible, err := el.IsVisible(); err == nil && !visible {
+				return el, nil
+			}
+		case el != nil && options != nil && options.Visible:
+			if visible, err := el.IsVisible(); err == nil && visible {
+				return el, nil
+			}
+		case el != nil:
+			return el, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, p.fail("wait for selector", fmt.Sprintf("timed out after %v waiting for %q", timeout, selector), context.DeadlineExceeded)
+		}
+		time.Sleep(polling)
+	}
+}
+
+// ExecuteScript evaluates script as an expression, matching the other
+// engines; WebDriver itself expects a function body.
+func (p *Page) ExecuteScript(script string) (interface{}, error) {
+	var result interface{}
+	body := map[string]interface{}{"script": "return eval(arguments[0]);", "args": []interface{}{script}}
+	err := p.call("execute script", http.MethodPost, "/execute/sync", body, &result)
+	return result, err
+}
+
+// ExecuteScriptAsync evaluates script and waits for the promise it returns
+func (p *Page) ExecuteScriptAsync(script string) (interface{}, error) {
+	var result struct {
+		Value interface{} `json:"value"`
+		Error string      `json:"error"`
+	}
+	body := map[string]interface{}{
+		"script": `const done = arguments[arguments.length - 1];
+Promise.resolve(eval(arguments[0])).then(
+  (value) => done({ value: value === undefined ? null : value }),
+  (err) => done({ error: String(err && err.stack || err) }));`,
+		"args": []interface{}{script},
+	}
+	if err := p.call("execute script", http.MethodPost, "/execute/async", body, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, p.fail("execute script", "script threw an exception", errors.New(result.Error))
+	}
+	return result.Value, nil
+}
+
+func (p *Page) mustQuery(operation, selector string) (*Element, error) {
+	el, err := p.QuerySelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if el == nil {
+		return nil, p.fail(operation, "no element matches selector "+selector, nil)
+	}
+	return el.(*Element), nil
+}
+
+func (p *Page) Click(selector string) error {
+	el, err := p.mustQuery("click", selector)
+	if err != nil {
+		return err
+	}
+	return el.Click()
+}
+
+func (p *Page) Type(selector string, text string) error {
+	el, err := p.mustQuery("type", selector)
+	if err != nil {
+		return err
+	}
+	return el.Type(text)
+}
+
+func (p *Page) Fill(selector string, text string) error {
+	el, err := p.mustQuery("fill", selector)
+	if err != nil {
+		return err
+	}
+	if err := el.call("fill", http.MethodPost, "/clear", nil, nil); err != nil {
+		return err
+	}
+	return el.Type(text)
+}
+
+func (p *Page) Select(selector string, values ...string) error {
+	el, err := p.mustQuery("select", selector)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{
+		"script": `const [el, values] = arguments;
+if (el.nodeName.toLowerCase() !== 'select') throw new Error('element is not a <select>');
+for (const option of el.options) option.selected = values.includes(option.value);
+el.dispatchEvent(new Event('input', { bubbles: true }));
+el.dispatchEvent(new Event('change', { bubbles: true }));`,
+		"args": []interface{}{elementRef{elementKey: el.id}, values},
+	}
+	return p.call("select", http.MethodPost, "/execute/sync", body, nil)
+}
+
+// Screenshot captures the viewport as PNG and crops or re-encodes it in Go,
+// since WebDriver only returns PNG screenshots of the visible window. A
+// transparent background needs a Chromium-based browser.
+func (p *Page) Screenshot(options engine.ScreenshotOptions) error {
+	format := options.ResolvedFormat()
+	if format == "webp" {
+		return p.fail("screenshot", "WebDriver cannot produce WebP screenshots", nil)
+	}
+
+	if options.FullPage {
+		restore, err := p.growToContent()
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if options.OmitBackground {
+		transparent := map[string]interface{}{"color": map[string]int{"r": 0, "g": 0, "b": 0, "a": 0}}
+		if err := p.devtools("screenshot", "Emulation.setDefaultBackgroundColorOverride", transparent); err != nil {
+			return err
+		}
+		defer p.devtools("screenshot", "Emulation.setDefaultBackgroundColorOverride", map[string]interface{}{})
+	}
+
+	var encoded string
+	if err := p.call("screenshot", http.MethodGet, "/screenshot", nil, &encoded); err != nil {
+		return err
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return p.fail("screenshot", "invalid screenshot data", err)
+	}
+
+	if options.Clip != nil || format != "png" {
+		if data, err = transcode(data, options.Clip, format, options.Quality); err != nil {
+			return p.fail("screenshot", "failed to process screenshot", err)
+		}
+	}
+	if err := os.WriteFile(options.Path, data, 0644); err != nil {
+		return p.fail("screenshot", "failed to write "+options.Path, err)
+	}
+	return nil
+}
+
+// cmPerInch converts PDFOptions lengths to the centimeters of WebDriver
+const cmPerInch = 2.54
+
+// PDF prints the page with the W3C Print command. WebDriver has no header
+// and footer templates.
+func (p *Page) PDF(options engine.PDFOptions) error {
+	paper, err := options.Paper()
+	if err != nil {
+		return p.fail("pdf", "invalid paper size", err)
+	}
+	if display, _, _ := options.HeaderFooter(); display {
+		return p.fail("pdf", "WebDriver cannot print header or footer templates", nil)
+	}
+	body := map[string]interface{}{
+		"orientation": "portrait",
+		"background":  options.PrintBackground,
+		"page":        map[string]float64{"width": paper.Width * cmPerInch, "height": paper.Height * cmPerInch},
+	}
+	if options.Landscape {
+		body["orientation"] = "landscape"
+	}
+	if options.Scale > 0 {
+		body["scale"] = options.Scale
+	}
+	if m := options.Margin; m != nil {
+		body["margin"] = map[string]float64{
+			"top": m.Top * cmPerInch, "right": m.Right * cmPerInch, "bottom": m.Bottom * cmPerInch, "left": m.Left * cmPerInch,
+		}
+	}
+	if options.PageRanges != "" {
+		var ranges []string
+		for _, r := range strings.Split(options.PageRanges, ",") {
+			ranges = append(ranges, strings.TrimSpace(r))
+		}
+		body["pageRanges"] = ranges
+	}
+
+	var encoded string
+	if err := p.call("pdf", http.MethodPost, "/print", body, &encoded); err != nil {
+		return err
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return p.fail("pdf", "invalid PDF data", err)
+	}
+	if err := os.WriteFile(options.Path, data, 0644); err != nil {
+		return p.fail("pdf", "failed to write "+options.Path, err)
+	}
+	return nil
+}
+
+// growToContent resizes the window to the document size for a full-page
+// capture and returns a function restoring the previous size.
+func (p *Page) growToContent() (func(), error) {
+	var rect struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+	if err := p.call("screenshot", http.MethodGet, "/window/rect", nil, &rect); err != nil {
+		return nil, err
+	}
+	size, err := p.ExecuteScript(`[document.documentElement.scrollWidth, document.documentElement.scrollHeight]`)
+	if err != nil {
+		return nil, err
+	}
+	dims, ok := size.([]interface{})
+	if !ok || len(dims) != 2 {
+		return nil, p.fail("screenshot", "failed to measure page", nil)
+	}
+	width, _ := dims[0].(float64)
+	height, _ := dims[1].(float64)
+
+	full := map[string]int{"width": max(rect.Width, int(width)), "height": max(rect.Height, int(height))}
+	if err := p.call("screenshot", http.MethodPost, "/window/rect", full, nil); err != nil {
+		return nil, err
+	}
+	return func() {
+		p.call("screenshot", http.MethodPost, "/window/rect", map[string]int{"width": rect.Width, "height": rect.Height}, nil)
+	}, nil
+}
+
+func transcode(data []byte, clip *engine.ClipOptions, format string, quality int) ([]byte, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if clip != nil {
+		r := image.Rect(int(clip.X), int(clip.Y), int(clip.X+clip.Width), int(clip.Y+clip.Height)).Intersect(img.Bounds())
+		cropped := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+		draw.Draw(cropped, cropped.Bounds(), img, r.Min, draw.Src)
+		img = cropped
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		if quality <= 0 {
+			quality = 90
+		}
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	return buf.Bytes(), err
+}
+
+// WaitForNavigation waits until the document reports it has finished loading
+func (p *Page) WaitForNavigation(options *engine.NavigationOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	ctx, cancel := p.navigationContext(options)
+	defer cancel()
+	if err := p.WaitForFunction(`document.readyState === 'complete'`, &engine.WaitOptions{Timeout: engine.Remaining(ctx)}); err != nil {
+		return err
+	}
+	if options != nil && options.WaitForSelector != "" {
+		_, err := p.WaitForSelector(options.WaitForSelector, &engine.WaitOptions{Timeout: engine.Remaining(ctx)})
+		return err
+	}
+	return nil
+}
+
+func (p *Page) WaitForTimeout(timeout time.Duration) error {
+	time.Sleep(timeout)
+	return nil
+}
+
+func (p *Page) WaitForFunction(pageFunction string, options *engine.WaitOptions) error {
+	timeout, polling := p.waitSettings(options)
+	deadline := time.Now().Add(timeout)
+	for {
+		value, err := p.ExecuteScriptAsync(fmt.Sprintf("(async () => !!(await (%s)))()", pageFunction))
+		if err != nil {
+			return err
+		}
+		if ok, _ := value.(bool); ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return p.fail("wait for function", fmt.Sprintf("timed out after %v", timeout), context.DeadlineExceeded)
+		}
+		time.Sleep(polling)
+	}
+}
+
+// wdCookie is the W3C cookie object
+type wdCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Domain   string `json:"domain,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Expiry   int64  `json:"expiry,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+func (p *Page) GetCookies() ([]engine.Cookie, error) {
+	var res []wdCookie
+	if err := p.call("get cookies", http.MethodGet, "/cookie", nil, &res); err != nil {
+		return nil, err
+	}
+	cookies := make([]engine.Cookie, len(res))
+	for i, c := range res {
+		cookies[i] = engine.Cookie{
+			Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path, Expires: c.Expiry,
+			HTTPOnly: c.HTTPOnly, Secure: c.Secure, SameSite: c.SameSite,
+		}
+	}
+	return cookies, nil
+}
+
+func (p *Page) SetCookies(cookies []engine.Cookie) error {
+	for _, c := range cookies {
+		cookie := wdCookie{
+			Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path, Expiry: c.Expires,
+			HTTPOnly: c.HTTPOnly, Secure: c.Secure, SameSite: c.SameSite,
+		}
+		if err := p.call("set cookies", http.MethodPost, "/cookie", map[string]interface{}{"cookie": cookie}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Page) ClearCookies() error {
+	return p.call("clear cookies", http.MethodDelete, "/cookie", nil, nil)
+}
+
+// SetViewport resizes the browser window; WebDriver has no separate
+// viewport, so the content area ends up slightly smaller than requested.
+func (p *Page) SetViewport(viewport engine.ViewportConfig) error {
+	return p.call("set viewport", http.MethodPost, "/window/rect", map[string]int{"width": viewport.Width, "height": viewport.Height}, nil)
+}
+
+// GetMetrics reports the page's navigation timing entry
+func (p *Page) GetMetrics() (map[string]interface{}, error) {
+	value, err := p.ExecuteScript(`(() => {
+		const nav = performance.getEntriesByType('navigation')[0];
+		return nav ? nav.toJSON() : {};
+	})()`)
+	if err != nil {
+		return nil, err
+	}
+	metrics, _ := value.(map[string]interface{})
+	return metrics, nil
+}
+
+// EmulateDevice resizes the window to the device viewport. WebDriver fixes
+// the user agent and touch support when the session is created.
+func (p *Page) EmulateDevice(device engine.Device) error {
+	return p.SetViewport(device.Viewport)
+}
+
+// Close closes the page's window. Deleting the session's last window ends
+// the session, so that one is left on about:blank for the next new page.
+func (p *Page) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	ctx, cancel := p.engine.timeout()
+	defer cancel()
+	if p.engine.isLastPage(p) {
+		if err := p.do(ctx, http.MethodPost, "/url", map[string]string{"url": "about:blank"}, nil); err != nil {
+			p.engine.removePage(p)
+			return p.fail("close", "failed to blank the last window", err)
+		}
+		p.engine.keepWindow(p)
+		return nil
+	}
+	p.engine.removePage(p)
+	if err := p.do(ctx, http.MethodDelete, "/window", nil, nil); err != nil {
+		return p.fail("close", "failed to close window", err)
+	}
+	p.engine.mu.Lock()
+	p.engine.current = ""
+	p.engine.mu.Unlock()
+	return nil
+}
+
+// escapePath escapes a value used as a URL path segment
+func escapePath(s string) string {
+	return url.PathEscape(s)
+}
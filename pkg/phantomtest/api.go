@@ -0,0 +1,267 @@
+package phantomtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// The runner reaches the API at PHANTOM_TEST_API with JSON POSTs:
+//
+//	/call    {"method", "page"?, ...} runs a page method; {"result"}
+//	/result  {"suite"?, "name", "status", "error"?, "stack"?, "duration_ms"} ends a test
+//	/error   {"error"} reports that the file failed outside its tests
+//
+// Failures are {"error", "error_kind"?}. A call to "goto" without a page
+// opens one and answers its id; pages opened since the last result are
+// closed when the next result arrives, which also gets the screenshots
+// saved since.
+
+// call is the body of /call. The fields after Page are the arguments of
+// the methods that take them.
+type call struct {
+	Method   string  `json:"method"`
+	Page     string  `json:"page,omitempty"`
+	URL      string  `json:"url,omitempty"`
+	Selector string  `json:"selector,omitempty"`
+	Text     string  `json:"text,omitempty"`
+	Script   string  `json:"script,omitempty"`
+	Path     string  `json:"path,omitempty"`
+	FullPage bool    `json:"full_page,omitempty"`
+	Timeout  float64 `json:"timeout,omitempty"` // milliseconds
+}
+
+// result is the body of /result
+type result struct {
+	Test
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// api serves the pages of one test file
+type api struct {
+	file   *File
+	config *Config
+	mux    *http.ServeMux
+
+	mu     sync.Mutex
+	pages  map[string]engine.Page
+	opened []string // ids of the pages opened since the last result
+	shots  []string // screenshots saved since the last result
+	nextID int
+	closed bool
+}
+
+func newAPI(f *File, config *Config) *api {
+	a := &api{file: f, config: config, mux: http.NewServeMux(), pages: map[string]engine.Page{}}
+	a.mux.HandleFunc("/call", a.post(func() interface{} { return &call{} }, a.call))
+	a.mux.HandleFunc("/result", a.post(func() interface{} { return &result{} }, a.result))
+	a.mux.HandleFunc("/error", a.post(func() interface{} { return &struct{ Error string }{} }, a.fileError))
+	return a
+}
+
+func (a *api) ServeHTTP(w http.ResponseWriter, r *http.Request) { a.mux.ServeHTTP(w, r) }
+
+// post decodes the body of a POST into the value body returns and answers
+// with the result of serve
+func (a *api) post(body func() interface{}, serve func(interface{}) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: r.URL.Path + " needs POST"})
+			return
+		}
+		v := body()
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid JSON body: " + err.Error()})
+			return
+		}
+		res, err := serve(v)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error(), Kind: engine.KindOf(err)})
+			return
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Result interface{} `json:"result"`
+		}{res})
+	}
+}
+
+type errorResponse struct {
+	Error string           `json:"error"`
+	Kind  engine.ErrorKind `json:"error_kind,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+func (a *api) call(v interface{}) (interface{}, error) {
+	c := v.(*call)
+	if c.Method == "goto" && c.Page == "" {
+		id, page, err := a.open()
+		if err != nil {
+			return nil, err
+		}
+		return id, page.Navigate(c.URL, a.config.Navigation)
+	}
+	page, err := a.page(c.Page)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Method {
+	case "goto":
+		return c.Page, page.Navigate(c.URL, a.config.Navigation)
+	case "title":
+		return page.Title()
+	case "url":
+		return page.URL()
+	case "content":
+		return page.Content()
+	case "evaluate":
+		return page.ExecuteScript(c.Script)
+	case "click":
+		return nil, page.Click(c.Selector)
+	case "type":
+		return nil, page.Type(c.Selector, c.Text)
+	case "fill":
+		return nil, page.Fill(c.Selector, c.Text)
+	case "waitForSelector":
+		_, err := page.WaitForSelector(c.Selector, &engine.WaitOptions{Timeout: time.Duration(c.Timeout * float64(time.Millisecond))})
+		return nil, err
+	case "screenshot":
+		if err := screenshot(page, c); err != nil {
+			return nil, err
+		}
+		a.mu.Lock()
+		a.shots = append(a.shots, c.Path)
+		a.mu.Unlock()
+		return c.Path, nil
+	case "close":
+		a.mu.Lock()
+		delete(a.pages, c.Page)
+		a.mu.Unlock()
+		return nil, page.Close()
+	}
+	return nil, fmt.Errorf("unknown method %q", c.Method)
+}
+
+// open opens a page for the running test
+func (a *api) open() (string, engine.Page, error) {
+	page, err := a.config.Engine.NewPage(context.Background())
+	if err != nil {
+		return "", nil, err
+	}
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		page.Close()
+		return "", nil, errors.New("the test file has finished")
+	}
+	a.nextID++
+	id := strconv.Itoa(a.nextID)
+	a.pages[id] = page
+	a.opened = append(a.opened, id)
+	a.mu.Unlock()
+
+	if a.config.Prepare != nil {
+		if err := a.config.Prepare(page); err != nil {
+			return "", nil, err
+		}
+	}
+	return id, page, nil
+}
+
+func (a *api) page(id string) (engine.Page, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	page, ok := a.pages[id]
+	if !ok {
+		return nil, fmt.Errorf("page %q is closed", id)
+	}
+	return page, nil
+}
+
+// screenshot saves a screenshot at c.Path in the format of its extension,
+// creating its directory
+func screenshot(page engine.Page, c *call) error {
+	if c.Path == "" {
+		return errors.New("screenshot needs a path")
+	}
+	format, ok := engine.ScreenshotFormat(c.Path)
+	if !ok {
+		return fmt.Errorf("screenshot %s: the extension must be .png, .jpg or .webp", c.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return err
+	}
+	return page.Screenshot(engine.ScreenshotOptions{Path: c.Path, Format: format, FullPage: c.FullPage})
+}
+
+func (a *api) result(v interface{}) (interface{}, error) {
+	r := v.(*result)
+	t := r.Test
+	t.Duration = time.Duration(r.DurationMS * float64(time.Millisecond))
+	switch t.Status {
+	case StatusPassed, StatusFailed, StatusSkipped:
+	default:
+		return nil, fmt.Errorf("unknown status %q", t.Status)
+	}
+	a.closeOpened()
+
+	a.mu.Lock()
+	t.Screenshots, a.shots = a.shots, nil
+	a.file.Tests = append(a.file.Tests, &t)
+	a.mu.Unlock()
+	a.config.Reporter.EndTest(a.file, &t)
+	return nil, nil
+}
+
+func (a *api) fileError(v interface{}) (interface{}, error) {
+	msg := v.(*struct{ Error string }).Error
+	a.mu.Lock()
+	a.file.Error = msg
+	a.mu.Unlock()
+	return nil, nil
+}
+
+// closeOpened closes the pages of the test that just ended
+func (a *api) closeOpened() {
+	a.mu.Lock()
+	var pages []engine.Page
+	for _, id := range a.opened {
+		if page, ok := a.pages[id]; ok {
+			pages = append(pages, page)
+			delete(a.pages, id)
+		}
+	}
+	a.opened = nil
+	a.mu.Unlock()
+	for _, page := range pages {
+		page.Close()
+	}
+}
+
+// close closes every page left open and refuses new ones
+func (a *api) close() {
+	a.closeOpened()
+	a.mu.Lock()
+	a.closed = true
+	pages := a.pages
+	a.pages = map[string]engine.Page{}
+	a.mu.Unlock()
+	for _, page := range pages {
+		page.Close()
+	}
+}
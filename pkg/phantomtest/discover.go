@@ -0,0 +1,168 @@
+package phantomtest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Suffixes are the endings of test file names
+var Suffixes = []string{".phantom.js", ".phantom.ts"}
+
+// skippedDirs are never searched for test files
+var skippedDirs = []string{"node_modules", "dist"}
+
+// IsTestFile reports whether name ends in one of Suffixes
+func IsTestFile(name string) bool {
+	for _, suffix := range Suffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Discover returns the test files that patterns name, sorted and without
+// duplicates. A pattern is a file, taken as it is, a directory searched
+// for test files, or a glob whose matches are; "**" in a glob matches any
+// number of directories. Without patterns the current directory is
+// searched. node_modules, dist and hidden directories are skipped.
+func Discover(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	var files []string
+	for _, pattern := range patterns {
+		if info, err := os.Stat(pattern); err == nil {
+			if !info.IsDir() {
+				files = append(files, filepath.Clean(pattern))
+				continue
+			}
+			found, err := search(pattern)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, found...)
+			continue
+		}
+		matches, err := glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", pattern, err)
+		}
+		var found []string
+		for _, match := range matches {
+			more, err := search(match)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, more...)
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("no test files match %q", pattern)
+		}
+		files = append(files, found...)
+	}
+	slices.Sort(files)
+	files = slices.Compact(files)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s files found", strings.Join(Suffixes, " or "))
+	}
+	return files, nil
+}
+
+// search returns the test files in and below root, or root itself when it
+// is a test file
+func search(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir() && p != root && skipDir(d.Name()):
+			return filepath.SkipDir
+		case !d.IsDir() && IsTestFile(d.Name()):
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func skipDir(name string) bool {
+	return slices.Contains(skippedDirs, name) || (strings.HasPrefix(name, ".") && name != "." && name != "..")
+}
+
+// glob is filepath.Glob with "**" for any number of directories
+func glob(pattern string) ([]string, error) {
+	pattern = path.Clean(filepath.ToSlash(pattern))
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(filepath.FromSlash(pattern))
+	}
+	segments := strings.Split(pattern, "/")
+	for _, s := range segments {
+		if _, err := path.Match(s, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// Walk from the directories before the first segment with a wildcard
+	root := "."
+	for i, s := range segments {
+		if strings.ContainsAny(s, `*?[\`) {
+			if i > 0 {
+				root = path.Join(segments[:i]...)
+			}
+			if strings.HasPrefix(pattern, "/") {
+				root = "/" + root
+			}
+			break
+		}
+	}
+	var matches []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return filepath.SkipDir
+		case err != nil:
+			return err
+		case d.IsDir() && p != filepath.FromSlash(root) && skipDir(d.Name()):
+			return filepath.SkipDir
+		}
+		name := filepath.ToSlash(p)
+		if strings.HasPrefix(pattern, "/") {
+			name = strings.TrimPrefix(name, "/")
+		}
+		if matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(name, "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// matchSegments matches the segments of a path against those of a pattern
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,120 @@
+package phantomtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// junitReporter writes JUnit XML when the run ends: a testsuite per file
+// and a testcase per test, classed by the file and its describe blocks.
+// A file that failed outside its tests is a testcase with an error.
+// Screenshots are [[ATTACHMENT|path]] lines of system-out, which Jenkins
+// and GitLab show with the test.
+type junitReporter struct {
+	w io.Writer
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Skipped   *struct{}     `xml:"skipped"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitProblem is a failure or error: the first line of the message, and
+// the stack, or the whole message without one
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (*junitReporter) StartFile(*File)      {}
+func (*junitReporter) EndTest(*File, *Test) {}
+func (*junitReporter) EndFile(*File)        {}
+
+func (j *junitReporter) End(r *Report) error {
+	doc := junitSuites{Name: "phantom-vite", Time: seconds(r.Duration)}
+	for _, f := range r.Files {
+		suite := junitSuite{Name: f.Path, Time: seconds(f.Duration)}
+		for _, t := range f.Tests {
+			c := junitCase{
+				Name:      t.Name,
+				Classname: strings.Join(append([]string{f.Path}, t.Suite...), " › "),
+				Time:      seconds(t.Duration),
+			}
+			switch t.Status {
+			case StatusFailed:
+				c.Failure = problem(t.Error, t.Stack)
+				suite.Failures++
+			case StatusSkipped:
+				c.Skipped = &struct{}{}
+				suite.Skipped++
+			}
+			for _, path := range t.Screenshots {
+				c.SystemOut += fmt.Sprintf("[[ATTACHMENT|%s]]\n", path)
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		if f.Error != "" {
+			suite.Cases = append(suite.Cases, junitCase{Name: f.Path, Classname: f.Path, Time: seconds(0), Error: problem(f.Error, "")})
+			suite.Errors++
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(j.w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(j.w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(j.w, "\n")
+	return err
+}
+
+func problem(message, stack string) *junitProblem {
+	p := &junitProblem{Message: strings.SplitN(message, "\n", 2)[0], Text: stack}
+	if p.Text == "" {
+		p.Text = message
+	}
+	return p
+}
+
+// seconds is a duration as JUnit writes it
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
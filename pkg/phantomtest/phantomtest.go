@@ -0,0 +1,263 @@
+// Package phantomtest runs *.phantom.js and *.phantom.ts test files. Each
+// file, bundled into an ES module, runs in its own Node.js process with
+// the runtime's runner (phantom-test.mjs), which defines describe, test and
+// expect and times out every test. Tests drive pages through the
+// 'phantom-vite' module (phantom.mjs):
+//
+//	import { phantom } from 'phantom-vite';
+//
+//	describe('Website Tests', () => {
+//	  test('should load homepage', async () => {
+//	    const page = await phantom.goto('https://example.com');
+//	    expect(await page.title()).toBe('Example Domain');
+//	    await page.screenshot('homepage.png');
+//	  });
+//	});
+//
+// The pages live in the engine of the Go process, which serves them to the
+// runner over HTTP on the loopback interface; see api.go. Pages a test
+// opens are closed when it ends.
+package phantomtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// Scripts of the runtime directory, and the module name tests import
+const (
+	RunnerScript = "phantom-test.mjs"
+	ModuleScript = "phantom.mjs"
+	Module       = "phantom-vite"
+)
+
+// DefaultTimeout is the limit of a test that does not set its own
+const DefaultTimeout = 30 * time.Second
+
+// Statuses of a Test
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// Config configures Run. Zero values select the defaults.
+type Config struct {
+	Engine engine.Engine // opens the pages of the tests; required
+
+	// Bundle turns the test files into ES modules Node can import, in the
+	// order of the files, with the Module import resolved to ModuleScript;
+	// required
+	Bundle func(files []string) ([]string, error)
+
+	RuntimeDir string                    // holds RunnerScript; "runtime" by default
+	Node       string                    // the Node.js binary; "node" by default
+	Timeout    time.Duration             // limit of each test; DefaultTimeout by default
+	Navigation *engine.NavigationOptions // how goto loads pages
+	Prepare    func(engine.Page) error   // called with every new page, e.g. to emulate a device
+	Reporter   Reporter                  // gets the results; the console reporter on Log by default
+	Log        io.Writer                 // the output of the tests; none when nil
+}
+
+// Report is the result of a run, with the files in the order they ran
+type Report struct {
+	Engine   string
+	Files    []*File
+	Duration time.Duration // including bundling
+}
+
+// File is the result of a test file
+type File struct {
+	Path     string
+	Error    string // the file failed outside its tests, e.g. to load
+	Tests    []*Test
+	Duration time.Duration
+}
+
+// Test is the result of a test, as the runner reports it
+type Test struct {
+	Suite       []string      `json:"suite"` // the describe blocks around the test, outermost first
+	Name        string        `json:"name"`
+	Status      string        `json:"status"`
+	Error       string        `json:"error"`
+	Stack       string        `json:"stack"` // of a failure, mapped to the test's source
+	Screenshots []string      `json:"-"`     // paths of the screenshots the test saved
+	Duration    time.Duration `json:"-"`
+}
+
+// FullName is the name of the test after those of its suites
+func (t *Test) FullName() string {
+	return strings.Join(append(append([]string{}, t.Suite...), t.Name), " › ")
+}
+
+// Counts returns how many tests passed, failed and were skipped, and how
+// many files failed outside their tests
+func (r *Report) Counts() (passed, failed, skipped, errored int) {
+	for _, f := range r.Files {
+		if f.Error != "" {
+			errored++
+		}
+		for _, t := range f.Tests {
+			switch t.Status {
+			case StatusPassed:
+				passed++
+			case StatusFailed:
+				failed++
+			case StatusSkipped:
+				skipped++
+			}
+		}
+	}
+	return passed, failed, skipped, errored
+}
+
+// OK reports whether no test and no file failed
+func (r *Report) OK() bool {
+	_, failed, _, errored := r.Counts()
+	return failed == 0 && errored == 0
+}
+
+// Run bundles files and runs their tests one file after another. It fails
+// only when the files cannot be bundled or the reporter fails to write;
+// failures of files and tests are in the report. When ctx is done the
+// running file is stopped and the report so far returned with ctx's error.
+func Run(ctx context.Context, files []string, config Config) (*Report, error) {
+	start := time.Now()
+	if config.RuntimeDir == "" {
+		config.RuntimeDir = "runtime"
+	}
+	if config.Node == "" {
+		config.Node = "node"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Log == nil {
+		config.Log = io.Discard
+	}
+	config.Log = &syncWriter{w: config.Log}
+	if config.Reporter == nil {
+		config.Reporter = &consoleReporter{w: config.Log}
+	}
+	runner, err := filepath.Abs(filepath.Join(config.RuntimeDir, RunnerScript))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(runner); err != nil {
+		return nil, fmt.Errorf("test runner not found: %w", err)
+	}
+
+	modules, err := config.Bundle(files)
+	if err != nil {
+		return nil, fmt.Errorf("bundling failed: %w", err)
+	}
+	if len(modules) != len(files) {
+		return nil, fmt.Errorf("bundling returned %d modules for %d files", len(modules), len(files))
+	}
+
+	report := &Report{Engine: config.Engine.Name()}
+	for i, path := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		report.Files = append(report.Files, runFile(ctx, runner, path, modules[i], &config))
+	}
+	report.Duration = time.Since(start)
+	if err := config.Reporter.End(report); err != nil {
+		return report, err
+	}
+	return report, ctx.Err()
+}
+
+// runFile runs the tests of the bundled module of the test file path
+func runFile(ctx context.Context, runner, path, module string, config *Config) *File {
+	start := time.Now()
+	f := &File{Path: path}
+	config.Reporter.StartFile(f)
+	defer func() { config.Reporter.EndFile(f) }()
+
+	fail := func(err error) *File {
+		f.Error = err.Error()
+		f.Duration = time.Since(start)
+		return f
+	}
+	token, err := newToken()
+	if err != nil {
+		return fail(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fail(err)
+	}
+	a := newAPI(f, config)
+	server := &http.Server{Handler: http.StripPrefix("/"+token, a)}
+	go server.Serve(listener)
+
+	cmd := exec.CommandContext(ctx, config.Node, "--enable-source-maps", runner, module)
+	cmd.Env = append(os.Environ(),
+		"PHANTOM_TEST_API=http://"+listener.Addr().String()+"/"+token,
+		fmt.Sprintf("PHANTOM_TEST_TIMEOUT=%d", config.Timeout.Milliseconds()),
+	)
+	cmd.Stdout, cmd.Stderr = config.Log, config.Log
+	runErr := cmd.Run()
+
+	// Calls of tests that timed out may still be in flight
+	server.Close()
+	a.close()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var exit *exec.ExitError
+	switch {
+	case f.Error != "": // reported by the runner
+	case ctx.Err() != nil:
+		return fail(fmt.Errorf("stopped: %w", context.Cause(ctx)))
+	case errors.As(runErr, &exit):
+		return fail(fmt.Errorf("the test runner exited with status %d", exit.ExitCode()))
+	case runErr != nil:
+		return fail(fmt.Errorf("failed to start the test runner: %w", runErr))
+	}
+	f.Duration = time.Since(start)
+	return f
+}
+
+// syncWriter serializes the lines of the API with the output of the
+// runner, which are written from different goroutines
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// newToken returns a random path prefix for the API, so other processes
+// on the machine cannot drive its pages
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// indent lines after the first of a message to sit under a result line
+func indent(s string) string {
+	return strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n     ")
+}
@@ -0,0 +1,347 @@
+package phantomtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// downEngine is a fake engine that cannot reach URLs containing "down"
+func downEngine() *enginetest.Engine {
+	return &enginetest.Engine{Load: func(url string) error {
+		if strings.Contains(url, "down") {
+			return errors.New("connection refused")
+		}
+		return nil
+	}}
+}
+
+// rewriteBundle stands in for Vite: it copies each file to an .mjs module
+// importing the runtime's phantom.mjs in place of 'phantom-vite'
+func rewriteBundle(t *testing.T, runtime string) func([]string) ([]string, error) {
+	module := filepath.Join(runtime, ModuleScript)
+	dir := t.TempDir()
+	return func(files []string) ([]string, error) {
+		var modules []string
+		for i, file := range files {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			out := filepath.Join(dir, strconv.Itoa(i)+".mjs")
+			code := strings.ReplaceAll(string(src), "'"+Module+"'", strconv.Quote(module))
+			if err := os.WriteFile(out, []byte(code), 0644); err != nil {
+				return nil, err
+			}
+			modules = append(modules, out)
+		}
+		return modules, nil
+	}
+}
+
+func needNode(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+}
+
+const siteTests = `import { phantom, describe, test, expect } from 'phantom-vite';
+
+describe('site', () => {
+  test('loads', async () => {
+    const page = await phantom.goto('https://example.com/');
+    expect(await page.title()).toBe('Title of https://example.com/');
+    expect(await page.evaluate(() => 1)).toMatch(/=> 1/);
+    await page.screenshot('shots/home.jpg');
+  });
+  describe('errors', () => {
+    test('assertion', () => expect(1 + 1).not.toBe(2));
+    test('navigation', async () => {
+      await phantom.goto('https://down.example.com/');
+    });
+  });
+  test.skip('later', () => {});
+  test('hangs', () => new Promise(() => {}), 50);
+});
+
+test('outside', () => {
+  console.log('hello from a test');
+  expect([1, { a: [2] }]).toEqual([1, { a: [2] }]);
+  expect('phantom').toContain('ant');
+});
+`
+
+func TestRun(t *testing.T) {
+	needNode(t)
+	dir := t.TempDir()
+	runtime, _ := filepath.Abs(filepath.Join("..", "..", "runtime"))
+	wd, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(wd) })
+	os.WriteFile("site.phantom.js", []byte(siteTests), 0644)
+	os.WriteFile("broken.phantom.js", []byte("import 'phantom-vite';\nthrow new Error('broken at load');\n"), 0644)
+
+	eng := downEngine()
+	var log bytes.Buffer
+	report, err := Run(context.Background(), []string{"broken.phantom.js", "site.phantom.js"}, Config{
+		Engine:     eng,
+		Bundle:     rewriteBundle(t, runtime),
+		RuntimeDir: runtime,
+		Log:        &log,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", report.Files)
+	}
+	broken, site := report.Files[0], report.Files[1]
+	if !strings.Contains(broken.Error, "broken at load") || len(broken.Tests) != 0 {
+		t.Errorf("expected the load error of broken.phantom.js, got %+v", broken)
+	}
+	if site.Error != "" {
+		t.Fatalf("expected site.phantom.js to run, got %s\n%s", site.Error, log.String())
+	}
+
+	want := []Test{
+		{Suite: []string{"site"}, Name: "loads", Status: StatusPassed},
+		{Suite: []string{"site", "errors"}, Name: "assertion", Status: StatusFailed, Error: "expected 2 not to be 2"},
+		{Suite: []string{"site", "errors"}, Name: "navigation", Status: StatusFailed, Error: "connection refused"},
+		{Suite: []string{"site"}, Name: "later", Status: StatusSkipped},
+		{Suite: []string{"site"}, Name: "hangs", Status: StatusFailed, Error: "timed out after 50ms"},
+		{Name: "outside", Status: StatusPassed},
+	}
+	if len(site.Tests) != len(want) {
+		t.Fatalf("expected %d tests, got %d\n%s", len(want), len(site.Tests), log.String())
+	}
+	for i, got := range site.Tests {
+		got := *got
+		got.Duration, got.Stack, got.Screenshots = 0, "", nil
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("test %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+	if shots := site.Tests[0].Screenshots; !reflect.DeepEqual(shots, []string{"shots/home.jpg"}) {
+		t.Errorf("expected the screenshot of the first test, got %q", shots)
+	}
+	// The stack of an assertion starts at the test, past the frames of expect
+	if stack := site.Tests[1].Stack; !strings.HasPrefix(stack, "AssertionError: expected 2 not to be 2\n") ||
+		!strings.Contains(stack, "at ") || strings.Contains(stack, ModuleScript) {
+		t.Errorf("expected the stack of the test, got %q", stack)
+	}
+	if stack := site.Tests[4].Stack; stack != "" {
+		t.Errorf("expected no stack for a timeout, got %q", stack)
+	}
+
+	if passed, failed, skipped, errored := report.Counts(); passed != 2 || failed != 3 || skipped != 1 || errored != 1 || report.OK() {
+		t.Errorf("expected 2 passed, 3 failed, 1 skipped and 1 file error, got %d, %d, %d and %d", passed, failed, skipped, errored)
+	}
+	if eng.Opened() != 2 || eng.Open() != 0 {
+		t.Errorf("expected the 2 pages opened to be closed, %d of %d are open", eng.Open(), eng.Opened())
+	}
+	if data, _ := os.ReadFile(filepath.Join("shots", "home.jpg")); string(data) != "jpeg" {
+		t.Errorf("expected a jpeg screenshot, got %q", data)
+	}
+	for _, line := range []string{"📄 site.phantom.js", "  ✅ site › loads (", "     📸 shots/home.jpg", "  ❌ site › errors › assertion (", "  ⏭️  site › later", "hello from a test", "❌ 2 passed, 3 failed, 1 skipped, 1 of 2 files failed to run in"} {
+		if !strings.Contains(log.String(), line) {
+			t.Errorf("expected %q in the log:\n%s", line, log.String())
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(wd) })
+	for _, name := range []string{
+		"a.phantom.js", "b.js", "tests/c.phantom.ts", "tests/deep/d.phantom.js",
+		"node_modules/pkg/e.phantom.js", "dist/f.phantom.js", ".cache/g.phantom.js",
+	} {
+		os.MkdirAll(filepath.Dir(name), 0755)
+		os.WriteFile(name, nil, 0644)
+	}
+
+	for _, tc := range []struct {
+		patterns []string
+		want     []string
+	}{
+		{nil, []string{"a.phantom.js", "tests/c.phantom.ts", "tests/deep/d.phantom.js"}},
+		{[]string{"tests"}, []string{"tests/c.phantom.ts", "tests/deep/d.phantom.js"}},
+		{[]string{"tests/*"}, []string{"tests/c.phantom.ts", "tests/deep/d.phantom.js"}},
+		{[]string{"tests/*.ts", "a.phantom.js"}, []string{"a.phantom.js", "tests/c.phantom.ts"}},
+		{[]string{"**/*.phantom.js"}, []string{"a.phantom.js", "tests/deep/d.phantom.js"}},
+		{[]string{"tests/**/d.*"}, []string{"tests/deep/d.phantom.js"}},
+		{[]string{"b.js", "b.js"}, []string{"b.js"}},
+	} {
+		got, err := Discover(tc.patterns)
+		if err != nil {
+			t.Errorf("%q: %v", tc.patterns, err)
+			continue
+		}
+		for i := range tc.want {
+			tc.want[i] = filepath.FromSlash(tc.want[i])
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: expected %q, got %q", tc.patterns, tc.want, got)
+		}
+	}
+
+	for _, patterns := range [][]string{{"*.ts"}, {"missing/**/*.js"}, {"[x"}} {
+		if files, err := Discover(patterns); err == nil {
+			t.Errorf("%q: expected an error, got %q", patterns, files)
+		}
+	}
+}
+
+func TestReporters(t *testing.T) {
+	ms := time.Millisecond
+	report := &Report{Engine: "fake", Duration: 1500 * ms, Files: []*File{
+		{Path: "tests/a.phantom.js", Duration: 900 * ms, Tests: []*Test{
+			{Suite: []string{"home"}, Name: "loads", Status: StatusPassed, Duration: 120 * ms, Screenshots: []string{"shots/home.png"}},
+			{Suite: []string{"home"}, Name: "has a <title> # 1", Status: StatusFailed, Duration: 30 * ms,
+				Error: "expected \"A\" to be \"B\"", Stack: "AssertionError: expected \"A\" to be \"B\"\n    at a.phantom.js:7:5"},
+			{Name: "later", Status: StatusSkipped},
+		}},
+		{Path: "tests/b.phantom.ts", Duration: 100 * ms, Error: "SyntaxError: Unexpected token\nat line 3"},
+	}}
+
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{ReporterTAP, `TAP version 13
+# tests/a.phantom.js
+ok 1 - home › loads
+  ---
+  file: "tests/a.phantom.js"
+  duration_ms: 120
+  screenshots:
+    - "shots/home.png"
+  ...
+not ok 2 - home › has a <title> \# 1
+  ---
+  message: "expected \"A\" to be \"B\""
+  file: "tests/a.phantom.js"
+  duration_ms: 30
+  stack: |-
+    AssertionError: expected "A" to be "B"
+        at a.phantom.js:7:5
+  ...
+ok 3 - later # SKIP
+# tests/b.phantom.ts
+not ok 4 - tests/b.phantom.ts
+  ---
+  message: "SyntaxError: Unexpected token\nat line 3"
+  ...
+1..4
+# tests 4
+# pass 1
+# fail 2
+# skip 1
+`},
+		{ReporterJUnit, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="phantom-vite" tests="4" failures="1" errors="1" skipped="1" time="1.500">
+  <testsuite name="tests/a.phantom.js" tests="3" failures="1" errors="0" skipped="1" time="0.900">
+    <testcase name="loads" classname="tests/a.phantom.js › home" time="0.120">
+      <system-out>[[ATTACHMENT|shots/home.png]]&#xA;</system-out>
+    </testcase>
+    <testcase name="has a &lt;title&gt; # 1" classname="tests/a.phantom.js › home" time="0.030">
+      <failure message="expected &#34;A&#34; to be &#34;B&#34;">AssertionError: expected &#34;A&#34; to be &#34;B&#34;&#xA;    at a.phantom.js:7:5</failure>
+    </testcase>
+    <testcase name="later" classname="tests/a.phantom.js" time="0.000">
+      <skipped></skipped>
+    </testcase>
+  </testsuite>
+  <testsuite name="tests/b.phantom.ts" tests="1" failures="0" errors="1" skipped="0" time="0.100">
+    <testcase name="tests/b.phantom.ts" classname="tests/b.phantom.ts" time="0.000">
+      <error message="SyntaxError: Unexpected token">SyntaxError: Unexpected token&#xA;at line 3</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`},
+		{ReporterJSON, `{
+  "engine": "fake",
+  "passed": 1,
+  "failed": 1,
+  "skipped": 1,
+  "errors": 1,
+  "files": [
+    {
+      "path": "tests/a.phantom.js",
+      "tests": [
+        {
+          "suite": [
+            "home"
+          ],
+          "name": "loads",
+          "status": "passed",
+          "screenshots": [
+            "shots/home.png"
+          ],
+          "duration_ms": 120
+        },
+        {
+          "suite": [
+            "home"
+          ],
+          "name": "has a <title> # 1",
+          "status": "failed",
+          "error": "expected \"A\" to be \"B\"",
+          "stack": "AssertionError: expected \"A\" to be \"B\"\n    at a.phantom.js:7:5",
+          "duration_ms": 30
+        },
+        {
+          "name": "later",
+          "status": "skipped",
+          "duration_ms": 0
+        }
+      ],
+      "duration_ms": 900
+    },
+    {
+      "path": "tests/b.phantom.ts",
+      "error": "SyntaxError: Unexpected token\nat line 3",
+      "tests": [],
+      "duration_ms": 100
+    }
+  ],
+  "timings": {
+    "total_ms": 1500
+  }
+}
+`},
+	} {
+		var out bytes.Buffer
+		r, err := NewReporter(tc.name, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range report.Files {
+			r.StartFile(f)
+			for _, test := range f.Tests {
+				r.EndTest(f, test)
+			}
+			r.EndFile(f)
+		}
+		if err := r.End(report); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tc.want {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.name, tc.want, out.String())
+		}
+	}
+	if _, err := NewReporter("xunit", nil); err == nil {
+		t.Error("expected an unknown reporter to fail")
+	}
+}
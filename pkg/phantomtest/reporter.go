@@ -0,0 +1,271 @@
+package phantomtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Names of the reporters of NewReporter
+const (
+	ReporterConsole = "console" // a line per test as it ends, then the counts
+	ReporterJUnit   = "junit"   // JUnit XML, as Jenkins and GitLab read it
+	ReporterTAP     = "tap"     // TAP version 13, with YAML diagnostics
+	ReporterJSON    = "json"    // one JSON document
+)
+
+// ReporterNames are the names NewReporter accepts
+var ReporterNames = []string{ReporterConsole, ReporterJUnit, ReporterTAP, ReporterJSON}
+
+// A Reporter receives the results of a run as they happen. StartFile and
+// EndFile surround the tests of each file, and End gets the whole report,
+// also when the run was stopped early. The methods are called one at a
+// time.
+type Reporter interface {
+	StartFile(f *File)
+	EndTest(f *File, t *Test)
+	EndFile(f *File)
+	End(r *Report) error
+}
+
+// NewReporter returns the named reporter writing to w
+func NewReporter(name string, w io.Writer) (Reporter, error) {
+	switch name {
+	case ReporterConsole:
+		return &consoleReporter{w: w}, nil
+	case ReporterJUnit:
+		return &junitReporter{w: w}, nil
+	case ReporterTAP:
+		return &tapReporter{w: w}, nil
+	case ReporterJSON:
+		return &jsonReporter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown reporter %q; use %s", name, strings.Join(ReporterNames, ", "))
+}
+
+// MultiReporter passes every event to each of reporters in turn
+func MultiReporter(reporters ...Reporter) Reporter { return multiReporter(reporters) }
+
+type multiReporter []Reporter
+
+func (m multiReporter) StartFile(f *File) {
+	for _, r := range m {
+		r.StartFile(f)
+	}
+}
+
+func (m multiReporter) EndTest(f *File, t *Test) {
+	for _, r := range m {
+		r.EndTest(f, t)
+	}
+}
+
+func (m multiReporter) EndFile(f *File) {
+	for _, r := range m {
+		r.EndFile(f)
+	}
+}
+
+func (m multiReporter) End(report *Report) error {
+	var first error
+	for _, r := range m {
+		if err := r.End(report); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// consoleReporter prints results for people
+type consoleReporter struct {
+	w io.Writer
+}
+
+func (c *consoleReporter) StartFile(f *File) { fmt.Fprintf(c.w, "📄 %s\n", f.Path) }
+
+func (c *consoleReporter) EndTest(_ *File, t *Test) {
+	switch t.Status {
+	case StatusPassed:
+		fmt.Fprintf(c.w, "  ✅ %s (%v)\n", t.FullName(), t.Duration.Round(time.Millisecond))
+	case StatusFailed:
+		fmt.Fprintf(c.w, "  ❌ %s (%v)\n     %s\n", t.FullName(), t.Duration.Round(time.Millisecond), indent(t.Error))
+	case StatusSkipped:
+		fmt.Fprintf(c.w, "  ⏭️  %s\n", t.FullName())
+	}
+	for _, path := range t.Screenshots {
+		fmt.Fprintf(c.w, "     📸 %s\n", path)
+	}
+}
+
+func (c *consoleReporter) EndFile(f *File) {
+	if f.Error != "" {
+		fmt.Fprintf(c.w, "  ❌ %s\n", indent(f.Error))
+	}
+}
+
+func (c *consoleReporter) End(r *Report) error {
+	passed, failed, skipped, errored := r.Counts()
+	counts := fmt.Sprintf("%d passed, %d failed, %d skipped", passed, failed, skipped)
+	if errored > 0 {
+		counts += fmt.Sprintf(", %d of %d files failed to run", errored, len(r.Files))
+	}
+	mark := "✅"
+	if !r.OK() {
+		mark = "❌"
+	}
+	_, err := fmt.Fprintf(c.w, "%s %s in %v\n", mark, counts, r.Duration.Round(time.Millisecond))
+	return err
+}
+
+// jsonReporter writes the report as one document when the run ends.
+// Durations are whole milliseconds.
+type jsonReporter struct {
+	w io.Writer
+}
+
+type jsonReport struct {
+	Engine  string      `json:"engine"`
+	Passed  int         `json:"passed"`
+	Failed  int         `json:"failed"`
+	Skipped int         `json:"skipped"`
+	Errors  int         `json:"errors"` // files that failed outside their tests
+	Files   []jsonFile  `json:"files"`
+	Timings jsonTimings `json:"timings"`
+}
+
+type jsonFile struct {
+	Path     string     `json:"path"`
+	Error    string     `json:"error,omitempty"`
+	Tests    []jsonTest `json:"tests"`
+	Duration int64      `json:"duration_ms"`
+}
+
+type jsonTest struct {
+	Suite       []string `json:"suite,omitempty"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Error       string   `json:"error,omitempty"`
+	Stack       string   `json:"stack,omitempty"`
+	Screenshots []string `json:"screenshots,omitempty"`
+	Duration    int64    `json:"duration_ms"`
+}
+
+type jsonTimings struct {
+	Total int64 `json:"total_ms"`
+}
+
+func (*jsonReporter) StartFile(*File)      {}
+func (*jsonReporter) EndTest(*File, *Test) {}
+func (*jsonReporter) EndFile(*File)        {}
+
+func (j *jsonReporter) End(r *Report) error {
+	doc := jsonReport{Engine: r.Engine, Files: []jsonFile{}, Timings: jsonTimings{Total: r.Duration.Milliseconds()}}
+	doc.Passed, doc.Failed, doc.Skipped, doc.Errors = r.Counts()
+	for _, f := range r.Files {
+		file := jsonFile{Path: f.Path, Error: f.Error, Tests: []jsonTest{}, Duration: f.Duration.Milliseconds()}
+		for _, t := range f.Tests {
+			file.Tests = append(file.Tests, jsonTest{
+				Suite: t.Suite, Name: t.Name, Status: t.Status, Error: t.Error, Stack: t.Stack,
+				Screenshots: t.Screenshots, Duration: t.Duration.Milliseconds(),
+			})
+		}
+		doc.Files = append(doc.Files, file)
+	}
+	enc := json.NewEncoder(j.w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// tapReporter writes TAP version 13 as tests end, with the plan last.
+// Each test but skipped ones has a YAML block with its duration, and the
+// message, stack and screenshots that apply.
+type tapReporter struct {
+	w       io.Writer
+	n       int
+	started bool
+}
+
+func (t *tapReporter) start() {
+	if !t.started {
+		t.started = true
+		fmt.Fprintln(t.w, "TAP version 13")
+	}
+}
+
+func (t *tapReporter) StartFile(f *File) {
+	t.start()
+	fmt.Fprintf(t.w, "# %s\n", f.Path)
+}
+
+func (t *tapReporter) EndTest(f *File, test *Test) {
+	t.n++
+	name := tapEscape(test.FullName())
+	switch test.Status {
+	case StatusSkipped:
+		fmt.Fprintf(t.w, "ok %d - %s # SKIP\n", t.n, name)
+		return
+	case StatusFailed:
+		fmt.Fprintf(t.w, "not ok %d - %s\n", t.n, name)
+	default:
+		fmt.Fprintf(t.w, "ok %d - %s\n", t.n, name)
+	}
+	var b bytes.Buffer
+	b.WriteString("  ---\n")
+	if test.Error != "" {
+		fmt.Fprintf(&b, "  message: %s\n", yamlString(test.Error))
+	}
+	fmt.Fprintf(&b, "  file: %s\n", yamlString(f.Path))
+	fmt.Fprintf(&b, "  duration_ms: %d\n", test.Duration.Milliseconds())
+	if test.Stack != "" {
+		b.WriteString("  stack: |-\n")
+		for _, line := range strings.Split(strings.TrimRight(test.Stack, "\n"), "\n") {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	if len(test.Screenshots) > 0 {
+		b.WriteString("  screenshots:\n")
+		for _, path := range test.Screenshots {
+			fmt.Fprintf(&b, "    - %s\n", yamlString(path))
+		}
+	}
+	b.WriteString("  ...\n")
+	t.w.Write(b.Bytes())
+}
+
+// EndFile reports a file that failed outside its tests as a failed test
+func (t *tapReporter) EndFile(f *File) {
+	if f.Error == "" {
+		return
+	}
+	t.n++
+	fmt.Fprintf(t.w, "not ok %d - %s\n  ---\n  message: %s\n  ...\n", t.n, tapEscape(f.Path), yamlString(f.Error))
+}
+
+func (t *tapReporter) End(r *Report) error {
+	t.start()
+	passed, failed, skipped, errored := r.Counts()
+	_, err := fmt.Fprintf(t.w, "1..%d\n# tests %d\n# pass %d\n# fail %d\n# skip %d\n",
+		t.n, t.n, passed, failed+errored, skipped)
+	return err
+}
+
+// tapEscape keeps a description on one line and its "#" from starting a
+// directive
+func tapEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "#", "\\#")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// yamlString quotes s; a JSON string is a YAML double-quoted scalar
+func yamlString(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,447 @@
+// Package pool shares browsers and their pages between concurrent callers.
+// A Pool runs up to Browsers engines with up to PagesPerBrowser pages each,
+// and up to MaxPages pages in all, launching them as they are needed. Pages go back to the pool when they
+// are released, browsers are replaced after MaxUses pages, and idle
+// browsers that fail a health check are closed.
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// ErrClosed is returned by Acquire once the pool is closed
+var ErrClosed = errors.New("pool: closed")
+
+// Launcher starts and initializes a browser
+type Launcher func(ctx context.Context) (engine.Engine, error)
+
+// Checker reports whether an idle browser still works
+type Checker func(ctx context.Context, e engine.Engine) error
+
+// Config configures a Pool. Zero values select the defaults.
+type Config struct {
+	Launch          Launcher      // required; see Open
+	Browsers        int           // browsers running at once; 1 by default
+	PagesPerBrowser int           // pages open on each browser; 4 by default
+	MaxPages        int           // pages in use at once; Browsers*PagesPerBrowser by default
+	MaxUses         int           // pages a browser hands out before it is replaced; 0 for no limit
+	HealthCheck     time.Duration // how often idle browsers are checked; 0 disables checks
+	Check           Checker       // CheckPages by default
+	CheckTimeout    time.Duration // limit of each check; 10s by default
+}
+
+// Open launches browsers of the named engine with engine.Open
+func Open(name string, config engine.Config) Launcher {
+	return func(context.Context) (engine.Engine, error) {
+		return engine.Open(name, config)
+	}
+}
+
+// CheckPages is the default Checker: the browser must list its pages and
+// the first of them must report its URL
+func CheckPages(ctx context.Context, e engine.Engine) error {
+	pages, err := e.GetPages(ctx)
+	if err != nil || len(pages) == 0 {
+		return err
+	}
+	_, err = pages[0].URL()
+	return err
+}
+
+// Stats are a snapshot of a pool's state and counters
+type Stats struct {
+	Browsers int `json:"browsers"` // running, including those being replaced
+	Pages    int `json:"pages"`    // open, in use or idle
+	InUse    int `json:"in_use"`
+	Waiting  int `json:"waiting"` // Acquire calls waiting for a page
+
+	Acquired  int64 `json:"acquired"`  // pages handed out
+	Launched  int64 `json:"launched"`  // browsers started
+	Recycled  int64 `json:"recycled"`  // browsers replaced after MaxUses
+	Unhealthy int64 `json:"unhealthy"` // browsers closed after a failed check
+	Failures  int64 `json:"failures"`  // failed launches and page opens
+	WaitMS    int64 `json:"wait_ms"`   // total time Acquire spent waiting
+}
+
+// browser is one engine of the pool
+type browser struct {
+	engine   engine.Engine
+	idle     []engine.Page
+	open     int  // pages open or being opened
+	inUse    int  // pages acquired
+	uses     int  // pages handed out so far
+	retired  bool // reached MaxUses; closed once its pages are released
+	checking bool // a health check is running; not handed out meanwhile
+}
+
+// Pool hands out pages of a set of browsers. It is safe for concurrent use.
+type Pool struct {
+	config Config
+
+	mu        sync.Mutex
+	browsers  []*browser
+	launching int
+	changed   chan struct{} // closed and replaced whenever capacity frees up
+	closed    bool
+	stats     Stats
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// New returns a pool. Browsers are launched on demand by Acquire.
+func New(config Config) (*Pool, error) {
+	if config.Launch == nil {
+		return nil, errors.New("pool: Config.Launch is required")
+	}
+	if config.Browsers < 0 || config.PagesPerBrowser < 0 || config.MaxPages < 0 || config.MaxUses < 0 {
+		return nil, errors.New("pool: Browsers, PagesPerBrowser, MaxPages and MaxUses must not be negative")
+	}
+	if config.Browsers == 0 {
+		config.Browsers = 1
+	}
+	if config.PagesPerBrowser == 0 {
+		config.PagesPerBrowser = 4
+	}
+	if all := config.Browsers * config.PagesPerBrowser; config.MaxPages == 0 || config.MaxPages > all {
+		config.MaxPages = all
+	}
+	if config.Check == nil {
+		config.Check = CheckPages
+	}
+	if config.CheckTimeout == 0 {
+		config.CheckTimeout = 10 * time.Second
+	}
+
+	p := &Pool{config: config, changed: make(chan struct{}), stop: make(chan struct{})}
+	if config.HealthCheck > 0 {
+		p.done.Add(1)
+		go p.checkLoop()
+	}
+	return p, nil
+}
+
+// Page is a page acquired from a pool. Return it with Release, or with
+// Discard when it should not be used again.
+type Page struct {
+	engine.Page
+	pool     *Pool
+	browser  *browser
+	released bool
+}
+
+// Release returns the page to the pool, keeping its state, such as its
+// cookies and current URL, for the next caller. Later calls do nothing.
+func (pg *Page) Release() { pg.pool.release(pg, false) }
+
+// Discard closes the page instead of returning it, for pages left in a
+// state the next caller should not see. Later calls do nothing.
+func (pg *Page) Discard() { pg.pool.release(pg, true) }
+
+// Acquire returns an idle page, opening a page or launching a browser when
+// the limits allow, or else waits until a page is released. It fails when
+// ctx is done first, or with ErrClosed.
+func (p *Pool) Acquire(ctx context.Context) (*Page, error) {
+	var waited time.Duration
+	defer func() {
+		p.mu.Lock()
+		p.stats.WaitMS += waited.Milliseconds()
+		p.mu.Unlock()
+	}()
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrClosed
+		}
+
+		// Browsers being replaced still count, so at most MaxPages pages
+		// are in use. An idle page is cheapest, then a new page on a
+		// running browser.
+		full := p.inUse() >= p.config.MaxPages
+		for _, b := range p.browsers {
+			if full {
+				break
+			}
+			if b.retired || b.checking || len(b.idle) == 0 {
+				continue
+			}
+			page := b.idle[len(b.idle)-1]
+			b.idle = b.idle[:len(b.idle)-1]
+			pg := p.handOut(b, page)
+			p.mu.Unlock()
+			return pg, nil
+		}
+		for _, b := range p.browsers {
+			if full {
+				break
+			}
+			if b.retired || b.checking || b.open >= p.config.PagesPerBrowser {
+				continue
+			}
+			b.open++
+			pg := p.handOut(b, nil)
+			p.mu.Unlock()
+			return p.openPage(ctx, pg)
+		}
+
+		// Then a new browser, the page taken from it on the next pass.
+		if !full && p.running()+p.launching < p.config.Browsers {
+			p.launching++
+			p.mu.Unlock()
+			if err := p.launch(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		changed := p.changed
+		p.stats.Waiting++
+		p.mu.Unlock()
+
+		start := time.Now()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+		}
+		waited += time.Since(start)
+
+		p.mu.Lock()
+		p.stats.Waiting--
+		p.mu.Unlock()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// handOut counts a page of b as acquired; p.mu is held
+func (p *Pool) handOut(b *browser, page engine.Page) *Page {
+	b.inUse++
+	b.uses++
+	if p.config.MaxUses > 0 && b.uses >= p.config.MaxUses {
+		b.retired = true
+	}
+	p.stats.Acquired++
+	return &Page{Page: page, pool: p, browser: b}
+}
+
+// openPage opens the page for pg, whose slot on its browser is reserved
+func (p *Pool) openPage(ctx context.Context, pg *Page) (*Page, error) {
+	page, err := pg.browser.engine.NewPage(ctx)
+	if err != nil {
+		p.mu.Lock()
+		pg.browser.open--
+		pg.browser.inUse--
+		p.stats.Acquired--
+		p.stats.Failures++
+		p.mu.Unlock()
+		pg.released = true
+		p.finish(pg.browser)
+		return nil, err
+	}
+	pg.Page = page
+	return pg, nil
+}
+
+// launch starts a browser in the slot reserved by p.launching
+func (p *Pool) launch(ctx context.Context) error {
+	e, err := p.config.Launch(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.launching--
+	defer p.signal()
+	if err != nil {
+		p.stats.Failures++
+		return err
+	}
+	if p.closed {
+		e.Close()
+		return ErrClosed
+	}
+	p.browsers = append(p.browsers, &browser{engine: e})
+	p.stats.Launched++
+	return nil
+}
+
+// running counts the browsers that are not being replaced; p.mu is held
+func (p *Pool) running() int {
+	n := 0
+	for _, b := range p.browsers {
+		if !b.retired {
+			n++
+		}
+	}
+	return n
+}
+
+// inUse counts the acquired pages of all browsers; p.mu is held
+func (p *Pool) inUse() int {
+	n := 0
+	for _, b := range p.browsers {
+		n += b.inUse
+	}
+	return n
+}
+
+// signal wakes up waiting Acquire calls; p.mu is held
+func (p *Pool) signal() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+func (p *Pool) release(pg *Page, discard bool) {
+	p.mu.Lock()
+	if pg.released {
+		p.mu.Unlock()
+		return
+	}
+	pg.released = true
+	b := pg.browser
+	b.inUse--
+	keep := !discard && !b.retired && !p.closed && p.has(b)
+	if keep {
+		b.idle = append(b.idle, pg.Page)
+	} else {
+		b.open--
+	}
+	p.mu.Unlock()
+
+	if !keep {
+		pg.Page.Close()
+	}
+	p.finish(b)
+}
+
+// finish closes b if it is retired and no longer in use, and wakes up
+// waiting Acquire calls either way
+func (p *Pool) finish(b *browser) {
+	p.mu.Lock()
+	drained := b.retired && b.inUse == 0 && p.remove(b)
+	if drained {
+		p.stats.Recycled++
+	}
+	p.signal()
+	p.mu.Unlock()
+
+	if drained {
+		b.engine.Close()
+	}
+}
+
+// has reports whether b is still one of the pool's browsers; p.mu is held
+func (p *Pool) has(b *browser) bool {
+	for _, other := range p.browsers {
+		if other == b {
+			return true
+		}
+	}
+	return false
+}
+
+// remove takes b out of the pool, reporting whether it was in it; p.mu is held
+func (p *Pool) remove(b *browser) bool {
+	for i, other := range p.browsers {
+		if other == b {
+			p.browsers = append(p.browsers[:i], p.browsers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Stats returns the pool's current state and counters
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.stats
+	s.Browsers = len(p.browsers)
+	for _, b := range p.browsers {
+		s.Pages += b.open
+		s.InUse += b.inUse
+	}
+	return s
+}
+
+// checkLoop runs the health checks until the pool is closed
+func (p *Pool) checkLoop() {
+	defer p.done.Done()
+	ticker := time.NewTicker(p.config.HealthCheck)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.CheckHealth()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// CheckHealth checks the browsers that have no page in use and closes the
+// ones that fail; Acquire launches replacements as needed. Pools with a
+// HealthCheck interval call it on their own.
+func (p *Pool) CheckHealth() {
+	p.mu.Lock()
+	var idle []*browser
+	for _, b := range p.browsers {
+		if b.inUse == 0 && !b.retired && !b.checking {
+			b.checking = true
+			idle = append(idle, b)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, b := range idle {
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.CheckTimeout)
+		err := p.config.Check(ctx, b.engine)
+		cancel()
+
+		p.mu.Lock()
+		b.checking = false
+		failed := err != nil && p.remove(b)
+		if failed {
+			p.stats.Unhealthy++
+		}
+		p.signal()
+		p.mu.Unlock()
+
+		if failed {
+			b.engine.Close()
+		}
+	}
+}
+
+// Close closes every browser, including those with pages in use, and
+// makes waiting and later Acquire calls fail with ErrClosed. It returns
+// the first error of closing a browser.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	browsers := p.browsers
+	p.browsers = nil
+	p.signal()
+	p.mu.Unlock()
+
+	close(p.stop)
+	p.done.Wait()
+
+	var first error
+	for _, b := range browsers {
+		if err := b.engine.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,285 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// launcher launches fake engines and keeps them in order
+type launcher struct {
+	mu      sync.Mutex
+	engines []*enginetest.Engine
+	err     error
+}
+
+func (l *launcher) launch(context.Context) (engine.Engine, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return nil, l.err
+	}
+	e := &enginetest.Engine{}
+	l.engines = append(l.engines, e)
+	return e, nil
+}
+
+func newPool(t *testing.T, config Config) (*Pool, *launcher) {
+	t.Helper()
+	l := &launcher{}
+	config.Launch = l.launch
+	p, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p, l
+}
+
+func acquire(t *testing.T, p *Pool) *Page {
+	t.Helper()
+	pg, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	return pg
+}
+
+func TestAcquireReusesReleasedPages(t *testing.T) {
+	p, l := newPool(t, Config{PagesPerBrowser: 2})
+
+	first := acquire(t, p)
+	page := first.Page
+	first.Release()
+	first.Release() // a second release does nothing
+
+	second := acquire(t, p)
+	if second.Page != page {
+		t.Errorf("expected the released page to be handed out again")
+	}
+	third := acquire(t, p)
+	if third.Page == page || len(l.engines) != 1 {
+		t.Errorf("expected a second page on the same browser, got %d browsers", len(l.engines))
+	}
+
+	s := p.Stats()
+	if s.Browsers != 1 || s.Pages != 2 || s.InUse != 2 || s.Acquired != 3 || s.Launched != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestAcquireSpreadsOverBrowsers(t *testing.T) {
+	p, l := newPool(t, Config{Browsers: 2, PagesPerBrowser: 1})
+
+	a, b := acquire(t, p), acquire(t, p)
+	if len(l.engines) != 2 || a.browser == b.browser {
+		t.Fatalf("expected one page on each of 2 browsers, got %d browsers", len(l.engines))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the full pool to wait until the deadline, got %v", err)
+	}
+	if s := p.Stats(); s.WaitMS < 40 || s.Waiting != 0 {
+		t.Errorf("expected the wait to be counted, got %+v", s)
+	}
+
+	got := make(chan *Page)
+	go func() {
+		pg, _ := p.Acquire(context.Background())
+		got <- pg
+	}()
+	time.Sleep(10 * time.Millisecond)
+	a.Release()
+	select {
+	case pg := <-got:
+		if pg.Page != a.Page {
+			t.Errorf("expected the waiting caller to get the released page")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiting caller did not get the released page")
+	}
+}
+
+func TestMaxPagesCapsAllBrowsers(t *testing.T) {
+	p, l := newPool(t, Config{Browsers: 2, PagesPerBrowser: 2, MaxPages: 3})
+
+	for i := 0; i < 3; i++ {
+		acquire(t, p)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a fourth page to wait, got %v", err)
+	}
+	if s := p.Stats(); s.InUse != 3 || len(l.engines) != 2 {
+		t.Errorf("expected 3 pages over 2 browsers, got %+v", s)
+	}
+}
+
+func TestMaxUsesRecyclesBrowser(t *testing.T) {
+	p, l := newPool(t, Config{PagesPerBrowser: 2, MaxUses: 2})
+
+	a, b := acquire(t, p), acquire(t, p)
+	if !a.browser.retired {
+		t.Fatalf("expected the browser to retire after 2 uses")
+	}
+	a.Release()
+	c := acquire(t, p)
+	if len(l.engines) != 2 || c.browser == a.browser {
+		t.Fatalf("expected a replacement browser, got %d browsers", len(l.engines))
+	}
+	if l.engines[0].Closed() {
+		t.Errorf("expected the retired browser to stay open while a page is in use")
+	}
+	b.Release()
+	if !l.engines[0].Closed() {
+		t.Errorf("expected the retired browser to close once drained")
+	}
+	if s := p.Stats(); s.Recycled != 1 || s.Browsers != 1 || s.InUse != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestDiscardClosesPage(t *testing.T) {
+	p, _ := newPool(t, Config{PagesPerBrowser: 1})
+
+	a := acquire(t, p)
+	page := a.Page.(*enginetest.Page)
+	a.Discard()
+	if !page.Closed() {
+		t.Errorf("expected the discarded page to be closed")
+	}
+	if b := acquire(t, p); b.Page == page {
+		t.Errorf("expected a new page after a discard")
+	}
+}
+
+func TestCheckHealthReplacesBrokenBrowser(t *testing.T) {
+	p, l := newPool(t, Config{})
+
+	acquire(t, p).Release()
+	l.engines[0].Break(errors.New("browser is gone"))
+
+	p.CheckHealth()
+	if !l.engines[0].Closed() {
+		t.Fatalf("expected the broken browser to be closed")
+	}
+	if pg := acquire(t, p); pg.browser.engine != l.engines[1] {
+		t.Errorf("expected a page of a new browser")
+	}
+	if s := p.Stats(); s.Unhealthy != 1 || s.Launched != 2 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestHealthCheckSkipsBusyBrowsers(t *testing.T) {
+	checked := make(chan struct{}, 10)
+	p, _ := newPool(t, Config{HealthCheck: 5 * time.Millisecond, Check: func(context.Context, engine.Engine) error {
+		checked <- struct{}{}
+		return errors.New("unhealthy")
+	}})
+
+	pg := acquire(t, p)
+	time.Sleep(30 * time.Millisecond)
+	if len(checked) != 0 {
+		t.Fatalf("expected no checks while a page is in use")
+	}
+	pg.Release()
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		t.Fatal("expected the idle browser to be checked")
+	}
+}
+
+func TestLaunchFailure(t *testing.T) {
+	p, l := newPool(t, Config{})
+	l.err = errors.New("chrome not found")
+
+	if _, err := p.Acquire(context.Background()); err != l.err {
+		t.Fatalf("expected the launch error, got %v", err)
+	}
+	l.err = nil
+	acquire(t, p)
+	if s := p.Stats(); s.Failures != 1 || s.Launched != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestCloseStopsAcquire(t *testing.T) {
+	p, l := newPool(t, Config{PagesPerBrowser: 1})
+	pg := acquire(t, p)
+
+	errs := make(chan error)
+	go func() {
+		_, err := p.Acquire(context.Background())
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != ErrClosed {
+		t.Errorf("expected ErrClosed for the waiting caller, got %v", err)
+	}
+	if !l.engines[0].Closed() {
+		t.Errorf("expected the browser to be closed")
+	}
+	pg.Release() // after Close, releasing only closes the page
+	if !pg.Page.(*enginetest.Page).Closed() {
+		t.Errorf("expected the page to be closed")
+	}
+}
+
+func TestConcurrentAcquire(t *testing.T) {
+	p, l := newPool(t, Config{Browsers: 2, PagesPerBrowser: 3, MaxUses: 10})
+
+	var inUse, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pg, err := p.Acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := inUse.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			inUse.Add(-1)
+			pg.Release()
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 6 {
+		t.Errorf("expected at most 6 pages in use, saw %d", peak.Load())
+	}
+	if s := p.Stats(); s.Acquired != 50 || s.InUse != 0 || int(s.Launched) != len(l.engines) || s.Launched < 5 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Errorf("expected Launch to be required")
+	}
+	if _, err := New(Config{Launch: (&launcher{}).launch, Browsers: -1}); err == nil {
+		t.Errorf("expected negative Browsers to fail")
+	}
+}
@@ -0,0 +1,597 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"phantomvite/pkg/engine"
+	pb "phantomvite/pkg/remote/remotepb"
+)
+
+const engineName = "remote"
+
+// callGrace is added to the deadline of every call, so the server's own
+// timeouts fail first and with their kind
+const callGrace = 10 * time.Second
+
+// Engine is an engine.Engine whose browser runs on a Browser server.
+// Initialize opens a session there and Close ends it.
+type Engine struct {
+	client pb.BrowserClient
+	conn   *grpc.ClientConn // closed with the engine when Dial opened it
+	name   string           // the engine the server opens
+	config engine.Config
+
+	session string
+	mu      sync.Mutex
+	pages   map[string]*Page
+}
+
+var (
+	_ engine.Engine      = (*Engine)(nil)
+	_ engine.Page        = (*Page)(nil)
+	_ engine.EventSource = (*Page)(nil)
+)
+
+// New returns an engine that opens the named engine through cc
+func New(cc grpc.ClientConnInterface, name string) *Engine {
+	return &Engine{client: pb.NewBrowserClient(cc), name: name, pages: make(map[string]*Page)}
+}
+
+// Dial connects to the server at target and returns an engine that opens
+// the named engine there. Without options the connection is plaintext.
+func Dial(target, name string, options ...grpc.DialOption) (*Engine, error) {
+	if len(options) == 0 {
+		options = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		return nil, engine.NewEngineError(engineName, "launch", "failed to connect to "+target, err)
+	}
+	e := New(conn, name)
+	e.conn = conn
+	return e, nil
+}
+
+// Name is "remote:" and the name of the engine on the server
+func (e *Engine) Name() string { return engineName + ":" + e.name }
+
+// context bounds a call that may take timeout, or the engine's timeout
+func (e *Engine) context(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = e.config.Timeout
+	}
+	return context.WithTimeout(context.Background(), timeout+callGrace)
+}
+
+func (e *Engine) Initialize(config engine.Config) error {
+	if config.Timeout <= 0 {
+		config.Timeout = engine.DefaultConfig().Timeout
+	}
+	e.config = config
+	ctx, cancel := e.context(0)
+	defer cancel()
+	session, err := e.client.OpenSession(ctx, &pb.OpenSessionRequest{Engine: e.name, Config: configToProto(config)})
+	if err != nil {
+		return fromStatus("initialize", err)
+	}
+	e.session = session.Id
+	return nil
+}
+
+func (e *Engine) Close() error {
+	var err error
+	if e.session != "" {
+		ctx, cancel := e.context(0)
+		_, err = e.client.CloseSession(ctx, &pb.SessionRef{SessionId: e.session})
+		cancel()
+		e.session = ""
+		if err != nil {
+			err = fromStatus("close", err)
+		}
+	}
+	if e.conn != nil {
+		e.conn.Close()
+	}
+	return err
+}
+
+// page returns the Page of id, the same one every time
+func (e *Engine) page(id string) *Page {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	p, ok := e.pages[id]
+	if !ok {
+		p = &Page{engine: e, id: id}
+		e.pages[id] = p
+	}
+	return p
+}
+
+func (e *Engine) Navigate(ctx context.Context, url string) (engine.Page, error) {
+	page, err := e.NewPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := page.Navigate(url, nil); err != nil {
+		page.Close()
+		return nil, err
+	}
+	return page, nil
+}
+
+func (e *Engine) NewPage(ctx context.Context) (engine.Page, error) {
+	ref, err := e.client.NewPage(ctx, &pb.SessionRef{SessionId: e.session})
+	if err != nil {
+		return nil, fromStatus("new page", err)
+	}
+	return e.page(ref.PageId), nil
+}
+
+func (e *Engine) GetPages(ctx context.Context) ([]engine.Page, error) {
+	res, err := e.client.ListPages(ctx, &pb.SessionRef{SessionId: e.session})
+	if err != nil {
+		return nil, fromStatus("get pages", err)
+	}
+	pages := make([]engine.Page, len(res.Pages))
+	for i, ref := range res.Pages {
+		pages[i] = e.page(ref.PageId)
+	}
+	return pages, nil
+}
+
+// Screenshot captures the last page the server lists
+func (e *Engine) Screenshot(ctx context.Context, options engine.ScreenshotOptions) error {
+	pages, err := e.GetPages(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pages) == 0 {
+		return engine.NewEngineError(engineName, "screenshot", "no page has been opened", nil)
+	}
+	return pages[len(pages)-1].Screenshot(options)
+}
+
+func (e *Engine) SetUserAgent(userAgent string) error {
+	ctx, cancel := e.context(0)
+	defer cancel()
+	_, err := e.client.SetUserAgent(ctx, &pb.SetUserAgentRequest{SessionId: e.session, UserAgent: userAgent})
+	return e.fail("set user agent", err)
+}
+
+func (e *Engine) SetExtraHeaders(headers map[string]string) error {
+	ctx, cancel := e.context(0)
+	defer cancel()
+	_, err := e.client.SetExtraHeaders(ctx, &pb.SetExtraHeadersRequest{SessionId: e.session, Headers: headers})
+	return e.fail("set extra headers", err)
+}
+
+func (e *Engine) SetViewport(viewport engine.ViewportConfig) error {
+	ctx, cancel := e.context(0)
+	defer cancel()
+	_, err := e.client.SetSessionViewport(ctx, &pb.SetSessionViewportRequest{SessionId: e.session, Viewport: viewportToProto(viewport)})
+	return e.fail("set viewport", err)
+}
+
+// fail converts the error of a call made for operation, if any
+func (e *Engine) fail(operation string, err error) error {
+	if err != nil {
+		return fromStatus(operation, err)
+	}
+	return nil
+}
+
+// Page is a page on the server
+type Page struct {
+	engine *Engine
+	id     string
+}
+
+// call runs a call of the page that may take timeout, or the engine's
+// timeout, and converts its error
+func (p *Page) call(operation string, timeout time.Duration, fn func(ctx context.Context, c pb.BrowserClient) error) error {
+	ctx, cancel := p.engine.context(timeout)
+	defer cancel()
+	return p.engine.fail(operation, fn(ctx, p.engine.client))
+}
+
+func (p *Page) ref() *pb.PageRef { return &pb.PageRef{PageId: p.id} }
+
+func (p *Page) info() (*pb.PageInfo, error) {
+	var info *pb.PageInfo
+	err := p.call("get page info", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		info, err = c.GetPageInfo(ctx, p.ref())
+		return err
+	})
+	return info, err
+}
+
+func (p *Page) Title() (string, error) {
+	info, err := p.info()
+	return info.GetTitle(), err
+}
+
+func (p *Page) URL() (string, error) {
+	info, err := p.info()
+	return info.GetUrl(), err
+}
+
+func (p *Page) Content() (string, error) {
+	var content *pb.Content
+	err := p.call("get content", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		content, err = c.GetContent(ctx, p.ref())
+		return err
+	})
+	return content.GetHtml(), err
+}
+
+// navigationTimeout is how long a navigation may take on the server
+func navigationTimeout(options *engine.NavigationOptions) time.Duration {
+	if options == nil {
+		return 0
+	}
+	return options.Timeout
+}
+
+func (p *Page) Navigate(url string, options *engine.NavigationOptions) error {
+	return p.call("navigate", navigationTimeout(options), func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Navigate(ctx, &pb.NavigateRequest{PageId: p.id, Url: url, Options: navigationToProto(options)})
+		return err
+	})
+}
+
+func (p *Page) Reload(options *engine.NavigationOptions) error {
+	return p.call("reload", navigationTimeout(options), func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Reload(ctx, &pb.ReloadRequest{PageId: p.id, Options: navigationToProto(options)})
+		return err
+	})
+}
+
+func (p *Page) GoBack() error {
+	return p.call("go back", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.GoBack(ctx, p.ref())
+		return err
+	})
+}
+
+func (p *Page) GoForward() error {
+	return p.call("go forward", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.GoForward(ctx, p.ref())
+		return err
+	})
+}
+
+// query matches selector in the page, or in the element of elementID
+func (p *Page) query(selector string, all bool, elementID string) ([]engine.ElementHandle, error) {
+	var res *pb.QueryResponse
+	err := p.call("query selector", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		res, err = c.Query(ctx, &pb.QueryRequest{PageId: p.id, Selector: selector, All: all, ElementId: elementID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	handles := make([]engine.ElementHandle, len(res.Elements))
+	for i, ref := range res.Elements {
+		handles[i] = &Element{page: p, id: ref.ElementId}
+	}
+	return handles, nil
+}
+
+// QuerySelector returns nil when nothing matches
+func (p *Page) QuerySelector(selector string) (engine.ElementHandle, error) {
+	handles, err := p.query(selector, false, "")
+	if err != nil || len(handles) == 0 {
+		return nil, err
+	}
+	return handles[0], nil
+}
+
+func (p *Page) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	return p.query(selector, true, "")
+}
+
+func waitTimeout(options *engine.WaitOptions) time.Duration {
+	if options == nil {
+		return 0
+	}
+	return options.Timeout
+}
+
+func (p *Page) WaitForSelector(selector string, options *engine.WaitOptions) (engine.ElementHandle, error) {
+	var ref *pb.ElementRef
+	err := p.call("wait for selector", waitTimeout(options), func(ctx context.Context, c pb.BrowserClient) (err error) {
+		ref, err = c.WaitForSelector(ctx, &pb.WaitForSelectorRequest{PageId: p.id, Selector: selector, Options: waitToProto(options)})
+		return err
+	})
+	if err != nil || ref.ElementId == "" {
+		return nil, err
+	}
+	return &Element{page: p, id: ref.ElementId}, nil
+}
+
+func (p *Page) evaluate(script string, async bool) (interface{}, error) {
+	var result interface{}
+	err := p.call("execute script", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		value, err := c.Evaluate(ctx, &pb.EvaluateRequest{PageId: p.id, Script: script, Async: async})
+		result = value.AsInterface()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *Page) ExecuteScript(script string) (interface{}, error) {
+	return p.evaluate(script, false)
+}
+
+func (p *Page) ExecuteScriptAsync(script string) (interface{}, error) {
+	return p.evaluate(script, true)
+}
+
+func (p *Page) Click(selector string) error {
+	return p.call("click", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Click(ctx, &pb.SelectorRequest{PageId: p.id, Selector: selector})
+		return err
+	})
+}
+
+func (p *Page) Type(selector string, text string) error {
+	return p.call("type", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Type(ctx, &pb.InputRequest{PageId: p.id, Selector: selector, Text: text})
+		return err
+	})
+}
+
+func (p *Page) Fill(selector string, text string) error {
+	return p.call("fill", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Fill(ctx, &pb.InputRequest{PageId: p.id, Selector: selector, Text: text})
+		return err
+	})
+}
+
+func (p *Page) Select(selector string, values ...string) error {
+	return p.call("select", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.Select(ctx, &pb.SelectRequest{PageId: p.id, Selector: selector, Values: values})
+		return err
+	})
+}
+
+// Screenshot writes the image the server returns to options.Path
+func (p *Page) Screenshot(options engine.ScreenshotOptions) error {
+	if options.Path == "" {
+		return &engine.EngineError{Engine: engineName, Operation: "screenshot", Message: "a path is required", Kind: engine.KindConfig}
+	}
+	var artifact *pb.Artifact
+	err := p.call("screenshot", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		artifact, err = c.Screenshot(ctx, screenshotToProto(p.id, options))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return p.save("screenshot", options.Path, artifact)
+}
+
+// PDF writes the PDF the server returns to options.Path
+func (p *Page) PDF(options engine.PDFOptions) error {
+	if options.Path == "" {
+		return &engine.EngineError{Engine: engineName, Operation: "pdf", Message: "a path is required", Kind: engine.KindConfig}
+	}
+	var artifact *pb.Artifact
+	err := p.call("pdf", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		artifact, err = c.PDF(ctx, pdfToProto(p.id, options))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return p.save("pdf", options.Path, artifact)
+}
+
+func (p *Page) save(operation, path string, artifact *pb.Artifact) error {
+	if err := os.WriteFile(path, artifact.Data, 0644); err != nil {
+		return engine.NewEngineError(engineName, operation, "failed to write "+path, err)
+	}
+	return nil
+}
+
+func (p *Page) WaitForNavigation(options *engine.NavigationOptions) error {
+	return p.call("wait for navigation", navigationTimeout(options), func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.WaitForNavigation(ctx, &pb.ReloadRequest{PageId: p.id, Options: navigationToProto(options)})
+		return err
+	})
+}
+
+func (p *Page) WaitForTimeout(timeout time.Duration) error {
+	return p.call("wait for timeout", timeout, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.WaitForTimeout(ctx, &pb.WaitForTimeoutRequest{PageId: p.id, Timeout: durationpb.New(timeout)})
+		return err
+	})
+}
+
+func (p *Page) WaitForFunction(pageFunction string, options *engine.WaitOptions) error {
+	return p.call("wait for function", waitTimeout(options), func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.WaitForFunction(ctx, &pb.WaitForFunctionRequest{PageId: p.id, Function: pageFunction, Options: waitToProto(options)})
+		return err
+	})
+}
+
+func (p *Page) GetCookies() ([]engine.Cookie, error) {
+	var res *pb.Cookies
+	err := p.call("get cookies", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		res, err = c.GetCookies(ctx, p.ref())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cookiesFromProto(res.Cookies), nil
+}
+
+func (p *Page) SetCookies(cookies []engine.Cookie) error {
+	return p.call("set cookies", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.SetCookies(ctx, &pb.SetCookiesRequest{PageId: p.id, Cookies: cookiesToProto(cookies)})
+		return err
+	})
+}
+
+func (p *Page) ClearCookies() error {
+	return p.call("clear cookies", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.ClearCookies(ctx, p.ref())
+		return err
+	})
+}
+
+func (p *Page) SetViewport(viewport engine.ViewportConfig) error {
+	return p.call("set viewport", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.SetViewport(ctx, &pb.SetViewportRequest{PageId: p.id, Viewport: viewportToProto(viewport)})
+		return err
+	})
+}
+
+func (p *Page) GetMetrics() (map[string]interface{}, error) {
+	var metrics map[string]interface{}
+	err := p.call("get metrics", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		res, err := c.GetMetrics(ctx, p.ref())
+		metrics = res.AsMap()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (p *Page) EmulateDevice(device engine.Device) error {
+	return p.call("emulate device", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.EmulateDevice(ctx, &pb.EmulateDeviceRequest{PageId: p.id, Device: deviceToProto(device)})
+		return err
+	})
+}
+
+func (p *Page) Close() error {
+	p.engine.mu.Lock()
+	delete(p.engine.pages, p.id)
+	p.engine.mu.Unlock()
+	return p.call("close", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.ClosePage(ctx, p.ref())
+		return err
+	})
+}
+
+// Events streams the page's events from the server. It fails when the
+// engine on the server cannot report events.
+func (p *Page) Events(ctx context.Context) (<-chan engine.Event, error) {
+	stream, err := p.engine.client.Events(ctx, p.ref())
+	if err != nil {
+		return nil, fromStatus("events", err)
+	}
+	// Without the server's header the call failed, and Recv says why
+	header, err := stream.Header()
+	if err == nil && len(header.Get(eventsHeader)) == 0 {
+		_, err = stream.Recv()
+		if err == io.EOF {
+			err = errors.New("event stream ended before it started")
+		}
+	}
+	if err != nil {
+		return nil, fromStatus("events", err)
+	}
+	events := make(chan engine.Event, 16)
+	go func() {
+		defer close(events)
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case events <- eventFromProto(ev):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// Element is an element handle on the server, valid until its page closes
+type Element struct {
+	page *Page
+	id   string
+}
+
+func (e *Element) ref() *pb.ElementRef { return &pb.ElementRef{PageId: e.page.id, ElementId: e.id} }
+
+func (e *Element) Click() error {
+	return e.page.call("click", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.ClickElement(ctx, e.ref())
+		return err
+	})
+}
+
+func (e *Element) Type(text string) error {
+	return e.page.call("type", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		_, err := c.TypeElement(ctx, &pb.TypeElementRequest{Element: e.ref(), Text: text})
+		return err
+	})
+}
+
+// QuerySelectorAll returns the descendants of the element matching selector
+func (e *Element) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	return e.page.query(selector, true, e.id)
+}
+
+func (e *Element) GetAttribute(name string) (string, error) {
+	var res *pb.AttributeResponse
+	err := e.page.call("get attribute", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		res, err = c.GetAttribute(ctx, &pb.ElementValueRequest{Element: e.ref(), Name: name})
+		return err
+	})
+	return res.GetValue(), err
+}
+
+func (e *Element) GetProperty(name string) (interface{}, error) {
+	var result interface{}
+	err := e.page.call("get property", 0, func(ctx context.Context, c pb.BrowserClient) error {
+		value, err := c.GetProperty(ctx, &pb.ElementValueRequest{Element: e.ref(), Name: name})
+		result = value.AsInterface()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (e *Element) IsVisible() (bool, error) {
+	var res *pb.IsVisibleResponse
+	err := e.page.call("is visible", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		res, err = c.IsVisible(ctx, e.ref())
+		return err
+	})
+	return res.GetVisible(), err
+}
+
+func (e *Element) BoundingBox() (*engine.BoundingBox, error) {
+	var res *pb.BoundingBoxResponse
+	err := e.page.call("bounding box", 0, func(ctx context.Context, c pb.BrowserClient) (err error) {
+		res, err = c.GetBoundingBox(ctx, e.ref())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return boxFromProto(res.Box), nil
+}
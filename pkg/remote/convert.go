@@ -0,0 +1,267 @@
+package remote
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"phantomvite/pkg/engine"
+	pb "phantomvite/pkg/remote/remotepb"
+)
+
+// Conversions between the engine types and their messages. Nil messages
+// convert to zero values and zero durations to unset fields.
+
+func durationToProto(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
+
+func configToProto(c engine.Config) *pb.Config {
+	return &pb.Config{
+		Viewport:     viewportToProto(c.Viewport),
+		Timeout:      durationToProto(c.Timeout),
+		UserAgent:    c.UserAgent,
+		ExtraHeaders: c.ExtraHeaders,
+	}
+}
+
+// configFromProto applies the page settings set in p to defaults, which
+// keep the server's launch settings.
+func configFromProto(p *pb.Config, defaults engine.Config) engine.Config {
+	c := defaults
+	if v := viewportFromProto(p.GetViewport()); v.Width > 0 && v.Height > 0 {
+		c.Viewport = v
+	}
+	if t := p.GetTimeout().AsDuration(); t > 0 {
+		c.Timeout = t
+	}
+	if p.GetUserAgent() != "" {
+		c.UserAgent = p.UserAgent
+	}
+	if len(p.GetExtraHeaders()) > 0 {
+		c.ExtraHeaders = p.ExtraHeaders
+	}
+	return c
+}
+
+func viewportToProto(v engine.ViewportConfig) *pb.Viewport {
+	return &pb.Viewport{Width: int32(v.Width), Height: int32(v.Height)}
+}
+
+func viewportFromProto(p *pb.Viewport) engine.ViewportConfig {
+	return engine.ViewportConfig{Width: int(p.GetWidth()), Height: int(p.GetHeight())}
+}
+
+func navigationToProto(o *engine.NavigationOptions) *pb.NavigationOptions {
+	if o == nil {
+		return nil
+	}
+	return &pb.NavigationOptions{
+		Timeout:         durationToProto(o.Timeout),
+		WaitUntil:       o.WaitUntil,
+		Referer:         o.Referer,
+		WaitForSelector: o.WaitForSelector,
+	}
+}
+
+func navigationFromProto(p *pb.NavigationOptions) *engine.NavigationOptions {
+	if p == nil {
+		return nil
+	}
+	return &engine.NavigationOptions{
+		Timeout:         p.Timeout.AsDuration(),
+		WaitUntil:       p.WaitUntil,
+		Referer:         p.Referer,
+		WaitForSelector: p.WaitForSelector,
+	}
+}
+
+func waitToProto(o *engine.WaitOptions) *pb.WaitOptions {
+	if o == nil {
+		return nil
+	}
+	return &pb.WaitOptions{
+		Timeout:    durationToProto(o.Timeout),
+		Visible:    o.Visible,
+		Hidden:     o.Hidden,
+		Polling:    durationToProto(o.Polling),
+		RetryCount: int32(o.RetryCount),
+	}
+}
+
+func waitFromProto(p *pb.WaitOptions) *engine.WaitOptions {
+	if p == nil {
+		return nil
+	}
+	return &engine.WaitOptions{
+		Timeout:    p.Timeout.AsDuration(),
+		Visible:    p.Visible,
+		Hidden:     p.Hidden,
+		Polling:    p.Polling.AsDuration(),
+		RetryCount: int(p.RetryCount),
+	}
+}
+
+func boxToProto(b *engine.BoundingBox) *pb.BoundingBox {
+	if b == nil {
+		return nil
+	}
+	return &pb.BoundingBox{X: b.X, Y: b.Y, Width: b.Width, Height: b.Height}
+}
+
+func boxFromProto(p *pb.BoundingBox) *engine.BoundingBox {
+	if p == nil {
+		return nil
+	}
+	return &engine.BoundingBox{X: p.X, Y: p.Y, Width: p.Width, Height: p.Height}
+}
+
+func screenshotToProto(pageID string, o engine.ScreenshotOptions) *pb.ScreenshotRequest {
+	req := &pb.ScreenshotRequest{
+		PageId:         pageID,
+		Format:         o.Format,
+		Quality:        int32(o.Quality),
+		FullPage:       o.FullPage,
+		OmitBackground: o.OmitBackground,
+	}
+	if o.Clip != nil {
+		req.Clip = &pb.BoundingBox{X: o.Clip.X, Y: o.Clip.Y, Width: o.Clip.Width, Height: o.Clip.Height}
+	}
+	return req
+}
+
+func screenshotFromProto(p *pb.ScreenshotRequest) engine.ScreenshotOptions {
+	o := engine.ScreenshotOptions{
+		Format:         p.Format,
+		Quality:        int(p.Quality),
+		FullPage:       p.FullPage,
+		OmitBackground: p.OmitBackground,
+	}
+	if c := p.Clip; c != nil {
+		o.Clip = &engine.ClipOptions{X: c.X, Y: c.Y, Width: c.Width, Height: c.Height}
+	}
+	return o
+}
+
+func pdfToProto(pageID string, o engine.PDFOptions) *pb.PDFRequest {
+	req := &pb.PDFRequest{
+		PageId:          pageID,
+		Format:          o.Format,
+		Width:           o.Width,
+		Height:          o.Height,
+		Landscape:       o.Landscape,
+		Scale:           o.Scale,
+		PrintBackground: o.PrintBackground,
+		PageRanges:      o.PageRanges,
+		HeaderTemplate:  o.HeaderTemplate,
+		FooterTemplate:  o.FooterTemplate,
+	}
+	if m := o.Margin; m != nil {
+		req.Margin = &pb.PDFMargin{Top: m.Top, Right: m.Right, Bottom: m.Bottom, Left: m.Left}
+	}
+	return req
+}
+
+func pdfFromProto(p *pb.PDFRequest) engine.PDFOptions {
+	o := engine.PDFOptions{
+		Format:          p.Format,
+		Width:           p.Width,
+		Height:          p.Height,
+		Landscape:       p.Landscape,
+		Scale:           p.Scale,
+		PrintBackground: p.PrintBackground,
+		PageRanges:      p.PageRanges,
+		HeaderTemplate:  p.HeaderTemplate,
+		FooterTemplate:  p.FooterTemplate,
+	}
+	if m := p.Margin; m != nil {
+		o.Margin = &engine.PDFMargin{Top: m.Top, Right: m.Right, Bottom: m.Bottom, Left: m.Left}
+	}
+	return o
+}
+
+func cookiesToProto(cookies []engine.Cookie) []*pb.Cookie {
+	out := make([]*pb.Cookie, len(cookies))
+	for i, c := range cookies {
+		out[i] = &pb.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  c.Expires,
+			HttpOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: c.SameSite,
+			Priority: c.Priority,
+		}
+	}
+	return out
+}
+
+func cookiesFromProto(cookies []*pb.Cookie) []engine.Cookie {
+	out := make([]engine.Cookie, len(cookies))
+	for i, c := range cookies {
+		out[i] = engine.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  c.Expires,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+			SameSite: c.SameSite,
+			Priority: c.Priority,
+		}
+	}
+	return out
+}
+
+func deviceToProto(d engine.Device) *pb.Device {
+	return &pb.Device{
+		Name:              d.Name,
+		UserAgent:         d.UserAgent,
+		Viewport:          viewportToProto(d.Viewport),
+		DeviceScaleFactor: d.DeviceScaleFactor,
+		IsMobile:          d.IsMobile,
+		HasTouch:          d.HasTouch,
+	}
+}
+
+func deviceFromProto(p *pb.Device) engine.Device {
+	return engine.Device{
+		Name:              p.GetName(),
+		UserAgent:         p.GetUserAgent(),
+		Viewport:          viewportFromProto(p.GetViewport()),
+		DeviceScaleFactor: p.GetDeviceScaleFactor(),
+		IsMobile:          p.GetIsMobile(),
+		HasTouch:          p.GetHasTouch(),
+	}
+}
+
+func eventToProto(e engine.Event) *pb.Event {
+	return &pb.Event{
+		Type:   string(e.Type),
+		Time:   timestamppb.New(e.Time),
+		Level:  e.Level,
+		Text:   e.Text,
+		Url:    e.URL,
+		Method: e.Method,
+		Status: int32(e.Status),
+	}
+}
+
+func eventFromProto(p *pb.Event) engine.Event {
+	return engine.Event{
+		Type:   engine.EventType(p.Type),
+		Time:   p.Time.AsTime(),
+		Level:  p.Level,
+		Text:   p.Text,
+		URL:    p.Url,
+		Method: p.Method,
+		Status: int(p.Status),
+	}
+}
@@ -0,0 +1,102 @@
+// Package remote drives browsers over gRPC. Server serves the Browser
+// service of remotepb with the registered engines, and Engine is an
+// engine.Engine whose pages live on such a server, so a program can
+// switch to a remote browser by changing how it opens its engine:
+//
+//	eng, err := remote.Dial("browsers.internal:9090", "cdp")
+//	if err != nil { ... }
+//	err = eng.Initialize(engine.DefaultConfig())
+//
+// Errors keep their engine.ErrorKind across the wire.
+package remote
+
+//go:generate protoc -I remotepb --go_out=remotepb --go_opt=paths=source_relative --go-grpc_out=remotepb --go-grpc_opt=paths=source_relative remote.proto
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"phantomvite/pkg/engine"
+)
+
+// errorDomain is the domain of the ErrorInfo details of failed calls
+const errorDomain = "phantomvite"
+
+// codesByKind are the status codes of failures with a kind; others are
+// codes.Unknown
+var codesByKind = map[engine.ErrorKind]codes.Code{
+	engine.KindConfig:            codes.InvalidArgument,
+	engine.KindUnavailable:       codes.Unavailable,
+	engine.KindNavigationTimeout: codes.DeadlineExceeded,
+}
+
+// toStatus turns err into a status whose ErrorInfo keeps its kind and, for
+// an *engine.EngineError, its fields
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	kind := engine.KindOf(err)
+	code, ok := codesByKind[kind]
+	if !ok {
+		code = codes.Unknown
+	}
+	info := &errdetails.ErrorInfo{Domain: errorDomain, Reason: string(kind)}
+	var e *engine.EngineError
+	if errors.As(err, &e) {
+		info.Metadata = map[string]string{"engine": e.Engine, "operation": e.Operation, "message": e.Message}
+		if e.Cause != nil {
+			info.Metadata["cause"] = e.Cause.Error()
+		}
+	}
+	st, detailErr := status.New(code, err.Error()).WithDetails(info)
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+// navigations are the operations whose deadline is the page's navigation
+// timeout; the deadline of any other call is only the call's
+var navigations = map[string]bool{"navigate": true, "reload": true}
+
+// fromStatus turns the error of a call made for operation back into an
+// *engine.EngineError with the kind it had on the server
+func fromStatus(operation string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return engine.NewEngineError(engineName, operation, "call failed", err)
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != errorDomain {
+			continue
+		}
+		kind := engine.ErrorKind(info.Reason)
+		if op, ok := info.Metadata["operation"]; ok {
+			e := &engine.EngineError{Engine: info.Metadata["engine"], Operation: op, Message: info.Metadata["message"], Kind: kind}
+			if cause, ok := info.Metadata["cause"]; ok {
+				e.Cause = errors.New(cause)
+			}
+			return e
+		}
+		return &engine.EngineError{Engine: engineName, Operation: operation, Message: st.Message(), Kind: kind}
+	}
+
+	// Failures of the connection rather than of the engine
+	e := &engine.EngineError{Engine: engineName, Operation: operation, Message: st.Message()}
+	switch st.Code() {
+	case codes.Unavailable:
+		e.Kind = engine.KindUnavailable
+	case codes.DeadlineExceeded:
+		if navigations[operation] {
+			e.Kind = engine.KindNavigationTimeout
+		}
+	case codes.InvalidArgument:
+		e.Kind = engine.KindConfig
+	}
+	return e
+}
@@ -0,0 +1,473 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"phantomvite/pkg/engine"
+	pb "phantomvite/pkg/remote/remotepb"
+)
+
+// fakeEngine records what the server asks of it; the embedded interfaces
+// panic on anything the tests do not use
+type fakeEngine struct {
+	engine.Engine
+	mu       sync.Mutex
+	config   engine.Config
+	pages    []engine.Page
+	closed   bool
+	noEvents bool
+}
+
+func (e *fakeEngine) Initialize(config engine.Config) error { e.config = config; return nil }
+
+func (e *fakeEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	return nil
+}
+
+func (e *fakeEngine) NewPage(context.Context) (engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var page engine.Page = &fakePage{cookies: []engine.Cookie{}}
+	if e.noEvents {
+		page = struct{ engine.Page }{page}
+	}
+	e.pages = append(e.pages, page)
+	return page, nil
+}
+
+func (e *fakeEngine) GetPages(context.Context) ([]engine.Page, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pages, nil
+}
+
+type fakePage struct {
+	engine.Page
+	url     string
+	nav     *engine.NavigationOptions
+	cookies []engine.Cookie
+	clicked string
+	closed  bool
+}
+
+func (p *fakePage) Navigate(url string, options *engine.NavigationOptions) error {
+	if strings.Contains(url, "slow") {
+		return engine.NewEngineError("fake", "navigate", "timed out waiting for load", context.DeadlineExceeded)
+	}
+	p.url, p.nav = url, options
+	return nil
+}
+
+func (p *fakePage) Reload(*engine.NavigationOptions) error { return nil }
+
+func (p *fakePage) URL() (string, error)   { return p.url, nil }
+func (p *fakePage) Title() (string, error) { return "Title of " + p.url, nil }
+func (p *fakePage) Close() error           { p.closed = true; return nil }
+
+func (p *fakePage) ExecuteScript(script string) (interface{}, error) {
+	if script == "throw" {
+		return nil, engine.NewEngineError("fake", "execute script", "script threw an exception", errors.New("Error: thrown"))
+	}
+	return map[string]interface{}{"script": script, "links": []interface{}{1.0, "two", nil}}, nil
+}
+
+func (p *fakePage) QuerySelector(selector string) (engine.ElementHandle, error) {
+	if selector == "#missing" {
+		return nil, nil
+	}
+	return &fakeElement{page: p, selector: selector}, nil
+}
+
+func (p *fakePage) Screenshot(options engine.ScreenshotOptions) error {
+	return os.WriteFile(options.Path, []byte("image/"+options.Format), 0644)
+}
+
+func (p *fakePage) PDF(options engine.PDFOptions) error {
+	return os.WriteFile(options.Path, []byte("%PDF "+options.Format), 0644)
+}
+
+func (p *fakePage) GetCookies() ([]engine.Cookie, error) { return p.cookies, nil }
+
+func (p *fakePage) SetCookies(cookies []engine.Cookie) error {
+	p.cookies = append(p.cookies, cookies...)
+	return nil
+}
+
+func (p *fakePage) Events(ctx context.Context) (<-chan engine.Event, error) {
+	events := make(chan engine.Event, 2)
+	events <- engine.Event{Type: engine.EventConsole, Time: time.Unix(1700000000, 0).UTC(), Level: "log", Text: "hello"}
+	events <- engine.Event{Type: engine.EventResponse, Time: time.Unix(1700000001, 0).UTC(), URL: p.url, Method: "GET", Status: 200}
+	close(events)
+	return events, nil
+}
+
+type fakeElement struct {
+	engine.ElementHandle
+	page     *fakePage
+	selector string
+}
+
+func (e *fakeElement) Click() error { e.page.clicked = e.selector; return nil }
+
+func (e *fakeElement) GetAttribute(name string) (string, error) { return e.selector + "@" + name, nil }
+
+func (e *fakeElement) QuerySelectorAll(selector string) ([]engine.ElementHandle, error) {
+	return []engine.ElementHandle{&fakeElement{page: e.page, selector: e.selector + " " + selector}}, nil
+}
+
+func (e *fakeElement) BoundingBox() (*engine.BoundingBox, error) {
+	return &engine.BoundingBox{X: 1, Y: 2, Width: 3, Height: 4}, nil
+}
+
+var (
+	fakesMu sync.Mutex
+	fakes   []*fakeEngine
+)
+
+func init() {
+	engine.Register(engine.Driver{
+		Name:        "fake",
+		Description: "In-memory engine for tests",
+		New: func() engine.Engine {
+			fakesMu.Lock()
+			defer fakesMu.Unlock()
+			e := &fakeEngine{}
+			fakes = append(fakes, e)
+			return e
+		},
+		Probe: func() engine.EngineStatus { return engine.EngineStatus{Available: true} },
+	})
+	engine.Register(engine.Driver{
+		Name:  "missing",
+		Probe: func() engine.EngineStatus { return engine.EngineStatus{Error: "not installed"} },
+	})
+}
+
+func lastFake() *fakeEngine {
+	fakesMu.Lock()
+	defer fakesMu.Unlock()
+	return fakes[len(fakes)-1]
+}
+
+// start serves a Server over an in-memory listener and returns an Engine
+// for the named engine on it
+func start(t *testing.T, name string) (*Engine, *Server) {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	srv := NewServer(engine.DefaultConfig())
+	gs := grpc.NewServer()
+	pb.RegisterBrowserServer(gs, srv)
+	go gs.Serve(listener)
+	t.Cleanup(func() {
+		gs.Stop()
+		srv.Close()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return New(conn, name), srv
+}
+
+func initialize(t *testing.T, e *Engine) {
+	t.Helper()
+	config := engine.DefaultConfig()
+	config.Viewport = engine.ViewportConfig{Width: 800, Height: 600}
+	config.Timeout = 5 * time.Second
+	if err := e.Initialize(config); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+}
+
+func TestPageRoundTrip(t *testing.T) {
+	e, _ := start(t, "fake")
+	initialize(t, e)
+	fake := lastFake()
+	if fake.config.Viewport.Width != 800 || fake.config.Engine != "fake" || fake.config.Timeout != 5*time.Second {
+		t.Errorf("expected the client's config on the server, got %+v", fake.config)
+	}
+	if e.Name() != "remote:fake" {
+		t.Errorf("unexpected name %q", e.Name())
+	}
+
+	page, err := e.Navigate(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	nav := &engine.NavigationOptions{Timeout: 3 * time.Second, WaitUntil: "networkidle0", WaitForSelector: "#app"}
+	if err := page.Navigate("https://example.com/docs", nav); err != nil {
+		t.Fatalf("navigate failed: %v", err)
+	}
+	served := fake.pages[0].(*fakePage)
+	if !reflect.DeepEqual(served.nav, nav) {
+		t.Errorf("expected navigation options %+v, got %+v", nav, served.nav)
+	}
+	if title, err := page.Title(); err != nil || title != "Title of https://example.com/docs" {
+		t.Errorf("unexpected title %q, %v", title, err)
+	}
+
+	result, err := page.ExecuteScript("document.links")
+	want := map[string]interface{}{"script": "document.links", "links": []interface{}{1.0, "two", nil}}
+	if err != nil || !reflect.DeepEqual(result, want) {
+		t.Errorf("expected %v, got %v, %v", want, result, err)
+	}
+
+	button, err := page.QuerySelector("#buy")
+	if err != nil || button == nil {
+		t.Fatalf("expected an element, got %v, %v", button, err)
+	}
+	if err := button.Click(); err != nil || served.clicked != "#buy" {
+		t.Errorf("expected the click to reach the server, got %q, %v", served.clicked, err)
+	}
+	if attr, err := button.GetAttribute("href"); err != nil || attr != "#buy@href" {
+		t.Errorf("unexpected attribute %q, %v", attr, err)
+	}
+	if box, err := button.BoundingBox(); err != nil || *box != (engine.BoundingBox{X: 1, Y: 2, Width: 3, Height: 4}) {
+		t.Errorf("unexpected bounding box %v, %v", box, err)
+	}
+	inner, err := button.(engine.ElementQuerier).QuerySelectorAll("span")
+	if err != nil || len(inner) != 1 {
+		t.Fatalf("expected one descendant, got %v, %v", inner, err)
+	}
+	if attr, err := inner[0].GetAttribute("class"); err != nil || attr != "#buy span@class" {
+		t.Errorf("expected the descendant's attribute, got %q, %v", attr, err)
+	}
+	if missing, err := page.QuerySelector("#missing"); err != nil || missing != nil {
+		t.Errorf("expected no element, got %v, %v", missing, err)
+	}
+
+	dir := t.TempDir()
+	shot := filepath.Join(dir, "shot.jpg")
+	if err := page.Screenshot(engine.ScreenshotOptions{Path: shot, Format: "jpeg"}); err != nil {
+		t.Fatalf("screenshot failed: %v", err)
+	}
+	if data, _ := os.ReadFile(shot); string(data) != "image/jpeg" {
+		t.Errorf("expected the server's screenshot, got %q", data)
+	}
+	pdf := filepath.Join(dir, "page.pdf")
+	if err := page.PDF(engine.PDFOptions{Path: pdf, Format: "a4"}); err != nil {
+		t.Fatalf("pdf failed: %v", err)
+	}
+	if data, _ := os.ReadFile(pdf); string(data) != "%PDF a4" {
+		t.Errorf("expected the server's PDF, got %q", data)
+	}
+
+	cookie := engine.Cookie{Name: "session", Value: "42", Domain: "example.com", HTTPOnly: true, SameSite: "Lax"}
+	if err := page.SetCookies([]engine.Cookie{cookie}); err != nil {
+		t.Fatal(err)
+	}
+	if cookies, err := page.GetCookies(); err != nil || !reflect.DeepEqual(cookies, []engine.Cookie{cookie}) {
+		t.Errorf("expected the cookie back, got %+v, %v", cookies, err)
+	}
+
+	pages, err := e.GetPages(context.Background())
+	if err != nil || len(pages) != 1 || pages[0] != page {
+		t.Errorf("expected the same page back, got %v, %v", pages, err)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !fake.closed {
+		t.Errorf("expected closing the engine to close the session")
+	}
+	if _, err := page.Title(); err == nil {
+		t.Errorf("expected the page to be gone with its session")
+	}
+}
+
+func TestErrorsKeepKind(t *testing.T) {
+	e, _ := start(t, "fake")
+	initialize(t, e)
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = page.Navigate("https://slow.example.com", nil)
+	want := engine.NewEngineError("fake", "navigate", "timed out waiting for load", context.DeadlineExceeded)
+	if engine.KindOf(err) != engine.KindNavigationTimeout || err == nil || err.Error() != want.Error() {
+		t.Errorf("expected %q with a navigation timeout, got %v (%q)", want, err, engine.KindOf(err))
+	}
+	if _, err := page.ExecuteScript("throw"); engine.KindOf(err) != engine.KindScript {
+		t.Errorf("expected a script failure, got %v", err)
+	}
+	if err := page.Screenshot(engine.ScreenshotOptions{}); engine.KindOf(err) != engine.KindConfig {
+		t.Errorf("expected a screenshot without a path to fail, got %v", err)
+	}
+
+	if _, err := e.Navigate(context.Background(), "https://slow.example.com"); err == nil {
+		t.Fatal("expected the navigation to fail")
+	}
+	fake := lastFake()
+	if failed := fake.pages[len(fake.pages)-1].(*fakePage); !failed.closed {
+		t.Errorf("expected the page of a failed navigation to be closed on the server")
+	}
+
+	missing, _ := start(t, "missing")
+	if err := missing.Initialize(engine.DefaultConfig()); engine.KindOf(err) != engine.KindUnavailable {
+		t.Errorf("expected an unavailable engine, got %v", err)
+	}
+	unknown, _ := start(t, "nope")
+	if err := unknown.Initialize(engine.DefaultConfig()); engine.KindOf(err) != engine.KindUnavailable {
+		t.Errorf("expected an unknown engine to be unavailable, got %v", err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	e, _ := start(t, "fake")
+	initialize(t, e)
+	page, err := e.Navigate(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := page.(engine.EventSource).Events(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []engine.Event
+	for ev := range events {
+		got = append(got, ev)
+	}
+	want := []engine.Event{
+		{Type: engine.EventConsole, Time: time.Unix(1700000000, 0).UTC(), Level: "log", Text: "hello"},
+		{Type: engine.EventResponse, Time: time.Unix(1700000001, 0).UTC(), URL: "https://example.com", Method: "GET", Status: 200},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	lastFake().noEvents = true
+	other, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.(engine.EventSource).Events(context.Background()); err == nil || !strings.Contains(err.Error(), "cannot report page events") {
+		t.Errorf("expected pages without events to fail, got %v", err)
+	}
+}
+
+func TestServerCloseEndsSessions(t *testing.T) {
+	e, srv := start(t, "fake")
+	initialize(t, e)
+	fake := lastFake()
+
+	if err := srv.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !fake.closed {
+		t.Errorf("expected the session's engine to be closed")
+	}
+	if err := e.Initialize(engine.DefaultConfig()); engine.KindOf(err) != engine.KindUnavailable {
+		t.Errorf("expected new sessions to fail after Close, got %v", err)
+	}
+}
+
+func TestServerKeepsLaunchSettings(t *testing.T) {
+	e, _ := start(t, "fake")
+	config := engine.DefaultConfig()
+	config.Headless = false
+	config.Proxy = "http://proxy:8080"
+	config.Args = []string{"--renderer-cmd-prefix=sh"}
+	if err := e.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastFake().config; !got.Headless || got.Proxy != "" || len(got.Args) > 0 {
+		t.Errorf("expected the server's launch settings, got %+v", got)
+	}
+
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := page.Navigate("file:///etc/passwd", nil); engine.KindOf(err) != engine.KindConfig {
+		t.Errorf("expected a file URL to be rejected, got %v", err)
+	}
+}
+
+// onlyPage returns the one page srv serves
+func onlyPage(srv *Server) *page {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for _, p := range srv.pages {
+		return p
+	}
+	return nil
+}
+
+func TestNavigationDropsElements(t *testing.T) {
+	e, srv := start(t, "fake")
+	initialize(t, e)
+	page, err := e.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := page.Navigate("http://example.com/", nil); err != nil {
+		t.Fatal(err)
+	}
+	button, err := page.QuerySelector("#buy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := onlyPage(srv)
+	if err := page.Reload(nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.elements) != 0 {
+		t.Errorf("expected reloading to drop the page's elements, got %d", len(p.elements))
+	}
+	if err := button.Click(); err == nil {
+		t.Errorf("expected an element of the old document to be gone")
+	}
+
+	if _, err := page.QuerySelector("#buy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := page.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.elements) != 0 {
+		t.Errorf("expected closing the page to drop its elements, got %d", len(p.elements))
+	}
+}
+
+func TestStatusKeepsWrappedErrors(t *testing.T) {
+	cause := engine.NewEngineError("fake", "navigate", "timed out waiting for load", context.DeadlineExceeded)
+	err := fromStatus("navigate", toStatus(fmt.Errorf("open page: %w", cause)))
+	var e *engine.EngineError
+	if !errors.As(err, &e) || e.Engine != "fake" || e.Operation != "navigate" || e.Kind != engine.KindNavigationTimeout {
+		t.Errorf("expected the wrapped engine error's fields, got %#v", err)
+	}
+
+	deadline := status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+	if kind := engine.KindOf(fromStatus("get content", deadline)); kind != "" {
+		t.Errorf("expected no kind for a call deadline, got %q", kind)
+	}
+	if kind := engine.KindOf(fromStatus("reload", deadline)); kind != engine.KindNavigationTimeout {
+		t.Errorf("expected a reload deadline to be a navigation timeout, got %q", kind)
+	}
+}
@@ -0,0 +1,668 @@
+package remote
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"phantomvite/pkg/engine"
+	pb "phantomvite/pkg/remote/remotepb"
+)
+
+// Server serves the Browser service, opening a registered engine for each
+// session a client starts. It is safe for concurrent use.
+type Server struct {
+	pb.UnimplementedBrowserServer
+	defaults engine.Config
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	pages    map[string]*page
+	byPage   map[engine.Page]*page
+	closed   bool
+}
+
+// session is an engine opened for a client
+type session struct {
+	id     string
+	name   string
+	engine engine.Engine
+}
+
+// page is a page of a session and the element handles found on it
+type page struct {
+	id      string
+	session *session
+	page    engine.Page
+
+	mu          sync.Mutex
+	elements    map[string]engine.ElementHandle
+	nextElement int
+}
+
+var empty = &emptypb.Empty{}
+
+// NewServer returns a server whose sessions start with defaults, updated
+// with the fields each client sets
+func NewServer(defaults engine.Config) *Server {
+	return &Server{
+		defaults: defaults,
+		sessions: make(map[string]*session),
+		pages:    make(map[string]*page),
+		byPage:   make(map[engine.Page]*page),
+	}
+}
+
+// Close closes the engines of every session and makes new sessions fail.
+// It returns the first error of closing an engine.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	sessions := s.sessions
+	s.sessions = make(map[string]*session)
+	s.pages = make(map[string]*page)
+	s.byPage = make(map[engine.Page]*page)
+	s.closed = true
+	s.mu.Unlock()
+
+	var first error
+	for _, sess := range sessions {
+		if err := sess.engine.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// checkURL accepts the http and https URLs pages may load; other schemes,
+// such as file, would read the server's own files
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return status.Errorf(codes.InvalidArgument, "url must be an http or https URL, got %q", raw)
+	}
+	return nil
+}
+
+// newID returns a random ID, so clients cannot guess each other's pages
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) session(id string) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown session %q", id)
+	}
+	return sess, nil
+}
+
+func (s *Server) page(id string) (*page, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pages[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown page %q", id)
+	}
+	return p, nil
+}
+
+// addPage returns the ID of an engine page, giving it one if it has none
+func (s *Server) addPage(sess *session, ep engine.Page) *pb.PageRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.byPage[ep]
+	if !ok {
+		p = &page{id: newID(), session: sess, page: ep, elements: make(map[string]engine.ElementHandle)}
+		s.pages[p.id] = p
+		s.byPage[ep] = p
+	}
+	return &pb.PageRef{PageId: p.id}
+}
+
+// forget drops the pages of sess, or only p when it is set
+func (s *Server) forget(sess *session, p *page) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, other := range s.pages {
+		if other.session == sess && (p == nil || other == p) {
+			delete(s.pages, id)
+			delete(s.byPage, other.page)
+			other.clearElements()
+		}
+	}
+}
+
+func (p *page) addElement(h engine.ElementHandle) *pb.ElementRef {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nextElement++
+	id := strconv.Itoa(p.nextElement)
+	p.elements[id] = h
+	return &pb.ElementRef{PageId: p.id, ElementId: id}
+}
+
+// clearElements drops the element handles of p, which belong to a document
+// it has left. IDs are not reused, so stale references are not found.
+func (p *page) clearElements() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.elements)
+}
+
+func (s *Server) element(ref *pb.ElementRef) (engine.ElementHandle, error) {
+	p, err := s.page(ref.GetPageId())
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h, ok := p.elements[ref.GetElementId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown element %q on page %q", ref.GetElementId(), p.id)
+	}
+	return h, nil
+}
+
+// onPage runs fn with the page of id and converts its error
+func (s *Server) onPage(id string, fn func(engine.Page) error) (*emptypb.Empty, error) {
+	p, err := s.page(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(p.page); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+// onNavigation is onPage for fn that leaves the page's document, whose
+// element handles it drops
+func (s *Server) onNavigation(id string, fn func(engine.Page) error) (*emptypb.Empty, error) {
+	p, err := s.page(id)
+	if err != nil {
+		return nil, err
+	}
+	defer p.clearElements()
+	if err := fn(p.page); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+// onElement runs fn with the element of ref and converts its error
+func (s *Server) onElement(ref *pb.ElementRef, fn func(engine.ElementHandle) error) (*emptypb.Empty, error) {
+	h, err := s.element(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(h); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+func (s *Server) OpenSession(ctx context.Context, req *pb.OpenSessionRequest) (*pb.Session, error) {
+	if req.Engine == "" {
+		return nil, status.Error(codes.InvalidArgument, "engine is required")
+	}
+	config := configFromProto(req.Config, s.defaults)
+	config.Engine = req.Engine
+	eng, err := engine.Open(req.Engine, config)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	sess := &session{id: newID(), name: req.Engine, engine: eng}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		eng.Close()
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	s.sessions[sess.id] = sess
+	s.mu.Unlock()
+	return &pb.Session{Id: sess.id, Engine: sess.name}, nil
+}
+
+func (s *Server) CloseSession(ctx context.Context, ref *pb.SessionRef) (*emptypb.Empty, error) {
+	sess, err := s.session(ref.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	delete(s.sessions, sess.id)
+	s.mu.Unlock()
+	s.forget(sess, nil)
+	if err := sess.engine.Close(); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+func (s *Server) onSession(id string, fn func(engine.Engine) error) (*emptypb.Empty, error) {
+	sess, err := s.session(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(sess.engine); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+func (s *Server) SetUserAgent(ctx context.Context, req *pb.SetUserAgentRequest) (*emptypb.Empty, error) {
+	return s.onSession(req.SessionId, func(e engine.Engine) error { return e.SetUserAgent(req.UserAgent) })
+}
+
+func (s *Server) SetExtraHeaders(ctx context.Context, req *pb.SetExtraHeadersRequest) (*emptypb.Empty, error) {
+	return s.onSession(req.SessionId, func(e engine.Engine) error { return e.SetExtraHeaders(req.Headers) })
+}
+
+func (s *Server) SetSessionViewport(ctx context.Context, req *pb.SetSessionViewportRequest) (*emptypb.Empty, error) {
+	return s.onSession(req.SessionId, func(e engine.Engine) error { return e.SetViewport(viewportFromProto(req.Viewport)) })
+}
+
+func (s *Server) NewPage(ctx context.Context, ref *pb.SessionRef) (*pb.PageRef, error) {
+	sess, err := s.session(ref.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	ep, err := sess.engine.NewPage(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return s.addPage(sess, ep), nil
+}
+
+func (s *Server) ListPages(ctx context.Context, ref *pb.SessionRef) (*pb.ListPagesResponse, error) {
+	sess, err := s.session(ref.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := sess.engine.GetPages(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.ListPagesResponse{}
+	for _, ep := range pages {
+		res.Pages = append(res.Pages, s.addPage(sess, ep))
+	}
+	return res, nil
+}
+
+func (s *Server) ClosePage(ctx context.Context, ref *pb.PageRef) (*emptypb.Empty, error) {
+	p, err := s.page(ref.PageId)
+	if err != nil {
+		return nil, err
+	}
+	s.forget(p.session, p)
+	if err := p.page.Close(); err != nil {
+		return nil, toStatus(err)
+	}
+	return empty, nil
+}
+
+func (s *Server) GetPageInfo(ctx context.Context, ref *pb.PageRef) (*pb.PageInfo, error) {
+	info := &pb.PageInfo{}
+	_, err := s.onPage(ref.PageId, func(p engine.Page) error {
+		var err error
+		if info.Url, err = p.URL(); err != nil {
+			return err
+		}
+		info.Title, err = p.Title()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *Server) GetContent(ctx context.Context, ref *pb.PageRef) (*pb.Content, error) {
+	content := &pb.Content{}
+	_, err := s.onPage(ref.PageId, func(p engine.Page) error {
+		var err error
+		content.Html, err = p.Content()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (s *Server) Navigate(ctx context.Context, req *pb.NavigateRequest) (*emptypb.Empty, error) {
+	if err := checkURL(req.Url); err != nil {
+		return nil, err
+	}
+	return s.onNavigation(req.PageId, func(p engine.Page) error { return p.Navigate(req.Url, navigationFromProto(req.Options)) })
+}
+
+func (s *Server) Reload(ctx context.Context, req *pb.ReloadRequest) (*emptypb.Empty, error) {
+	return s.onNavigation(req.PageId, func(p engine.Page) error { return p.Reload(navigationFromProto(req.Options)) })
+}
+
+func (s *Server) GoBack(ctx context.Context, ref *pb.PageRef) (*emptypb.Empty, error) {
+	return s.onNavigation(ref.PageId, engine.Page.GoBack)
+}
+
+func (s *Server) GoForward(ctx context.Context, ref *pb.PageRef) (*emptypb.Empty, error) {
+	return s.onNavigation(ref.PageId, engine.Page.GoForward)
+}
+
+func (s *Server) WaitForNavigation(ctx context.Context, req *pb.ReloadRequest) (*emptypb.Empty, error) {
+	return s.onNavigation(req.PageId, func(p engine.Page) error { return p.WaitForNavigation(navigationFromProto(req.Options)) })
+}
+
+func (s *Server) Query(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	p, err := s.page(req.PageId)
+	if err != nil {
+		return nil, err
+	}
+	var handles []engine.ElementHandle
+	switch {
+	case req.ElementId != "":
+		h, lookupErr := s.element(&pb.ElementRef{PageId: req.PageId, ElementId: req.ElementId})
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		q, ok := h.(engine.ElementQuerier)
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "the elements of page %q cannot be queried", req.PageId)
+		}
+		if handles, err = q.QuerySelectorAll(req.Selector); err == nil && !req.All && len(handles) > 1 {
+			handles = handles[:1]
+		}
+	case req.All:
+		handles, err = p.page.QuerySelectorAll(req.Selector)
+	default:
+		var h engine.ElementHandle
+		if h, err = p.page.QuerySelector(req.Selector); h != nil {
+			handles = append(handles, h)
+		}
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := &pb.QueryResponse{}
+	for _, h := range handles {
+		res.Elements = append(res.Elements, p.addElement(h))
+	}
+	return res, nil
+}
+
+func (s *Server) WaitForSelector(ctx context.Context, req *pb.WaitForSelectorRequest) (*pb.ElementRef, error) {
+	p, err := s.page(req.PageId)
+	if err != nil {
+		return nil, err
+	}
+	h, err := p.page.WaitForSelector(req.Selector, waitFromProto(req.Options))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if h == nil {
+		// Waiting for an element to hide finds none
+		return &pb.ElementRef{PageId: p.id}, nil
+	}
+	return p.addElement(h), nil
+}
+
+func (s *Server) ClickElement(ctx context.Context, ref *pb.ElementRef) (*emptypb.Empty, error) {
+	return s.onElement(ref, engine.ElementHandle.Click)
+}
+
+func (s *Server) TypeElement(ctx context.Context, req *pb.TypeElementRequest) (*emptypb.Empty, error) {
+	return s.onElement(req.Element, func(h engine.ElementHandle) error { return h.Type(req.Text) })
+}
+
+func (s *Server) GetAttribute(ctx context.Context, req *pb.ElementValueRequest) (*pb.AttributeResponse, error) {
+	res := &pb.AttributeResponse{}
+	_, err := s.onElement(req.Element, func(h engine.ElementHandle) error {
+		var err error
+		res.Value, err = h.GetAttribute(req.Name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) GetProperty(ctx context.Context, req *pb.ElementValueRequest) (*structpb.Value, error) {
+	var value *structpb.Value
+	_, err := s.onElement(req.Element, func(h engine.ElementHandle) error {
+		v, err := h.GetProperty(req.Name)
+		if err != nil {
+			return err
+		}
+		value, err = toValue(v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *Server) IsVisible(ctx context.Context, ref *pb.ElementRef) (*pb.IsVisibleResponse, error) {
+	res := &pb.IsVisibleResponse{}
+	_, err := s.onElement(ref, func(h engine.ElementHandle) error {
+		var err error
+		res.Visible, err = h.IsVisible()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) GetBoundingBox(ctx context.Context, ref *pb.ElementRef) (*pb.BoundingBoxResponse, error) {
+	res := &pb.BoundingBoxResponse{}
+	_, err := s.onElement(ref, func(h engine.ElementHandle) error {
+		box, err := h.BoundingBox()
+		res.Box = boxToProto(box)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) Click(ctx context.Context, req *pb.SelectorRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.Click(req.Selector) })
+}
+
+func (s *Server) Type(ctx context.Context, req *pb.InputRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.Type(req.Selector, req.Text) })
+}
+
+func (s *Server) Fill(ctx context.Context, req *pb.InputRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.Fill(req.Selector, req.Text) })
+}
+
+func (s *Server) Select(ctx context.Context, req *pb.SelectRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.Select(req.Selector, req.Values...) })
+}
+
+func (s *Server) Evaluate(ctx context.Context, req *pb.EvaluateRequest) (*structpb.Value, error) {
+	var value *structpb.Value
+	_, err := s.onPage(req.PageId, func(p engine.Page) error {
+		run := p.ExecuteScript
+		if req.Async {
+			run = p.ExecuteScriptAsync
+		}
+		v, err := run(req.Script)
+		if err != nil {
+			return err
+		}
+		value, err = toValue(v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// toValue converts a script result, which engines decode from JSON
+func toValue(v interface{}) (*structpb.Value, error) {
+	value, err := structpb.NewValue(v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot send a result of type %T", v)
+	}
+	return value, nil
+}
+
+func (s *Server) WaitForFunction(ctx context.Context, req *pb.WaitForFunctionRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.WaitForFunction(req.Function, waitFromProto(req.Options)) })
+}
+
+func (s *Server) WaitForTimeout(ctx context.Context, req *pb.WaitForTimeoutRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.WaitForTimeout(req.Timeout.AsDuration()) })
+}
+
+// contentTypes are the Artifact content types by screenshot format
+var contentTypes = map[string]string{"": "image/png", "png": "image/png", "jpeg": "image/jpeg", "webp": "image/webp"}
+
+func (s *Server) Screenshot(ctx context.Context, req *pb.ScreenshotRequest) (*pb.Artifact, error) {
+	options := screenshotFromProto(req)
+	contentType, ok := contentTypes[options.Format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "format must be png, jpeg or webp, got %q", options.Format)
+	}
+	if options.Format == "" {
+		options.Format = "png"
+	}
+	return s.artifact(req.PageId, contentType, "screenshot-*."+options.Format, func(p engine.Page, path string) error {
+		options.Path = path
+		return p.Screenshot(options)
+	})
+}
+
+func (s *Server) PDF(ctx context.Context, req *pb.PDFRequest) (*pb.Artifact, error) {
+	options := pdfFromProto(req)
+	return s.artifact(req.PageId, "application/pdf", "page-*.pdf", func(p engine.Page, path string) error {
+		options.Path = path
+		return p.PDF(options)
+	})
+}
+
+// artifact has write save a file of the page in a temporary directory and
+// returns its contents
+func (s *Server) artifact(pageID, contentType, pattern string, write func(p engine.Page, path string) error) (*pb.Artifact, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	res := &pb.Artifact{ContentType: contentType}
+	_, err = s.onPage(pageID, func(p engine.Page) error {
+		if err := write(p, path); err != nil {
+			return err
+		}
+		res.Data, err = os.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) GetCookies(ctx context.Context, ref *pb.PageRef) (*pb.Cookies, error) {
+	res := &pb.Cookies{}
+	_, err := s.onPage(ref.PageId, func(p engine.Page) error {
+		cookies, err := p.GetCookies()
+		res.Cookies = cookiesToProto(cookies)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Server) SetCookies(ctx context.Context, req *pb.SetCookiesRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.SetCookies(cookiesFromProto(req.Cookies)) })
+}
+
+func (s *Server) ClearCookies(ctx context.Context, ref *pb.PageRef) (*emptypb.Empty, error) {
+	return s.onPage(ref.PageId, engine.Page.ClearCookies)
+}
+
+func (s *Server) SetViewport(ctx context.Context, req *pb.SetViewportRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.SetViewport(viewportFromProto(req.Viewport)) })
+}
+
+func (s *Server) EmulateDevice(ctx context.Context, req *pb.EmulateDeviceRequest) (*emptypb.Empty, error) {
+	return s.onPage(req.PageId, func(p engine.Page) error { return p.EmulateDevice(deviceFromProto(req.Device)) })
+}
+
+func (s *Server) GetMetrics(ctx context.Context, ref *pb.PageRef) (*structpb.Struct, error) {
+	var metrics *structpb.Struct
+	_, err := s.onPage(ref.PageId, func(p engine.Page) error {
+		m, err := p.GetMetrics()
+		if err != nil {
+			return err
+		}
+		if metrics, err = structpb.NewStruct(m); err != nil {
+			return status.Errorf(codes.Internal, "cannot send metrics: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// eventsHeader is the header Events sends once the page reports events,
+// so clients learn of a failure before the first event
+const eventsHeader = "phantomvite-events"
+
+func (s *Server) Events(ref *pb.PageRef, stream pb.Browser_EventsServer) error {
+	p, err := s.page(ref.PageId)
+	if err != nil {
+		return err
+	}
+	source, ok := p.page.(engine.EventSource)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "the %s engine cannot report page events", p.session.name)
+	}
+	events, err := source.Events(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	if err := stream.SendHeader(metadata.Pairs(eventsHeader, "started")); err != nil {
+		return err
+	}
+	for ev := range events {
+		if err := stream.Send(eventToProto(ev)); err != nil {
+			return fmt.Errorf("failed to send event: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package scrape
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"phantomvite/pkg/engine"
+)
+
+// textProperty is the property read from fields without attr or property
+const textProperty = "innerText"
+
+// ErrNoElementQueries is returned for specs that read fields inside
+// elements when the engine's elements cannot look up their descendants
+var ErrNoElementQueries = errors.New("the engine cannot query inside elements; use items and object fields with another engine")
+
+// Record is an extracted object. Its values are strings, or other JSON
+// values for properties, []interface{} for lists and Records for object
+// fields; fields without a match are nil.
+type Record struct {
+	Names  []string
+	Values []interface{}
+}
+
+// Get returns the value of the named field
+func (r Record) Get(name string) (interface{}, bool) {
+	for i, n := range r.Names {
+		if n == name {
+			return r.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON writes the record as an object in the order of its fields
+func (r Record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range r.Names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encode(&buf, name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encode(&buf, r.Values[i]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encode writes v as compact JSON, leaving HTML characters alone
+func encode(w *bytes.Buffer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	w.Truncate(w.Len() - 1) // the newline of Encode
+	return nil
+}
+
+// querier looks up elements: a page, or an element with descendants
+type querier interface {
+	QuerySelectorAll(selector string) ([]engine.ElementHandle, error)
+}
+
+// Extract reads the records of the page as it is now
+func (s *Spec) Extract(page engine.Page) ([]Record, error) {
+	if s.Items == "" {
+		r, err := extractFields(page, nil, s.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return []Record{r}, nil
+	}
+	items, err := page.QuerySelectorAll(s.Items)
+	if err != nil {
+		return nil, fmt.Errorf("items %q: %w", s.Items, err)
+	}
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		q, ok := item.(engine.ElementQuerier)
+		if !ok {
+			return nil, ErrNoElementQueries
+		}
+		r, err := extractFields(q, item, s.Fields)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// extractFields reads fields within scope; self is the element of scope,
+// nil for the page
+func extractFields(scope querier, self engine.ElementHandle, fields Fields) (Record, error) {
+	r := Record{Names: make([]string, len(fields)), Values: make([]interface{}, len(fields))}
+	for i, f := range fields {
+		v, err := extractField(scope, self, f)
+		if err != nil {
+			return Record{}, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		r.Names[i], r.Values[i] = f.Name, v
+	}
+	return r, nil
+}
+
+func extractField(scope querier, self engine.ElementHandle, f *Field) (interface{}, error) {
+	matches := []engine.ElementHandle{self}
+	if f.Selector != "" {
+		var err error
+		if matches, err = scope.QuerySelectorAll(f.Selector); err != nil {
+			return nil, err
+		}
+	}
+	if !f.List {
+		if len(matches) == 0 {
+			return nil, nil
+		}
+		matches = matches[:1]
+	}
+	values := make([]interface{}, 0, len(matches))
+	for _, el := range matches {
+		v, err := value(el, f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if !f.List {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// value reads f from the element el
+func value(el engine.ElementHandle, f *Field) (interface{}, error) {
+	switch {
+	case f.Fields != nil:
+		q, ok := el.(engine.ElementQuerier)
+		if !ok {
+			return nil, ErrNoElementQueries
+		}
+		return extractFields(q, el, f.Fields)
+	case f.Attr != "":
+		return el.GetAttribute(f.Attr)
+	}
+	property := f.Property
+	if property == "" {
+		property = textProperty
+	}
+	v, err := el.GetProperty(property)
+	if s, ok := v.(string); ok && f.Property == "" {
+		return strings.TrimSpace(s), err
+	}
+	return v, err
+}
+
+// Options control Scrape. Zero values select the defaults.
+type Options struct {
+	Navigation *engine.NavigationOptions // how each page is loaded
+	MaxPages   int                       // overrides the spec's max_pages when positive
+	Log        io.Writer                 // one line per page; none when nil
+}
+
+// Scrape loads start in page and passes its records to emit, then follows
+// the spec's Next link while there is one, up to MaxPages pages and
+// without reading a page twice. It returns the number of pages read.
+func Scrape(page engine.Page, start string, spec *Spec, options Options, emit func(Record) error) (int, error) {
+	maxPages := spec.MaxPages
+	if options.MaxPages > 0 {
+		maxPages = options.MaxPages
+	}
+	if maxPages == 0 {
+		maxPages = DefaultMaxPages
+	}
+	if options.Log == nil {
+		options.Log = io.Discard
+	}
+
+	// Next links may only lead to local files from a local start page
+	local := false
+	if u, err := url.Parse(start); err == nil {
+		local = u.Scheme == "file"
+	}
+	seen := map[string]bool{}
+	pages := 0
+	for link := start; link != "" && !seen[link] && pages < maxPages; {
+		seen[link] = true
+		if err := page.Navigate(link, options.Navigation); err != nil {
+			return pages, fmt.Errorf("navigation to %s failed: %w", link, err)
+		}
+		pages++
+		records, err := spec.Extract(page)
+		if err != nil {
+			return pages, fmt.Errorf("%s: %w", link, err)
+		}
+		for _, r := range records {
+			if err := emit(r); err != nil {
+				return pages, err
+			}
+		}
+		fmt.Fprintf(options.Log, "📄 [%d] %s: %d records\n", pages, link, len(records))
+
+		if spec.Next == "" {
+			break
+		}
+		if link, err = nextLink(page, spec.Next, link, local); err != nil {
+			return pages, err
+		}
+	}
+	return pages, nil
+}
+
+// nextLink resolves the href of the first element matching selector, or
+// returns "" when there is none or it is not an http or https URL, or a
+// file URL when local is set
+func nextLink(page engine.Page, selector, current string, local bool) (string, error) {
+	el, err := page.QuerySelector(selector)
+	if err != nil || el == nil {
+		return "", err
+	}
+	href, err := el.GetAttribute("href")
+	if err != nil || strings.TrimSpace(href) == "" {
+		return "", err
+	}
+	if u, err := page.URL(); err == nil && u != "" {
+		current = u
+	}
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return "", fmt.Errorf("next link %q: %w", href, err)
+	}
+	next := base.ResolveReference(ref)
+	next.Fragment = ""
+	if next.Scheme != "http" && next.Scheme != "https" && (next.Scheme != "file" || !local) {
+		return "", nil
+	}
+	return next.String(), nil
+}
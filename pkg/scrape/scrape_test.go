@@ -0,0 +1,218 @@
+package scrape
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// element is an element of a listing
+type element = enginetest.Element
+
+func text(s string) *element { return &element{Text: s} }
+
+// product is an article of a listing
+func product(title, href string, tags ...string) *element {
+	var tagElements []*element
+	for _, tag := range tags {
+		tagElements = append(tagElements, text(tag))
+	}
+	return &element{
+		Attrs: map[string]string{"data-id": title[:1]},
+		Matches: map[string][]*element{
+			"h2":   {text("  " + title + "\n")},
+			"a":    {{Attrs: map[string]string{"href": href}}},
+			".tag": tagElements,
+			".seller": {{Matches: map[string][]*element{
+				".name": {text("Shop of " + title)},
+			}}},
+		},
+	}
+}
+
+// listing opens a page of a shop of two pages
+func listing(t *testing.T) engine.Page {
+	t.Helper()
+	docs := map[string]*element{
+		"https://shop.example/": {Matches: map[string][]*element{
+			"article":      {product("Apple", "/apple", "fruit", "red"), product("Brick", "/brick")},
+			"a[rel=next]":  {{Attrs: map[string]string{"href": "/page/2#top"}}},
+			"h1":           {text("Shop")},
+			"input#signup": {{Properties: map[string]interface{}{"checked": true}}},
+		}},
+		"https://shop.example/page/2": {Matches: map[string][]*element{
+			"article":     {product("Cherry", "/cherry", "fruit")},
+			"a[rel=next]": {{Attrs: map[string]string{"href": "/"}}}, // back to the start
+		}},
+	}
+	eng := &enginetest.Engine{
+		Load: func(url string) error {
+			if docs[url] == nil {
+				return errors.New("404 " + url)
+			}
+			return nil
+		},
+		Document: func(url string) *element { return docs[url] },
+	}
+	page, err := eng.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return page
+}
+
+const specJSON = `{
+  "items": "article",
+  "fields": {
+    "title": "h2",
+    "id": {"attr": "data-id"},
+    "url": {"selector": "a", "attr": "href"},
+    "tags": {"selector": ".tag", "list": true},
+    "seller": {"selector": ".seller", "fields": {"name": ".name"}},
+    "missing": ".nope"
+  },
+  "next": "a[rel=next]"
+}`
+
+func TestScrape(t *testing.T) {
+	spec, err := ParseSpec([]byte(specJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	pages, err := Scrape(listing(t), "https://shop.example/", spec, Options{}, func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 2 || len(records) != 3 {
+		t.Fatalf("expected 3 records from 2 pages, got %d from %d", len(records), pages)
+	}
+
+	var out bytes.Buffer
+	w, _ := NewWriter(&out, FormatNDJSON, spec)
+	for _, r := range records {
+		w.Write(r)
+	}
+	want := `{"title":"Apple","id":"A","url":"/apple","tags":["fruit","red"],"seller":{"name":"Shop of Apple"},"missing":null}
+{"title":"Brick","id":"B","url":"/brick","tags":[],"seller":{"name":"Shop of Brick"},"missing":null}
+{"title":"Cherry","id":"C","url":"/cherry","tags":["fruit"],"seller":{"name":"Shop of Cherry"},"missing":null}
+`
+	if out.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, out.String())
+	}
+
+	records = nil
+	pages, err = Scrape(listing(t), "https://shop.example/", spec, Options{MaxPages: 1}, func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil || pages != 1 || len(records) != 2 {
+		t.Errorf("expected --max-pages to stop after a page, got %d records from %d pages, %v", len(records), pages, err)
+	}
+}
+
+func TestScrapePage(t *testing.T) {
+	spec, err := ParseSpec([]byte(`{"fields": {"heading": "h1", "signup": {"selector": "input#signup", "property": "checked"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := listing(t)
+	page.Navigate("https://shop.example/", nil)
+	records, err := spec.Extract(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{{Names: []string{"heading", "signup"}, Values: []interface{}{"Shop", true}}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("expected %+v, got %+v", want, records)
+	}
+}
+
+func TestNextLinkToFileOnlyFromFile(t *testing.T) {
+	next := &element{Attrs: map[string]string{"href": "file:///etc/passwd"}}
+	docs := map[string]*element{
+		"https://shop.example/": {Matches: map[string][]*element{"a[rel=next]": {next}}},
+		"file:///tmp/page.html": {Matches: map[string][]*element{"a[rel=next]": {next}}},
+		"file:///etc/passwd":    {},
+	}
+	eng := &enginetest.Engine{Document: func(url string) *element { return docs[url] }}
+	spec, err := ParseSpec([]byte(`{"fields": {"heading": "h1"}, "next": "a[rel=next]"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for start, want := range map[string]int{"https://shop.example/": 1, "file:///tmp/page.html": 2} {
+		page, _ := eng.NewPage(context.Background())
+		pages, err := Scrape(page, start, spec, Options{}, func(Record) error { return nil })
+		if err != nil || pages != want {
+			t.Errorf("%s: expected %d pages, got %d, %v", start, want, pages, err)
+		}
+	}
+}
+
+func TestWriterFormats(t *testing.T) {
+	spec, _ := ParseSpec([]byte(`{"items": "li", "fields": {"name": "b", "tags": {"selector": "i", "list": true}}}`))
+	records := []Record{
+		{Names: []string{"name", "tags"}, Values: []interface{}{"a, \"b\"", []interface{}{"x", "<y>"}}},
+		{Names: []string{"name", "tags"}, Values: []interface{}{nil, []interface{}{}}},
+	}
+	for _, tc := range []struct {
+		format  string
+		records []Record
+		want    string
+	}{
+		{FormatJSON, records, "[\n  {\n    \"name\": \"a, \\\"b\\\"\",\n    \"tags\": [\n      \"x\",\n      \"<y>\"\n    ]\n  },\n  {\n    \"name\": null,\n    \"tags\": []\n  }\n]\n"},
+		{FormatJSON, nil, "[]\n"},
+		{FormatCSV, records, "name,tags\n\"a, \"\"b\"\"\",\"[\"\"x\"\",\"\"<y>\"\"]\"\n,[]\n"},
+		{FormatCSV, nil, "name,tags\n"},
+		{FormatNDJSON, nil, ""},
+	} {
+		var out bytes.Buffer
+		w, err := NewWriter(&out, tc.format, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range tc.records {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tc.want {
+			t.Errorf("%s with %d records: expected\n%q\ngot\n%q", tc.format, len(tc.records), tc.want, out.String())
+		}
+	}
+	if _, err := NewWriter(nil, "xml", spec); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	for spec, want := range map[string]string{
+		`{}`:                                  "at least one field",
+		`{"fields": {"a": "h1"}, "nxt": "a"}`: "unknown field",
+		`{"fields": {"a": {"attr": "href"}}}`: `field "a": a selector is required outside items`,
+		`{"items": "li", "fields": {"a": {"attr": "href", "property": "value"}}}`:                                     "cannot both be set",
+		`{"items": "li", "fields": {"a": {"fields": {}}}}`:                                                            `field "a": fields must name`,
+		`{"items": "li", "fields": {"a": {"fields": {"b": {"fields": {"c": {"attr": "x", "fields": {"d": "i"}}}}}}}}`: `field "a.b.c": an object field`,
+		`{"items": "li", "fields": {"a": "b", "a": "c"}}`:                                                             "declared twice",
+		`{"items": "li", "fields": {"a": {"selector": "b", "atr": "c"}}}`:                                             `unknown field "atr"`,
+		`{"fields": {"a": "h1"}, "max_pages": -1}`:                                                                    "max_pages",
+	} {
+		_, err := ParseSpec([]byte(spec))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected an error about %q, got %v", spec, want, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package scrape extracts records from pages as an extraction spec
+// declares them, instead of with a script per site. A spec names the
+// elements of the records and the fields read from each of them:
+//
+//	{
+//	  "items": "article.product",
+//	  "fields": {
+//	    "title": "h2",
+//	    "url":   {"selector": "a", "attr": "href"},
+//	    "tags":  {"selector": ".tag", "list": true},
+//	    "seller": {"selector": ".seller", "fields": {"name": ".name"}}
+//	  },
+//	  "next": "a[rel=next]",
+//	  "max_pages": 5
+//	}
+//
+// Fields are read through engine.Page.QuerySelectorAll and the element
+// methods, and keep the order of the spec in every output format.
+package scrape
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultMaxPages is how many pages a spec with Next reads without max_pages
+const DefaultMaxPages = 10
+
+// Spec declares the records of a page
+type Spec struct {
+	Items    string `json:"items,omitempty"`     // selector of the records; without it the page is one record
+	Fields   Fields `json:"fields"`              // what each record holds
+	Next     string `json:"next,omitempty"`      // selector of the link to the next page
+	MaxPages int    `json:"max_pages,omitempty"` // pages read, following Next; DefaultMaxPages by default
+}
+
+// Field is a value of a record. In a spec it is either a selector, for the
+// trimmed text of the first match, or an object.
+type Field struct {
+	Name     string `json:"-"`
+	Selector string `json:"selector,omitempty"` // relative to the record; empty for the record's element
+	Attr     string `json:"attr,omitempty"`     // attribute to read instead of the text
+	Property string `json:"property,omitempty"` // DOM property to read instead of the text, e.g. "value"
+	List     bool   `json:"list,omitempty"`     // every match instead of the first
+	Fields   Fields `json:"fields,omitempty"`   // read an object from each match instead of a value
+}
+
+// Fields are the fields of a record in the order of the spec
+type Fields []*Field
+
+// UnmarshalJSON reads a selector or a field object
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var selector string
+	if err := json.Unmarshal(data, &selector); err == nil {
+		*f = Field{Selector: selector}
+		return nil
+	}
+	type plain Field
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var p plain
+	if err := dec.Decode(&p); err != nil {
+		return err
+	}
+	*f = Field(p)
+	return nil
+}
+
+// UnmarshalJSON reads an object of fields, keeping their order
+func (fs *Fields) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return errors.New("fields must be an object of field names")
+	}
+	*fs = Fields{}
+	seen := map[string]bool{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name := tok.(string)
+		if seen[name] {
+			return fmt.Errorf("field %q is declared twice", name)
+		}
+		seen[name] = true
+		f := &Field{}
+		if err := dec.Decode(f); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+		f.Name = name
+		*fs = append(*fs, f)
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// ParseSpec reads and checks a spec
+func ParseSpec(data []byte) (*Spec, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return &s, nil
+}
+
+// LoadSpec reads and checks the spec file at path
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Validate reports the first problem of the spec
+func (s *Spec) Validate() error {
+	if s.MaxPages < 0 {
+		return fmt.Errorf("max_pages must be at least 0, got %d", s.MaxPages)
+	}
+	return validateFields(s.Fields, "", s.Items != "")
+}
+
+// validateFields checks fields read at path; inElement is false for fields
+// read from the whole page, which need a selector
+func validateFields(fields Fields, path string, inElement bool) error {
+	if len(fields) == 0 {
+		if path == "" {
+			return errors.New("fields must name at least one field")
+		}
+		return fmt.Errorf("field %q: fields must name at least one field", strings.TrimSuffix(path, "."))
+	}
+	for _, f := range fields {
+		name := path + f.Name
+		switch {
+		case f.Name == "":
+			return fmt.Errorf("field names must not be empty")
+		case f.Selector == "" && !inElement:
+			return fmt.Errorf("field %q: a selector is required outside items", name)
+		case f.Attr != "" && f.Property != "":
+			return fmt.Errorf("field %q: attr and property cannot both be set", name)
+		case f.Fields != nil && (f.Attr != "" || f.Property != ""):
+			return fmt.Errorf("field %q: an object field reads its fields, not an attr or property", name)
+		}
+		if f.Fields != nil {
+			if err := validateFields(f.Fields, name+".", true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Columns are the names of the top-level fields, the columns of CSV output
+func (s *Spec) Columns() []string {
+	names := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		names[i] = f.Name
+	}
+	return names
+}
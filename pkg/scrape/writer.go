@@ -0,0 +1,146 @@
+package scrape
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Output formats of a Writer
+const (
+	FormatJSON   = "json"   // an array of records
+	FormatNDJSON = "ndjson" // one record per line
+	FormatCSV    = "csv"    // a header of the top-level fields, then one row per record
+)
+
+// Formats are the formats NewWriter accepts
+var Formats = []string{FormatJSON, FormatNDJSON, FormatCSV}
+
+// Writer writes records as they are extracted. Close it to finish the
+// output.
+type Writer struct {
+	w       io.Writer
+	format  string
+	columns []string
+	csv     *csv.Writer
+	count   int
+}
+
+// NewWriter returns a writer of records of spec in format
+func NewWriter(w io.Writer, format string, spec *Spec) (*Writer, error) {
+	out := &Writer{w: w, format: format, columns: spec.Columns()}
+	switch format {
+	case FormatJSON, FormatNDJSON:
+	case FormatCSV:
+		out.csv = csv.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown format %q; use json, ndjson or csv", format)
+	}
+	return out, nil
+}
+
+// Count is the number of records written
+func (w *Writer) Count() int { return w.count }
+
+func (w *Writer) Write(r Record) error {
+	var err error
+	switch w.format {
+	case FormatJSON:
+		err = w.writeJSON(r)
+	case FormatNDJSON:
+		var buf bytes.Buffer
+		if err = encode(&buf, r); err == nil {
+			buf.WriteByte('\n')
+			_, err = w.w.Write(buf.Bytes())
+		}
+	case FormatCSV:
+		err = w.writeCSV(r)
+	}
+	if err == nil {
+		w.count++
+	}
+	return err
+}
+
+// writeJSON writes r as an indented element of the array
+func (w *Writer) writeJSON(r Record) error {
+	var compact, buf bytes.Buffer
+	if err := encode(&compact, r); err != nil {
+		return err
+	}
+	if w.count == 0 {
+		buf.WriteString("[\n  ")
+	} else {
+		buf.WriteString(",\n  ")
+	}
+	if err := json.Indent(&buf, compact.Bytes(), "  ", "  "); err != nil {
+		return err
+	}
+	_, err := w.w.Write(buf.Bytes())
+	return err
+}
+
+func (w *Writer) writeCSV(r Record) error {
+	if w.count == 0 {
+		if err := w.csv.Write(w.columns); err != nil {
+			return err
+		}
+	}
+	row := make([]string, len(w.columns))
+	for i, name := range w.columns {
+		v, _ := r.Get(name)
+		cell, err := csvCell(v)
+		if err != nil {
+			return err
+		}
+		row[i] = cell
+	}
+	if err := w.csv.Write(row); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// csvCell writes strings and numbers as they are, nothing for nil and
+// lists and objects as JSON
+func csvCell(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Close finishes the output: the end of the JSON array, or the CSV header
+// when there were no records
+func (w *Writer) Close() error {
+	switch {
+	case w.format == FormatJSON && w.count == 0:
+		_, err := io.WriteString(w.w, "[]\n")
+		return err
+	case w.format == FormatJSON:
+		_, err := io.WriteString(w.w, "\n]\n")
+		return err
+	case w.format == FormatCSV && w.count == 0:
+		if err := w.csv.Write(w.columns); err != nil {
+			return err
+		}
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+// Package server exposes pages of a pool over HTTP. Every endpoint takes a
+// JSON body naming a URL and how to load it:
+//
+//	POST /screenshot  the image, as ScreenshotOptions describe it
+//	POST /pdf         the PDF, as PDFOptions describe it
+//	POST /content     {"url", "title", "content"} of the loaded page
+//	POST /evaluate    {"result"} of a script run in the loaded page
+//	GET  /health      {"status", "pool"} with the pool's Stats
+//
+// URLs must be http or https. Failures are {"error", "error_kind"?} with a
+// status that follows the engine.ErrorKind: 400 for config, 503 for
+// engine_unavailable or no free page, 504 for navigation_timeout and 422
+// for script. Pages are closed after each request, and their cookies and
+// storage cleared. Pages of one browser share its cookies, so clients are
+// only kept apart when the pool has one page per browser.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/pool"
+)
+
+// Config configures a Server. Zero values select the defaults.
+type Config struct {
+	Pages          *pool.Pool    // required; one page per browser keeps requests' cookies apart
+	RequestTimeout time.Duration // limit of a request, including the wait for a page; 60s by default
+	MaxBodyBytes   int64         // 1 MiB by default
+	Device         string        // device every page emulates, see engine.LookupDevice
+	Log            io.Writer     // one line per request; none when nil
+}
+
+// Server is an http.Handler for the endpoints of the package
+type Server struct {
+	config Config
+	mux    *http.ServeMux
+}
+
+// New returns a server for the pages of config.Pages
+func New(config Config) *Server {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = 60 * time.Second
+	}
+	if config.MaxBodyBytes == 0 {
+		config.MaxBodyBytes = 1 << 20
+	}
+	s := &Server{config: config, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/screenshot", s.post(s.screenshot))
+	s.mux.HandleFunc("/pdf", s.post(s.pdf))
+	s.mux.HandleFunc("/content", s.post(s.content))
+	s.mux.HandleFunc("/evaluate", s.post(s.evaluate))
+	s.mux.HandleFunc("/health", s.health)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r)
+	if s.config.Log != nil {
+		fmt.Fprintf(s.config.Log, "%s %s %d %v\n", r.Method, r.URL.Path, rec.status, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// statusRecorder remembers the status of a response for the request log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Request is the part of every body that says which page to load and how.
+// The fields mirror engine.NavigationOptions; Timeout is milliseconds or a
+// duration string, and defaults to the engine's timeout.
+type Request struct {
+	URL             string          `json:"url"`
+	Timeout         config.Duration `json:"timeout,omitempty"`
+	WaitUntil       string          `json:"wait_until,omitempty"`
+	WaitForSelector string          `json:"wait_for_selector,omitempty"`
+	Referer         string          `json:"referer,omitempty"`
+}
+
+func (r *Request) request() *Request { return r }
+
+// ScreenshotRequest is the body of POST /screenshot. The screenshot
+// options sit beside the Request fields; "path" is not allowed.
+type ScreenshotRequest struct {
+	Request
+	engine.ScreenshotOptions
+}
+
+// PDFRequest is the body of POST /pdf. The PDF options sit beside the
+// Request fields; "path" is not allowed.
+type PDFRequest struct {
+	Request
+	engine.PDFOptions
+}
+
+// EvaluateRequest is the body of POST /evaluate
+type EvaluateRequest struct {
+	Request
+	Script string `json:"script"` // an expression; a promise is not awaited
+}
+
+// ContentResponse is the body of a successful POST /content
+type ContentResponse struct {
+	URL     string `json:"url"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// EvaluateResponse is the body of a successful POST /evaluate
+type EvaluateResponse struct {
+	Result interface{} `json:"result"`
+}
+
+// HealthResponse is the body of GET /health
+type HealthResponse struct {
+	Status string     `json:"status"` // "ok"
+	Pool   pool.Stats `json:"pool"`
+}
+
+// ErrorResponse is the body of a failed request
+type ErrorResponse struct {
+	Error string           `json:"error"`
+	Kind  engine.ErrorKind `json:"error_kind,omitempty"`
+}
+
+// requestError fails a request with a status of its own
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+func badRequest(format string, args ...interface{}) error {
+	return &requestError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// handler serves a loaded page; it writes the response unless it fails
+type handler func(w http.ResponseWriter, page engine.Page, body interface{ request() *Request }) error
+
+// post decodes the body of a POST into the type body returns, loads its
+// page and hands both to serve
+func (s *Server) post(serve func() (interface{ request() *Request }, handler)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, &requestError{status: http.StatusMethodNotAllowed, err: fmt.Errorf("%s needs POST", r.URL.Path)})
+			return
+		}
+		body, h := serve()
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(body); err != nil {
+			writeError(w, badRequest("invalid JSON body: %v", err))
+			return
+		}
+		if err := checkURL(body.request().URL); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), s.config.RequestTimeout)
+		defer cancel()
+		if err := s.withPage(ctx, body.request(), func(page engine.Page) error { return h(w, page, body) }); err != nil {
+			writeError(w, err)
+		}
+	}
+}
+
+// checkURL accepts the http and https URLs the server may load; other
+// schemes, such as file, would read the server's own files
+func checkURL(raw string) error {
+	if raw == "" {
+		return badRequest("url is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return badRequest("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return badRequest("url must be an http or https URL, got %q", raw)
+	}
+	return nil
+}
+
+// clearStorage empties the storage of the page's origin; errors are
+// ignored, as pages on error pages or about:blank have none
+const clearStorage = "try { localStorage.clear(); sessionStorage.clear(); } catch (e) {}"
+
+// withPage loads req in a page of the pool and runs fn with it. The page
+// is closed afterwards instead of going back to the pool, and the cookies
+// of its browser are cleared first, for the next request to use it.
+func (s *Server) withPage(ctx context.Context, req *Request, fn func(engine.Page) error) error {
+	page, err := s.config.Pages.Acquire(ctx)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return &requestError{status: http.StatusServiceUnavailable, err: errors.New("no page became free within the request timeout")}
+	case errors.Is(err, pool.ErrClosed):
+		return &requestError{status: http.StatusServiceUnavailable, err: errors.New("the server is shutting down")}
+	case err != nil:
+		return err
+	}
+	defer func() {
+		page.ClearCookies()
+		page.Discard()
+	}()
+
+	if device, ok := engine.LookupDevice(s.config.Device); ok {
+		if err := page.EmulateDevice(device); err != nil {
+			return err
+		}
+	}
+	nav := &engine.NavigationOptions{
+		Timeout:         time.Duration(req.Timeout),
+		WaitUntil:       req.WaitUntil,
+		WaitForSelector: req.WaitForSelector,
+		Referer:         req.Referer,
+	}
+	if remaining := engine.Remaining(ctx); nav.Timeout == 0 || nav.Timeout > remaining {
+		nav.Timeout = remaining
+	}
+	if err := page.Navigate(req.URL, nav); err != nil {
+		return err
+	}
+	defer page.ExecuteScript(clearStorage)
+	return fn(page)
+}
+
+func (s *Server) screenshot() (interface{ request() *Request }, handler) {
+	return &ScreenshotRequest{}, func(w http.ResponseWriter, page engine.Page, body interface{ request() *Request }) error {
+		opts := body.(*ScreenshotRequest).ScreenshotOptions
+		if opts.Path != "" {
+			return badRequest("path is not allowed; the image is the response")
+		}
+		types := map[string]string{"": "image/png", "png": "image/png", "jpeg": "image/jpeg", "webp": "image/webp"}
+		contentType, ok := types[opts.Format]
+		if !ok {
+			return badRequest("format must be png, jpeg or webp, got %q", opts.Format)
+		}
+		if opts.Format == "" {
+			opts.Format = "png"
+		}
+		return sendFile(w, contentType, "screenshot-*."+opts.Format, func(path string) error {
+			opts.Path = path
+			return page.Screenshot(opts)
+		})
+	}
+}
+
+func (s *Server) pdf() (interface{ request() *Request }, handler) {
+	return &PDFRequest{}, func(w http.ResponseWriter, page engine.Page, body interface{ request() *Request }) error {
+		opts := body.(*PDFRequest).PDFOptions
+		if opts.Path != "" {
+			return badRequest("path is not allowed; the PDF is the response")
+		}
+		return sendFile(w, "application/pdf", "page-*.pdf", func(path string) error {
+			opts.Path = path
+			return page.PDF(opts)
+		})
+	}
+}
+
+func (s *Server) content() (interface{ request() *Request }, handler) {
+	return &Request{}, func(w http.ResponseWriter, page engine.Page, _ interface{ request() *Request }) error {
+		var res ContentResponse
+		var err error
+		if res.URL, err = page.URL(); err != nil {
+			return err
+		}
+		if res.Title, err = page.Title(); err != nil {
+			return err
+		}
+		if res.Content, err = page.Content(); err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, res)
+		return nil
+	}
+}
+
+func (s *Server) evaluate() (interface{ request() *Request }, handler) {
+	return &EvaluateRequest{}, func(w http.ResponseWriter, page engine.Page, body interface{ request() *Request }) error {
+		script := body.(*EvaluateRequest).Script
+		if script == "" {
+			return badRequest("script is required")
+		}
+		result, err := page.ExecuteScript(script)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, EvaluateResponse{Result: result})
+		return nil
+	}
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, &requestError{status: http.StatusMethodNotAllowed, err: errors.New("/health needs GET")})
+		return
+	}
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok", Pool: s.config.Pages.Stats()})
+}
+
+// sendFile has write save a file in a temporary directory and sends it
+func sendFile(w http.ResponseWriter, contentType, pattern string, write func(path string) error) error {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	if err := write(path); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filepath.Base(path)))
+	w.Write(data)
+	return nil
+}
+
+// statuses maps error kinds to HTTP statuses; other failures are 500
+var statuses = map[engine.ErrorKind]int{
+	engine.KindConfig:            http.StatusBadRequest,
+	engine.KindUnavailable:       http.StatusServiceUnavailable,
+	engine.KindNavigationTimeout: http.StatusGatewayTimeout,
+	engine.KindScript:            http.StatusUnprocessableEntity,
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		status = reqErr.status
+	} else if s, ok := statuses[engine.KindOf(err)]; ok {
+		status = s
+	}
+	writeJSON(w, status, ErrorResponse{Error: err.Error(), Kind: engine.KindOf(err)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+	"phantomvite/pkg/pool"
+)
+
+// newEngine is a fake engine whose scripts log in with "login", report the
+// session with "session", and otherwise return the script with the
+// navigation timeout. Its cookies are shared by its pages, as a browser's
+// are, while the storage is each page's own.
+func newEngine() *enginetest.Engine {
+	var mu sync.Mutex
+	storage := make(map[*enginetest.Page]string)
+	return &enginetest.Engine{Script: func(p *enginetest.Page, script string) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch script {
+		case "throw":
+			return nil, &engine.EngineError{Engine: "fake", Operation: "evaluate", Message: "Error: thrown", Kind: engine.KindScript}
+		case "login":
+			storage[p] = "token=secret"
+			return true, p.SetCookies([]engine.Cookie{{Name: "session", Value: "secret"}})
+		case "session":
+			cookies, err := p.GetCookies()
+			var session string
+			for _, c := range cookies {
+				session += c.Name + "=" + c.Value
+			}
+			return session + storage[p], err
+		case clearStorage:
+			delete(storage, p)
+			return nil, nil
+		}
+		return map[string]interface{}{"script": script, "timeout_ms": p.Navigation().Timeout.Milliseconds()}, nil
+	}}
+}
+
+func newServer(t *testing.T, config Config) (*httptest.Server, *pool.Pool) {
+	t.Helper()
+	ts, pages, _ := newServerWithEngine(t, config)
+	return ts, pages
+}
+
+// newServerWithEngine also returns the pool's one browser
+func newServerWithEngine(t *testing.T, config Config) (*httptest.Server, *pool.Pool, *enginetest.Engine) {
+	t.Helper()
+	browser := newEngine()
+	pages, err := pool.New(pool.Config{
+		Browsers:        1,
+		PagesPerBrowser: 1,
+		Launch:          func(context.Context) (engine.Engine, error) { return browser, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pages.Close() })
+	config.Pages = pages
+	ts := httptest.NewServer(New(config))
+	t.Cleanup(ts.Close)
+	return ts, pages, browser
+}
+
+func post(t *testing.T, ts *httptest.Server, path, body string) (*http.Response, string) {
+	t.Helper()
+	res, err := http.Post(ts.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, string(data)
+}
+
+func TestEndpoints(t *testing.T) {
+	ts, _ := newServer(t, Config{})
+
+	for _, tc := range []struct {
+		path, body  string
+		contentType string
+		want        string
+	}{
+		{"/screenshot", `{"url": "https://example.com"}`, "image/png", "png"},
+		{"/screenshot", `{"url": "https://example.com", "format": "jpeg", "quality": 80}`, "image/jpeg", "jpeg"},
+		{"/pdf", `{"url": "https://example.com", "format": "a4", "landscape": true}`, "application/pdf", "%PDF a4"},
+		{"/content", `{"url": "https://example.com"}`, "application/json", `{"url":"https://example.com","title":"Title of https://example.com","content":"<html></html>"}`},
+		{"/evaluate", `{"url": "https://example.com", "script": "document.title", "timeout": "5s"}`, "application/json", `{"result":{"script":"document.title","timeout_ms":5000}}`},
+	} {
+		res, body := post(t, ts, tc.path, tc.body)
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("%s %s: expected 200, got %d: %s", tc.path, tc.body, res.StatusCode, body)
+			continue
+		}
+		if got := res.Header.Get("Content-Type"); got != tc.contentType {
+			t.Errorf("%s %s: expected %s, got %s", tc.path, tc.body, tc.contentType, got)
+		}
+		if strings.TrimSpace(body) != tc.want {
+			t.Errorf("%s %s: expected %q, got %q", tc.path, tc.body, tc.want, body)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ts, _ := newServer(t, Config{MaxBodyBytes: 200})
+
+	for _, tc := range []struct {
+		path, body string
+		status     int
+		kind       engine.ErrorKind
+	}{
+		{"/screenshot", `{"url": "https://example.com", "path": "/etc/passwd"}`, http.StatusBadRequest, ""},
+		{"/screenshot", `{"url": "https://example.com", "format": "gif"}`, http.StatusBadRequest, ""},
+		{"/screenshot", `{"url": "https://example.com", "fullpage": true}`, http.StatusBadRequest, ""},
+		{"/screenshot", `{"url": "https://example.com", "wait_until": "idle"}`, http.StatusBadRequest, engine.KindConfig},
+		{"/content", `{}`, http.StatusBadRequest, ""},
+		{"/content", `{"url": "file:///etc/passwd"}`, http.StatusBadRequest, ""},
+		{"/evaluate", `{"url": "file:///etc/passwd", "script": "document.body.innerText"}`, http.StatusBadRequest, ""},
+		{"/screenshot", `{"url": "javascript:alert(1)"}`, http.StatusBadRequest, ""},
+		{"/pdf", `{"url": "example.com"}`, http.StatusBadRequest, ""},
+		{"/content", `{"url": "https://slow.example.com"}`, http.StatusGatewayTimeout, engine.KindNavigationTimeout},
+		{"/content", `{"url": "https://example.com", "script": "` + strings.Repeat("x", 200) + `"}`, http.StatusBadRequest, ""},
+		{"/evaluate", `{"url": "https://example.com"}`, http.StatusBadRequest, ""},
+		{"/evaluate", `{"url": "https://example.com", "script": "throw"}`, http.StatusUnprocessableEntity, engine.KindScript},
+	} {
+		res, body := post(t, ts, tc.path, tc.body)
+		var e ErrorResponse
+		if err := json.Unmarshal([]byte(body), &e); err != nil {
+			t.Fatalf("%s %s: %v: %s", tc.path, tc.body, err, body)
+		}
+		if res.StatusCode != tc.status || e.Kind != tc.kind || e.Error == "" {
+			t.Errorf("%s %s: expected %d %q, got %d %s", tc.path, tc.body, tc.status, tc.kind, res.StatusCode, body)
+		}
+	}
+
+	res, err := http.Get(ts.URL + "/screenshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusMethodNotAllowed || res.Header.Get("Allow") != http.MethodPost {
+		t.Errorf("expected GET /screenshot to be refused, got %d", res.StatusCode)
+	}
+}
+
+func TestBusyPool(t *testing.T) {
+	ts, pages := newServer(t, Config{RequestTimeout: 50 * time.Millisecond})
+
+	// The pool has one page; holding it leaves none for requests.
+	held, err := pages.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, body := post(t, ts, "/content", `{"url": "https://example.com"}`)
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while no page is free, got %d: %s", res.StatusCode, body)
+	}
+	held.Release()
+
+	res, err = http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var health HealthResponse
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		t.Fatal(err)
+	}
+	if health.Status != "ok" || health.Pool.Acquired != 1 || health.Pool.InUse != 0 {
+		t.Errorf("unexpected health %+v", health)
+	}
+}
+
+func TestRequestsDoNotShareSessions(t *testing.T) {
+	// Both requests get the pool's one browser, one page at a time
+	ts, _, browser := newServerWithEngine(t, Config{})
+
+	res, body := post(t, ts, "/evaluate", `{"url": "https://example.com/login", "script": "login"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.StatusCode, body)
+	}
+	res, body = post(t, ts, "/evaluate", `{"url": "https://example.com/account", "script": "session"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.StatusCode, body)
+	}
+	if strings.TrimSpace(body) != `{"result":""}` {
+		t.Errorf("expected the second request to see no session, got %s", body)
+	}
+	if opened := browser.Opened(); opened != 2 {
+		t.Errorf("expected a new page for each request, got %d pages", opened)
+	}
+}
@@ -0,0 +1,121 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/engine"
+)
+
+// DefaultTimeout is how long wait SELECTOR waits without a timeout of its own
+const DefaultTimeout = 30 * time.Second
+
+// Options are the settings of Run
+type Options struct {
+	Navigation *engine.NavigationOptions // of open
+	Timeout    time.Duration             // of wait SELECTOR; DefaultTimeout by default
+	Log        io.Writer                 // gets a line per step that passed; none by default
+}
+
+// Run runs the steps of s against page in order and stops at the first
+// that fails, with an *Error. Stopping ctx stops it between steps.
+func (s *Script) Run(ctx context.Context, page engine.Page, opts Options) error {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	for _, step := range s.Steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		start := time.Now()
+		if err := step.run(page, opts); err != nil {
+			return &Error{Path: s.Path, Line: step.Line, Err: err}
+		}
+		fmt.Fprintf(opts.Log, "  ✅ %d: %s (%v)\n", step.Line, step.Text, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+func (s Step) run(page engine.Page, opts Options) error {
+	args := s.Args
+	switch s.Name {
+	case Open:
+		return page.Navigate(args[0], opts.Navigation)
+	case Click:
+		return page.Click(args[0])
+	case Type:
+		return page.Type(args[0], args[1])
+	case Wait:
+		if s.pause {
+			return page.WaitForTimeout(s.duration)
+		}
+		timeout := s.duration
+		if timeout == 0 {
+			timeout = opts.Timeout
+		}
+		_, err := page.WaitForSelector(args[0], &engine.WaitOptions{Timeout: timeout, Visible: true})
+		return err
+	case ExpectTitle:
+		title, err := page.Title()
+		if err != nil {
+			return err
+		}
+		if title != args[0] {
+			return fmt.Errorf("expected the title %q, got %q", args[0], title)
+		}
+	case ExpectText:
+		selector, want := "body", args[0]
+		if len(args) == 2 {
+			selector, want = args[0], args[1]
+		}
+		text, err := innerText(page, selector)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(text, want) {
+			return fmt.Errorf("expected %s to contain %q, got %q", selector, want, abbreviate(text))
+		}
+	case Screenshot:
+		if err := os.MkdirAll(filepath.Dir(s.shot.Path), 0755); err != nil {
+			return err
+		}
+		return page.Screenshot(s.shot)
+	case SetViewport:
+		return page.SetViewport(s.viewport)
+	}
+	return nil
+}
+
+// innerText is the text of the first element matching selector
+func innerText(page engine.Page, selector string) (string, error) {
+	el, err := page.QuerySelector(selector)
+	if err != nil {
+		return "", err
+	}
+	if el == nil {
+		return "", fmt.Errorf("no element matches %s", selector)
+	}
+	v, err := el.GetProperty("innerText")
+	if err != nil {
+		return "", err
+	}
+	text, _ := v.(string)
+	return text, nil
+}
+
+// abbreviate shortens page text for an error message
+func abbreviate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 80 {
+		return string(r[:80]) + "…"
+	}
+	return s
+}
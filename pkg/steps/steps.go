@@ -0,0 +1,247 @@
+// Package steps parses and runs .gemini step files: smoke tests written as
+// one browser step per line, for people who don't write JavaScript.
+//
+//	# Example Domain loads
+//	gemini set-viewport 1280x720
+//	gemini open https://example.com
+//	gemini wait h1
+//	gemini expect-title "Example Domain"
+//	gemini expect-text h1 "Example"
+//	gemini screenshot shots/example.png full-page
+//
+// Lines starting with # and blank lines are skipped, and the leading
+// "gemini" is optional. Arguments are separated by spaces; double quotes
+// keep spaces in one argument and take \" and \\ escapes, single quotes
+// take none. Steps run in order against an engine.Page, and every error,
+// when parsing or running, names the file and line of its step.
+package steps
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"phantomvite/pkg/config"
+	"phantomvite/pkg/engine"
+)
+
+// Ext is the extension of step files
+const Ext = ".gemini"
+
+// Prefix is the keyword step lines may start with
+const Prefix = "gemini"
+
+// Names of the steps
+const (
+	Open        = "open"         // open URL
+	Click       = "click"        // click SELECTOR
+	Type        = "type"         // type SELECTOR TEXT
+	Wait        = "wait"         // wait DURATION, or wait SELECTOR [TIMEOUT]
+	ExpectTitle = "expect-title" // expect-title TITLE
+	ExpectText  = "expect-text"  // expect-text [SELECTOR] TEXT
+	Screenshot  = "screenshot"   // screenshot FILE [full-page]
+	SetViewport = "set-viewport" // set-viewport WIDTHxHEIGHT, or set-viewport WIDTH HEIGHT
+)
+
+// Names are the steps Parse accepts
+var Names = []string{Open, Click, Type, Wait, ExpectTitle, ExpectText, Screenshot, SetViewport}
+
+// Script is a parsed step file
+type Script struct {
+	Path  string
+	Steps []Step
+}
+
+// Step is one line of a script, with its arguments checked by Parse
+type Step struct {
+	Line int      // from 1
+	Name string   // one of Names
+	Args []string // unquoted
+	Text string   // the line without its prefix, as written
+
+	pause    bool                     // wait DURATION rather than wait SELECTOR
+	duration time.Duration            // wait DURATION, or the timeout of wait SELECTOR
+	viewport engine.ViewportConfig    // set-viewport
+	shot     engine.ScreenshotOptions // screenshot
+}
+
+// String is the step as written
+func (s Step) String() string { return s.Text }
+
+// Error is an error of the step at Line of Path, when parsing or running
+// it. It unwraps to Err, so engine.KindOf classifies the errors of the
+// engine.
+type Error struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err) }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// ParseFile reads and parses the step file at path
+func ParseFile(path string) (*Script, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(path, f)
+}
+
+// Parse parses the steps of r, which path names in errors. It stops at the
+// first mistake.
+func Parse(path string, r io.Reader) (*Script, error) {
+	script := &Script{Path: path}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		step, err := parseStep(line)
+		if err != nil {
+			return nil, &Error{Path: path, Line: n, Err: err}
+		}
+		step.Line = n
+		script.Steps = append(script.Steps, step)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(script.Steps) == 0 {
+		return nil, fmt.Errorf("%s: no steps", path)
+	}
+	return script, nil
+}
+
+func parseStep(line string) (Step, error) {
+	words, err := split(line)
+	if err != nil {
+		return Step{}, err
+	}
+	if words[0] == Prefix {
+		words = words[1:]
+		line = strings.TrimSpace(strings.TrimPrefix(line, Prefix))
+		if len(words) == 0 {
+			return Step{}, fmt.Errorf("missing step after %q; use %s", Prefix, strings.Join(Names, ", "))
+		}
+	}
+	step := Step{Name: words[0], Args: words[1:], Text: line}
+	args := step.Args
+	usage := func(form string) error { return fmt.Errorf("%s takes %s", step.Name, form) }
+
+	switch step.Name {
+	case Open:
+		if len(args) != 1 {
+			return step, usage("a URL")
+		}
+	case Click:
+		if len(args) != 1 {
+			return step, usage("a selector")
+		}
+	case Type:
+		if len(args) != 2 {
+			return step, usage("a selector and the text to type")
+		}
+	case Wait:
+		if len(args) == 0 || len(args) > 2 {
+			return step, usage("a duration such as 2s, or a selector and an optional timeout")
+		}
+		if d, err := config.ParseDuration(args[0]); err == nil && len(args) == 1 {
+			if d < 0 {
+				return step, fmt.Errorf("wait %s: the duration must not be negative", args[0])
+			}
+			step.pause, step.duration = true, time.Duration(d)
+		} else if len(args) == 2 {
+			d, err := config.ParseDuration(args[1])
+			if err != nil || d <= 0 {
+				return step, fmt.Errorf("wait %s: the timeout must be a duration such as 10s, got %q", args[0], args[1])
+			}
+			step.duration = time.Duration(d)
+		}
+	case ExpectTitle:
+		if len(args) != 1 {
+			return step, usage("the expected title")
+		}
+	case ExpectText:
+		if len(args) == 0 || len(args) > 2 {
+			return step, usage("the expected text, after an optional selector")
+		}
+	case Screenshot:
+		if len(args) == 0 || len(args) > 2 || (len(args) == 2 && args[1] != "full-page") {
+			return step, usage("a file and optionally full-page")
+		}
+		format, ok := engine.ScreenshotFormat(args[0])
+		if !ok {
+			return step, fmt.Errorf("screenshot %s: the extension must be .png, .jpg or .webp", args[0])
+		}
+		step.shot = engine.ScreenshotOptions{Path: args[0], Format: format, FullPage: len(args) == 2}
+	case SetViewport:
+		size := strings.Join(args, "x")
+		w, h, ok := strings.Cut(strings.ToLower(size), "x")
+		width, werr := strconv.Atoi(w)
+		height, herr := strconv.Atoi(h)
+		if len(args) == 0 || len(args) > 2 || !ok || werr != nil || herr != nil || width < 1 || height < 1 {
+			return step, usage(fmt.Sprintf("a size such as 1280x720, got %q", strings.Join(args, " ")))
+		}
+		step.viewport = engine.ViewportConfig{Width: width, Height: height}
+	default:
+		return step, fmt.Errorf("unknown step %q; use %s", step.Name, strings.Join(Names, ", "))
+	}
+	return step, nil
+}
+
+// split breaks line into its arguments
+func split(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '"':
+			inWord = true
+			for i++; ; i++ {
+				if i == len(line) {
+					return nil, errors.New("missing closing \"")
+				}
+				if line[i] == '"' {
+					break
+				}
+				if line[i] == '\\' && i+1 < len(line) && (line[i+1] == '"' || line[i+1] == '\\') {
+					i++
+				}
+				word.WriteByte(line[i])
+			}
+		case c == '\'':
+			inWord = true
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("missing closing '")
+			}
+			word.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+		default:
+			inWord = true
+			word.WriteByte(c)
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,184 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"phantomvite/pkg/engine"
+	"phantomvite/pkg/engine/enginetest"
+)
+
+// newPage opens a page of a fake engine, on which each selector of texts
+// matches an element with its text
+func newPage(t *testing.T, texts map[string]string) *enginetest.Page {
+	t.Helper()
+	doc := &enginetest.Element{Matches: make(map[string][]*enginetest.Element)}
+	for selector, text := range texts {
+		doc.Matches[selector] = []*enginetest.Element{{Text: text}}
+	}
+	eng := &enginetest.Engine{Document: func(string) *enginetest.Element { return doc }}
+	page, err := eng.NewPage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return page.(*enginetest.Page)
+}
+
+func TestParse(t *testing.T) {
+	script, err := Parse("smoke.gemini", strings.NewReader(`# Gemini test file
+gemini open https://example.com
+
+	gemini expect-title "Example \"Domain\""
+type input[name='q'] 'a "b" c\'
+gemini wait 250
+wait "#app .ready" 5s
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range script.Steps {
+		got = append(got, fmt.Sprintf("%d %s %q", s.Line, s.Name, s.Args))
+	}
+	want := []string{
+		`2 open ["https://example.com"]`,
+		`4 expect-title ["Example \"Domain\""]`,
+		`5 type ["input[name=q]" "a \"b\" c\\"]`,
+		`6 wait ["250"]`,
+		`7 wait ["#app .ready" "5s"]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if s := script.Steps[1]; s.String() != `expect-title "Example \"Domain\""` {
+		t.Errorf("expected the step as written, got %q", s)
+	}
+
+	for _, tc := range []struct {
+		src, want string
+	}{
+		{"# nothing\n\n", "bad.gemini: no steps"},
+		{"gemini open https://example.com\ngemini opne https://example.com", `bad.gemini:2: unknown step "opne"; use open, click, type, wait, expect-title, expect-text, screenshot, set-viewport`},
+		{"\n\ngemini", `bad.gemini:3: missing step after "gemini"`},
+		{"open", "bad.gemini:1: open takes a URL"},
+		{"click a b", "bad.gemini:1: click takes a selector"},
+		{"type #q", "bad.gemini:1: type takes a selector and the text to type"},
+		{"wait", "bad.gemini:1: wait takes a duration such as 2s, or a selector and an optional timeout"},
+		{"wait -1s", "bad.gemini:1: wait -1s: the duration must not be negative"},
+		{"wait #app soon", `bad.gemini:1: wait #app: the timeout must be a duration such as 10s, got "soon"`},
+		{`expect-title "Example`, `bad.gemini:1: missing closing "`},
+		{"expect-text", "bad.gemini:1: expect-text takes the expected text, after an optional selector"},
+		{"screenshot shot.gif", "bad.gemini:1: screenshot shot.gif: the extension must be .png, .jpg or .webp"},
+		{"screenshot shot.png all", "bad.gemini:1: screenshot takes a file and optionally full-page"},
+		{"set-viewport 1280", `bad.gemini:1: set-viewport takes a size such as 1280x720, got "1280"`},
+		{"set-viewport 0x720", `bad.gemini:1: set-viewport takes a size such as 1280x720, got "0x720"`},
+	} {
+		_, err := Parse("bad.gemini", strings.NewReader(tc.src))
+		if err == nil || !strings.HasPrefix(err.Error(), tc.want) {
+			t.Errorf("%q: expected %q, got %v", tc.src, tc.want, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	chdir(t, t.TempDir())
+	script, err := Parse("smoke.gemini", strings.NewReader(`gemini set-viewport 1280 720
+gemini open https://example.com
+gemini wait 2s
+gemini wait h1
+gemini wait "#late" 1m
+gemini type #q "phantom vite"
+gemini click button
+gemini expect-title "Title of https://example.com"
+gemini expect-text "Example Domain"
+gemini expect-text h1 Example
+gemini screenshot shots/home.jpg full-page
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := newPage(t, map[string]string{"body": "Example Domain\nMore information...", "h1": "Example Domain"})
+	var log strings.Builder
+	err = script.Run(context.Background(), page, Options{Navigation: &engine.NavigationOptions{Timeout: 5 * time.Second}, Log: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"viewport 1280x720",
+		"navigate https://example.com 5s",
+		"sleep 2s",
+		"wait h1 30s",
+		"wait #late 1m0s",
+		`type #q "phantom vite"`,
+		"click button",
+		"screenshot shots/home.jpg jpeg true",
+	}
+	if calls := page.Calls(); !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected calls\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(calls, "\n"))
+	}
+	if _, err := os.Stat(filepath.Join("shots", "home.jpg")); err != nil {
+		t.Error(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(log.String()), "\n"); len(lines) != 11 || !strings.HasPrefix(lines[1], "  ✅ 2: open https://example.com (") {
+		t.Errorf("expected a line per step, got\n%s", log.String())
+	}
+
+	for _, tc := range []struct {
+		src, want string
+		kind      engine.ErrorKind
+	}{
+		{"open https://example.com\n\nexpect-title Other", `run.gemini:3: expected the title "Other", got "Title of https://example.com"`, ""},
+		{"open https://example.com\nexpect-text Missing", `run.gemini:2: expected body to contain "Missing", got "Example Domain More information..."`, ""},
+		{"open https://example.com\nexpect-text #nope Example", "run.gemini:2: no element matches #nope", ""},
+		{"# slow\nopen https://slow.example", "run.gemini:2: ", engine.KindNavigationTimeout},
+	} {
+		script, err := Parse("run.gemini", strings.NewReader(tc.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := newPage(t, map[string]string{"body": "Example Domain\n  More information..."})
+		err = script.Run(context.Background(), page, Options{})
+		var stepErr *Error
+		if !errors.As(err, &stepErr) || !strings.HasPrefix(err.Error(), tc.want) {
+			t.Errorf("%q: expected %q, got %v", tc.src, tc.want, err)
+		}
+		if tc.kind != "" && engine.KindOf(err) != tc.kind {
+			t.Errorf("%q: expected kind %s, got %s", tc.src, tc.kind, engine.KindOf(err))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := script.Run(ctx, newPage(t, nil), Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a stopped run to fail with context.Canceled, got %v", err)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	script, err := ParseFile(filepath.Join("..", "..", "scripts", "gemini-test.gemini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(script.Steps) != 2 || script.Steps[0].Name != Open || script.Steps[1].Name != ExpectTitle {
+		t.Errorf("expected open and expect-title, got %+v", script.Steps)
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
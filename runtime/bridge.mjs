@@ -0,0 +1,538 @@
+// runtime/bridge.mjs
+//
+// Long-lived sidecar that exposes Puppeteer or Playwright to the Go CLI.
+// Requests and responses are JSON-RPC 2.0 objects, one per line, on
+// stdin/stdout. Anything else a page or library prints goes to stderr so it
+// can never corrupt the protocol stream.
+
+import { createInterface } from 'readline';
+
+const rpcOut = process.stdout.write.bind(process.stdout);
+console.log = (...args) => console.error(...args);
+console.info = (...args) => console.error(...args);
+
+const pages = new Map();
+const elements = new Map();
+const elementPages = new Map(); // elementId -> pageId of the page it belongs to
+let nextId = 1;
+let driver = null;
+
+function register(map, value) {
+  const id = String(nextId++);
+  map.set(id, value);
+  return id;
+}
+
+function lookup(map, id, kind) {
+  const value = map.get(id);
+  if (!value) throw Object.assign(new Error(`unknown ${kind}: ${id}`), { name: 'NotFoundError' });
+  return value;
+}
+
+// registerElement keeps handle until its page closes or navigates away
+function registerElement(pageId, handle) {
+  const id = register(elements, handle);
+  elementPages.set(id, pageId);
+  return id;
+}
+
+// releaseElements drops the handles of pageId; the document they point into
+// is gone or about to be.
+function releaseElements(pageId) {
+  for (const [id, owner] of elementPages) {
+    if (owner !== pageId) continue;
+    const handle = elements.get(id);
+    elements.delete(id);
+    elementPages.delete(id);
+    handle?.dispose?.().catch(() => {});
+  }
+}
+
+// ---------------------------------------------------------------------------
+// Drivers adapt each library to the small surface the Go engine relies on.
+
+// launchArgs are the Chromium flags shared by both drivers. Puppeteer has no
+// proxy option, so the proxy is passed as a flag there.
+function launchArgs(config, withProxy) {
+  const args = [...(config.args || [])];
+  if (withProxy && config.proxy) args.push(`--proxy-server=${config.proxy}`);
+  return args;
+}
+
+async function puppeteerDriver(config) {
+  const { default: puppeteer } = await import('puppeteer');
+  const browser = await puppeteer.launch({
+    headless: config.headless,
+    executablePath: config.executable_path || undefined,
+    args: launchArgs(config, true),
+    timeout: ms(config.timeout),
+    defaultViewport: config.viewport?.width ? config.viewport : null,
+  });
+  const state = { userAgent: config.user_agent || '', headers: config.extra_headers || {} };
+
+  return {
+    name: 'puppeteer',
+    async newPage() {
+      const page = await browser.newPage();
+      if (config.timeout) page.setDefaultTimeout(ms(config.timeout));
+      if (state.userAgent) await page.setUserAgent(state.userAgent);
+      if (Object.keys(state.headers).length) await page.setExtraHTTPHeaders(state.headers);
+      return page;
+    },
+    async setUserAgent(ua) {
+      state.userAgent = ua;
+      for (const page of pages.values()) await page.setUserAgent(ua);
+    },
+    async setExtraHeaders(headers) {
+      state.headers = headers;
+      for (const page of pages.values()) await page.setExtraHTTPHeaders(headers);
+    },
+    waitUntil: (value) => value || 'load',
+    setViewport: (page, vp) => page.setViewport({ width: vp.width, height: vp.height }),
+    cookies: (page) => page.cookies(),
+    setCookies: (page, cookies) => page.setCookie(...cookies),
+    async clearCookies(page) {
+      const client = await page.createCDPSession();
+      await client.send('Network.clearBrowserCookies');
+      await client.detach();
+    },
+    metrics: (page) => page.metrics(),
+    async emulate(pageId, device) {
+      await lookup(pages, pageId, 'page').emulate({
+        userAgent: device.userAgent,
+        viewport: {
+          width: device.viewport.width,
+          height: device.viewport.height,
+          deviceScaleFactor: device.deviceScaleFactor,
+          isMobile: device.isMobile,
+          hasTouch: device.hasTouch,
+        },
+      });
+    },
+    close: () => browser.close(),
+  };
+}
+
+async function playwrightDriver(config) {
+  const { chromium } = await import('playwright');
+  const browser = await chromium.launch({
+    headless: config.headless,
+    executablePath: config.executable_path || undefined,
+    args: launchArgs(config, false),
+    proxy: config.proxy ? { server: config.proxy } : undefined,
+    timeout: ms(config.timeout),
+  });
+  const state = {
+    userAgent: config.user_agent || undefined,
+    headers: config.extra_headers || {},
+    viewport: config.viewport?.width ? config.viewport : null,
+  };
+
+  // Playwright binds user agent and device settings to a context, so each
+  // page gets its own context, and changing them moves the page to a new one.
+  const contextOptions = new WeakMap(); // page -> options of its context
+
+  async function openPage(options) {
+    const context = await browser.newContext(options);
+    if (config.timeout) context.setDefaultTimeout(ms(config.timeout));
+    const page = await context.newPage();
+    page.on('close', () => context.close().catch(() => {}));
+    contextOptions.set(page, options);
+    return page;
+  }
+
+  // reopen replaces the page of pageId with one in a context of options,
+  // bringing its cookies and reloading its URL
+  async function reopen(pageId, options) {
+    const old = lookup(pages, pageId, 'page');
+    const page = await openPage(options);
+    const cookies = await old.context().cookies();
+    if (cookies.length) await page.context().addCookies(cookies);
+    const url = old.url();
+    releaseElements(pageId);
+    pages.set(pageId, page);
+    await old.close();
+    if (url && url !== 'about:blank') await page.goto(url);
+  }
+
+  return {
+    name: 'playwright',
+    newPage: () => openPage({ userAgent: state.userAgent, viewport: state.viewport, extraHTTPHeaders: state.headers }),
+    async setUserAgent(ua) {
+      state.userAgent = ua;
+      for (const [pageId, page] of pages) await reopen(pageId, { ...contextOptions.get(page), userAgent: ua });
+    },
+    async setExtraHeaders(headers) {
+      state.headers = headers;
+      for (const page of pages.values()) {
+        contextOptions.set(page, { ...contextOptions.get(page), extraHTTPHeaders: headers });
+        await page.setExtraHTTPHeaders(headers);
+      }
+    },
+    waitUntil: (value) => (value && value.startsWith('networkidle') ? 'networkidle' : value || 'load'),
+    setViewport: (page, vp) => page.setViewportSize({ width: vp.width, height: vp.height }),
+    cookies: (page) => page.context().cookies(),
+    async setCookies(page, cookies) {
+      const url = page.url();
+      await page.context().addCookies(cookies.map((c) => (c.domain ? c : { ...c, url })));
+    },
+    clearCookies: (page) => page.context().clearCookies(),
+    metrics: async () => ({}),
+    async emulate(pageId, device) {
+      const options = contextOptions.get(lookup(pages, pageId, 'page'));
+      await reopen(pageId, {
+        ...options,
+        userAgent: device.userAgent || options.userAgent,
+        viewport: { width: device.viewport.width, height: device.viewport.height },
+        deviceScaleFactor: device.deviceScaleFactor || undefined,
+        isMobile: device.isMobile,
+        hasTouch: device.hasTouch,
+      });
+    },
+    close: () => browser.close(),
+  };
+}
+
+const drivers = { puppeteer: puppeteerDriver, playwright: playwrightDriver };
+
+// ---------------------------------------------------------------------------
+// Helpers shared by both drivers; their page and element APIs overlap here.
+
+function ms(nanos) {
+  return nanos ? Math.round(nanos / 1e6) : undefined;
+}
+
+function navOptions(options = {}) {
+  return { waitUntil: driver.waitUntil(options.wait_until), timeout: ms(options.timeout), referer: options.referer || undefined };
+}
+
+// afterNavigation waits for options.wait_for_selector in what is left of
+// the navigation timeout, which ran from started
+async function afterNavigation(page, options = {}, started = Date.now()) {
+  if (options.wait_for_selector) {
+    const timeout = ms(options.timeout);
+    await page.waitForSelector(options.wait_for_selector, {
+      timeout: timeout && Math.max(1, timeout - (Date.now() - started)),
+    });
+  }
+}
+
+function screenshotOptions(options) {
+  const type = options.format === 'jpg' ? 'jpeg' : options.format || undefined;
+  return {
+    path: options.path,
+    type,
+    quality: type && type !== 'png' && options.quality ? options.quality : undefined,
+    fullPage: !!options.full_page,
+    clip: options.clip || undefined,
+    omitBackground: !!options.omit_background,
+  };
+}
+
+// pdfOptions maps PDFOptions, whose lengths are inches and whose paper size
+// and templates the Go side has already resolved
+function pdfOptions(options) {
+  const inches = (n) => `${n || 0}in`;
+  const margin = options.margin;
+  return {
+    path: options.path,
+    width: inches(options.width),
+    height: inches(options.height),
+    landscape: !!options.landscape,
+    margin: margin
+      ? { top: inches(margin.top), right: inches(margin.right), bottom: inches(margin.bottom), left: inches(margin.left) }
+      : undefined,
+    scale: options.scale || undefined,
+    printBackground: !!options.print_background,
+    pageRanges: options.page_ranges || undefined,
+    displayHeaderFooter: !!(options.header_template || options.footer_template),
+    headerTemplate: options.header_template || undefined,
+    footerTemplate: options.footer_template || undefined,
+  };
+}
+
+function toCookie(c) {
+  return {
+    name: c.name,
+    value: c.value,
+    domain: c.domain,
+    path: c.path,
+    expires: c.expires > 0 ? Math.floor(c.expires) : 0,
+    httpOnly: !!c.httpOnly,
+    secure: !!c.secure,
+    sameSite: c.sameSite,
+    priority: c.priority,
+  };
+}
+
+function fromCookie(c) {
+  const cookie = { name: c.name, value: c.value };
+  if (c.domain) cookie.domain = c.domain;
+  if (c.path) cookie.path = c.path;
+  if (c.expires) cookie.expires = c.expires;
+  if (c.httpOnly) cookie.httpOnly = true;
+  if (c.secure) cookie.secure = true;
+  if (c.sameSite) cookie.sameSite = c.sameSite;
+  return cookie;
+}
+
+async function isVisible(handle) {
+  return handle.evaluate((el) => {
+    const style = window.getComputedStyle(el);
+    const r = el.getBoundingClientRect();
+    return style.visibility !== 'hidden' && style.display !== 'none' && r.width > 0 && r.height > 0;
+  });
+}
+
+function handleOrNull(pageId, handle) {
+  return handle ? { elementId: registerElement(pageId, handle) } : { elementId: null };
+}
+
+// ---------------------------------------------------------------------------
+// RPC methods. Every page method takes a pageId; element methods an elementId.
+
+const methods = {
+  async launch({ driver: name, config }) {
+    if (driver) throw new Error('browser already launched');
+    const factory = drivers[name];
+    if (!factory) throw new Error(`unsupported driver: ${name}`);
+    driver = await factory(config || {});
+    return { driver: driver.name };
+  },
+
+  async close() {
+    if (driver) await driver.close();
+    driver = null;
+    pages.clear();
+    elements.clear();
+    elementPages.clear();
+    return {};
+  },
+
+  async newPage() {
+    const page = await driver.newPage();
+    return { pageId: register(pages, page) };
+  },
+
+  async setUserAgent({ userAgent }) {
+    await driver.setUserAgent(userAgent);
+    return {};
+  },
+
+  async setExtraHeaders({ headers }) {
+    await driver.setExtraHeaders(headers || {});
+    return {};
+  },
+
+  'page.navigate': async ({ pageId, url, options }) => {
+    const page = lookup(pages, pageId, 'page');
+    releaseElements(pageId);
+    const started = Date.now();
+    await page.goto(url, navOptions(options));
+    await afterNavigation(page, options, started);
+    return {};
+  },
+  'page.reload': async ({ pageId, options }) => {
+    const page = lookup(pages, pageId, 'page');
+    releaseElements(pageId);
+    const started = Date.now();
+    await page.reload(navOptions(options));
+    await afterNavigation(page, options, started);
+    return {};
+  },
+  'page.goBack': async ({ pageId }) => {
+    const page = lookup(pages, pageId, 'page');
+    releaseElements(pageId);
+    await page.goBack();
+    return {};
+  },
+  'page.goForward': async ({ pageId }) => {
+    const page = lookup(pages, pageId, 'page');
+    releaseElements(pageId);
+    await page.goForward();
+    return {};
+  },
+  'page.waitForNavigation': async ({ pageId, options }) => {
+    const page = lookup(pages, pageId, 'page');
+    const started = Date.now();
+    const { waitUntil, timeout } = navOptions(options);
+    if (page.waitForNavigation) await page.waitForNavigation({ waitUntil, timeout });
+    else await page.waitForLoadState(waitUntil, { timeout });
+    releaseElements(pageId);
+    await afterNavigation(page, options, started);
+    return {};
+  },
+  'page.title': async ({ pageId }) => ({ value: await lookup(pages, pageId, 'page').title() }),
+  'page.url': async ({ pageId }) => ({ value: lookup(pages, pageId, 'page').url() }),
+  'page.content': async ({ pageId }) => ({ value: await lookup(pages, pageId, 'page').content() }),
+
+  'page.querySelector': async ({ pageId, selector }) => handleOrNull(pageId, await lookup(pages, pageId, 'page').$(selector)),
+  'page.querySelectorAll': async ({ pageId, selector }) => {
+    const handles = await lookup(pages, pageId, 'page').$$(selector);
+    return { elementIds: handles.map((h) => registerElement(pageId, h)) };
+  },
+  'page.waitForSelector': async ({ pageId, selector, options = {} }) => {
+    const page = lookup(pages, pageId, 'page');
+    const handle = await page.waitForSelector(selector, {
+      timeout: ms(options.timeout),
+      visible: options.visible || undefined,
+      hidden: options.hidden || undefined,
+      state: options.visible ? 'visible' : options.hidden ? 'hidden' : undefined,
+    });
+    return handleOrNull(pageId, handle);
+  },
+
+  // Both libraries evaluate a string as an expression and await a returned promise.
+  'page.evaluate': async ({ pageId, script }) => ({
+    value: (await lookup(pages, pageId, 'page').evaluate(script)) ?? null,
+  }),
+  'page.waitForFunction': async ({ pageId, script, options = {} }) => {
+    await lookup(pages, pageId, 'page').waitForFunction(script, undefined, {
+      timeout: ms(options.timeout),
+      polling: ms(options.polling),
+    });
+    return {};
+  },
+  'page.waitForTimeout': async ({ timeout }) => {
+    await new Promise((resolve) => setTimeout(resolve, ms(timeout)));
+    return {};
+  },
+
+  'page.click': async ({ pageId, selector }) => {
+    await lookup(pages, pageId, 'page').click(selector);
+    return {};
+  },
+  'page.type': async ({ pageId, selector, text }) => {
+    await lookup(pages, pageId, 'page').type(selector, text);
+    return {};
+  },
+  'page.fill': async ({ pageId, selector, text }) => {
+    const page = lookup(pages, pageId, 'page');
+    if (page.fill) await page.fill(selector, text);
+    else
+      await page.$eval(
+        selector,
+        (el, value) => {
+          el.value = value;
+          el.dispatchEvent(new Event('input', { bubbles: true }));
+          el.dispatchEvent(new Event('change', { bubbles: true }));
+        },
+        text,
+      );
+    return {};
+  },
+  'page.select': async ({ pageId, selector, values }) => {
+    const page = lookup(pages, pageId, 'page');
+    if (page.selectOption) await page.selectOption(selector, values);
+    else await page.select(selector, ...values);
+    return {};
+  },
+
+  'page.screenshot': async ({ pageId, options }) => {
+    await lookup(pages, pageId, 'page').screenshot(screenshotOptions(options));
+    return {};
+  },
+
+  'page.pdf': async ({ pageId, options }) => {
+    await lookup(pages, pageId, 'page').pdf(pdfOptions(options));
+    return {};
+  },
+
+  'page.cookies': async ({ pageId }) => ({ cookies: (await driver.cookies(lookup(pages, pageId, 'page'))).map(toCookie) }),
+  'page.setCookies': async ({ pageId, cookies }) => {
+    await driver.setCookies(lookup(pages, pageId, 'page'), cookies.map(fromCookie));
+    return {};
+  },
+  'page.clearCookies': async ({ pageId }) => {
+    await driver.clearCookies(lookup(pages, pageId, 'page'));
+    return {};
+  },
+
+  'page.setViewport': async ({ pageId, viewport }) => {
+    await driver.setViewport(lookup(pages, pageId, 'page'), viewport);
+    return {};
+  },
+  'page.metrics': async ({ pageId }) => ({ metrics: await driver.metrics(lookup(pages, pageId, 'page')) }),
+  'page.emulate': async ({ pageId, device }) => {
+    await driver.emulate(pageId, device);
+    return {};
+  },
+  'page.close': async ({ pageId }) => {
+    const page = lookup(pages, pageId, 'page');
+    pages.delete(pageId);
+    releaseElements(pageId);
+    await page.close();
+    return {};
+  },
+
+  'element.click': async ({ elementId }) => {
+    await lookup(elements, elementId, 'element').click();
+    return {};
+  },
+  'element.type': async ({ elementId, text }) => {
+    await lookup(elements, elementId, 'element').type(text);
+    return {};
+  },
+  'element.querySelectorAll': async ({ elementId, selector }) => {
+    const handles = await lookup(elements, elementId, 'element').$$(selector);
+    const pageId = elementPages.get(elementId);
+    return { elementIds: handles.map((h) => registerElement(pageId, h)) };
+  },
+  'element.getAttribute': async ({ elementId, name }) => ({
+    value: await lookup(elements, elementId, 'element').evaluate((el, n) => el.getAttribute(n), name),
+  }),
+  'element.getProperty': async ({ elementId, name }) => ({
+    value: (await lookup(elements, elementId, 'element').evaluate((el, n) => el[n], name)) ?? null,
+  }),
+  'element.isVisible': async ({ elementId }) => ({ value: await isVisible(lookup(elements, elementId, 'element')) }),
+  'element.boundingBox': async ({ elementId }) => ({ box: await lookup(elements, elementId, 'element').boundingBox() }),
+};
+
+// ---------------------------------------------------------------------------
+// JSON-RPC plumbing
+
+function send(message) {
+  rpcOut(JSON.stringify({ jsonrpc: '2.0', ...message }) + '\n');
+}
+
+async function dispatch(request) {
+  const { id, method, params } = request;
+  const fn = methods[method];
+  if (!fn) {
+    send({ id, error: { code: -32601, message: `method not found: ${method}` } });
+    return;
+  }
+  if (!driver && method !== 'launch' && method !== 'close') {
+    send({ id, error: { code: -32002, message: 'browser not launched' } });
+    return;
+  }
+  try {
+    send({ id, result: await fn(params || {}) });
+  } catch (err) {
+    send({
+      id,
+      error: {
+        code: -32000,
+        message: err?.message || String(err),
+        data: { name: err?.name || 'Error', stack: err?.stack || '' },
+      },
+    });
+  }
+}
+
+const rl = createInterface({ input: process.stdin, crlfDelay: Infinity });
+rl.on('line', (line) => {
+  if (!line.trim()) return;
+  let request;
+  try {
+    request = JSON.parse(line);
+  } catch (err) {
+    send({ id: null, error: { code: -32700, message: `parse error: ${err.message}` } });
+    return;
+  }
+  dispatch(request);
+});
+rl.on('close', async () => {
+  if (driver) await driver.close().catch(() => {});
+  process.exit(0);
+});
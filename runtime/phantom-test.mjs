@@ -0,0 +1,118 @@
+// runtime/phantom-test.mjs
+//
+// Runs the tests of one bundled *.phantom.js file for 'phantom-vite test':
+//
+//   node phantom-test.mjs <module>
+//
+// It defines describe, test and expect as globals, imports the module to
+// collect its tests, then runs them in order, each within the timeout of
+// PHANTOM_TEST_TIMEOUT milliseconds or its own. Every result is POSTed to
+// the API at PHANTOM_TEST_API before the next test starts, which closes
+// the pages the test opened. Stacks of failures point into the sources of
+// the test, as Node runs with --enable-source-maps. A module that fails to
+// load is reported to /error and exits 1; failed tests do not change the
+// exit status.
+
+import { resolve } from 'path';
+import { pathToFileURL } from 'url';
+import { expect } from './phantom.mjs';
+
+const api = process.env.PHANTOM_TEST_API;
+const defaultTimeout = Number(process.env.PHANTOM_TEST_TIMEOUT) || 30000;
+
+async function post(path, body) {
+  const res = await fetch(`${api}${path}`, {
+    method: 'POST',
+    headers: { 'content-type': 'application/json' },
+    body: JSON.stringify(body),
+  });
+  if (!res.ok) throw new Error(`${path}: ${(await res.json()).error}`);
+}
+
+function message(err) {
+  return err instanceof Error ? err.message : String(err);
+}
+
+// stack drops the frames of the runner, the phantom module and Node from
+// the stack of err, leaving those of the test; undefined when none are left
+function stack(err) {
+  if (!(err instanceof Error) || !err.stack) return undefined;
+  const internal = /^\s+at .*(phantom-test\.mjs|phantom\.mjs|node:internal)/;
+  const lines = err.stack.split('\n').filter((line) => !internal.test(line));
+  return lines.some((line) => /^\s+at /.test(line)) ? lines.join('\n') : undefined;
+}
+
+// ---------------------------------------------------------------------------
+// Collecting: describe blocks run as the module loads and add their tests
+// to the suite around them.
+
+const root = { name: '', items: [] };
+let current = root;
+
+globalThis.describe = (name, fn) => {
+  const suite = { name: String(name), items: [] };
+  current.items.push(suite);
+  const parent = current;
+  current = suite;
+  try {
+    if (fn() instanceof Promise) throw new Error(`describe('${name}') must not be async; put awaits in tests`);
+  } finally {
+    current = parent;
+  }
+};
+
+function addTest(name, fn, timeout, skip) {
+  if (!skip && typeof fn !== 'function') throw new TypeError(`test('${name}') needs a function`);
+  current.items.push({ name: String(name), fn, timeout: timeout ?? defaultTimeout, skip });
+}
+
+globalThis.test = (name, fn, timeout) => addTest(name, fn, timeout, false);
+globalThis.test.skip = (name, fn) => addTest(name, fn, undefined, true);
+globalThis.it = globalThis.test;
+globalThis.expect = expect;
+
+// ---------------------------------------------------------------------------
+// Running
+
+async function runTest(t, suite) {
+  const result = { suite: suite.length ? suite : undefined, name: t.name, status: 'passed', duration_ms: 0 };
+  if (t.skip) {
+    result.status = 'skipped';
+    return post('/result', result);
+  }
+  const start = performance.now();
+  let timer;
+  try {
+    await Promise.race([
+      (async () => t.fn())(),
+      new Promise((_, reject) => {
+        timer = setTimeout(() => reject(new Error(`timed out after ${t.timeout}ms`)), t.timeout);
+      }),
+    ]);
+  } catch (err) {
+    result.status = 'failed';
+    result.error = message(err);
+    result.stack = stack(err);
+  } finally {
+    clearTimeout(timer);
+  }
+  result.duration_ms = performance.now() - start;
+  await post('/result', result);
+}
+
+async function run(suite, path) {
+  for (const item of suite.items) {
+    if (item.items) await run(item, [...path, item.name]);
+    else await runTest(item, path);
+  }
+}
+
+try {
+  await import(pathToFileURL(resolve(process.argv[2])).href);
+} catch (err) {
+  await post('/error', { error: message(err) });
+  process.exit(1);
+}
+await run(root, []);
+// Tests that timed out may still hold the event loop
+process.exit(0);
@@ -0,0 +1,151 @@
+// runtime/phantom.mjs
+//
+// The 'phantom-vite' module of *.phantom.js tests. Pages live in the engine
+// of the 'phantom-vite test' process, which serves them to this module at
+// PHANTOM_TEST_API; describe and test are the globals phantom-test.mjs
+// defines, re-exported so tests can import them.
+
+async function call(method, params = {}) {
+  const api = process.env.PHANTOM_TEST_API;
+  if (!api) throw new Error("phantom-vite: run this file with 'phantom-vite test'");
+  const res = await fetch(`${api}/call`, {
+    method: 'POST',
+    headers: { 'content-type': 'application/json' },
+    body: JSON.stringify({ method, ...params }),
+  });
+  const body = await res.json();
+  if (!res.ok) throw Object.assign(new Error(body.error), { kind: body.error_kind });
+  return body.result;
+}
+
+// Page is a page of the engine. Pages a test opens are closed when it ends.
+class Page {
+  constructor(id) {
+    this.id = id;
+  }
+
+  #call(method, params) {
+    return call(method, { page: this.id, ...params });
+  }
+
+  async goto(url) {
+    await this.#call('goto', { url });
+  }
+
+  title() {
+    return this.#call('title');
+  }
+
+  url() {
+    return this.#call('url');
+  }
+
+  content() {
+    return this.#call('content');
+  }
+
+  // evaluate runs an expression, or a function without arguments, in the
+  // page and returns its JSON value
+  evaluate(script) {
+    if (typeof script === 'function') script = `(${script})()`;
+    return this.#call('evaluate', { script });
+  }
+
+  async click(selector) {
+    await this.#call('click', { selector });
+  }
+
+  async type(selector, text) {
+    await this.#call('type', { selector, text });
+  }
+
+  async fill(selector, text) {
+    await this.#call('fill', { selector, text });
+  }
+
+  async waitForSelector(selector, { timeout } = {}) {
+    await this.#call('waitForSelector', { selector, timeout });
+  }
+
+  // screenshot saves the page at path, or options.path; the extension
+  // picks the format: .png, .jpg or .webp
+  screenshot(path, options = {}) {
+    if (typeof path === 'object') [path, options] = [path.path, path];
+    return this.#call('screenshot', { path, full_page: Boolean(options.fullPage) });
+  }
+
+  async close() {
+    await this.#call('close');
+  }
+}
+
+export const phantom = {
+  // goto opens a page at url
+  async goto(url) {
+    return new Page(await call('goto', { url }));
+  },
+};
+
+export const describe = (...args) => globalThis.describe(...args);
+export const test = Object.assign((...args) => globalThis.test(...args), {
+  skip: (...args) => globalThis.test.skip(...args),
+});
+export const it = test;
+
+// ---------------------------------------------------------------------------
+// expect
+
+export class AssertionError extends Error {
+  name = 'AssertionError';
+}
+
+function show(value) {
+  if (typeof value === 'string') return JSON.stringify(value);
+  if (typeof value === 'function') return `[Function ${value.name || 'anonymous'}]`;
+  if (value instanceof RegExp || typeof value === 'bigint' || typeof value === 'symbol') return String(value);
+  if (value === undefined || Number.isNaN(value)) return String(value);
+  try {
+    return JSON.stringify(value);
+  } catch {
+    return String(value);
+  }
+}
+
+function equal(a, b) {
+  if (Object.is(a, b)) return true;
+  if (typeof a !== 'object' || typeof b !== 'object' || a === null || b === null) return false;
+  if (Array.isArray(a) !== Array.isArray(b) || Object.getPrototypeOf(a) !== Object.getPrototypeOf(b)) return false;
+  if (a instanceof Date) return a.getTime() === b.getTime();
+  const keys = Object.keys(a);
+  if (keys.length !== Object.keys(b).length) return false;
+  return keys.every((key) => Object.hasOwn(b, key) && equal(a[key], b[key]));
+}
+
+function matchers(actual, negated) {
+  const check = (pass, description, ...expected) => {
+    if (pass !== negated) return;
+    let message = `expected ${show(actual)} ${negated ? 'not ' : ''}${description}`;
+    if (expected.length) message += ` ${show(expected[0])}`;
+    throw new AssertionError(message);
+  };
+  return {
+    toBe: (expected) => check(Object.is(actual, expected), 'to be', expected),
+    toEqual: (expected) => check(equal(actual, expected), 'to equal', expected),
+    toBeTruthy: () => check(Boolean(actual), 'to be truthy'),
+    toBeFalsy: () => check(!actual, 'to be falsy'),
+    toBeNull: () => check(actual === null, 'to be null'),
+    toBeUndefined: () => check(actual === undefined, 'to be undefined'),
+    toBeDefined: () => check(actual !== undefined, 'to be defined'),
+    toContain: (item) => check(actual?.includes?.(item) === true, 'to contain', item),
+    toMatch: (pattern) =>
+      check(typeof actual === 'string' && (pattern instanceof RegExp ? pattern.test(actual) : actual.includes(pattern)), 'to match', pattern),
+    toHaveLength: (length) => check(actual?.length === length, 'to have length', length),
+    toBeGreaterThan: (n) => check(actual > n, 'to be greater than', n),
+    toBeLessThan: (n) => check(actual < n, 'to be less than', n),
+  };
+}
+
+// expect returns the matchers of actual; expect(x).not negates them
+export function expect(actual) {
+  return Object.assign(matchers(actual, false), { not: matchers(actual, true) });
+}